@@ -28,3 +28,68 @@ const API_TYPE_SOAP = "SOAP"
 const API_TYPE_SSE = "SSE"
 const API_TYPE_WS = "WS"
 const API_TYPE_WEBSUB = "WEBSUB"
+
+const GRAPHQL_QUERY = "QUERY"
+const GRAPHQL_MUTATION = "MUTATION"
+const GRAPHQL_SUBSCRIPTION = "SUBSCRIPTION"
+
+const ASYNC_PUBLISH = "PUBLISH"
+const ASYNC_SUBSCRIBE = "SUBSCRIBE"
+
+const POLICY_ADD_HEADER = "AddHeader"
+const POLICY_SET_HEADER = "SetHeader"
+const POLICY_REMOVE_HEADER = "RemoveHeader"
+const POLICY_REQUEST_MIRROR = "RequestMirror"
+const POLICY_REQUEST_REDIRECT = "RequestRedirect"
+const POLICY_INTERCEPTOR = "Interceptor"
+const POLICY_BACKEND_JWT = "BackendJwt"
+
+const DP_GROUP = "dp.wso2.com"
+const API_KIND = "API"
+const BACKEND_KIND = "Backend"
+const SERVICE_KIND = "Service"
+const CONFIGMAP_KIND = "ConfigMap"
+const SECRET_KIND = "Secret"
+
+const DP_V1ALPHA1 = DP_GROUP + "/v1alpha1"
+const DP_V1ALPHA2 = DP_GROUP + "/v1alpha2"
+const DP_V1ALPHA3 = DP_GROUP + "/v1alpha3"
+
+const GATEWAY_GROUP = "gateway.networking.k8s.io"
+const GATEWAY_V1 = GATEWAY_GROUP + "/v1"
+
+// MAX_RULES_PER_ROUTE is the maximum number of rules of a Gateway API route.
+const MAX_RULES_PER_ROUTE = 16
+
+const HTTPROUTE_KIND = "HTTPRoute"
+const GRPCROUTE_KIND = "GRPCRoute"
+const GQLROUTE_KIND = "GQLRoute"
+const RESOURCE_KIND = "Resource"
+const SCOPE_KIND = "Scope"
+const AUTHENTICATION_KIND = "Authentication"
+const RATELIMIT_POLICY_KIND = "RateLimitPolicy"
+const AI_PROVIDER_KIND = "AIProvider"
+const AI_RATELIMIT_POLICY_KIND = "AIRateLimitPolicy"
+const API_POLICY_KIND = "APIPolicy"
+const INTERCEPTOR_SERVICE_KIND = "InterceptorService"
+const BACKEND_JWT_KIND = "BackendJWT"
+
+const AUTH_TYPE_OAUTH2 = "OAuth2"
+const AUTH_TYPE_API_KEY = "APIKey"
+const AUTH_TYPE_MTLS = "mTLS"
+const AUTH_TYPE_JWT = "JWT"
+
+const RATELIMIT_UNIT_MINUTE = "Minute"
+const RATELIMIT_UNIT_HOUR = "Hour"
+const RATELIMIT_UNIT_DAY = "Day"
+
+const AI_PROVIDER_OPENAI = "OpenAI"
+const AI_PROVIDER_AZURE_OPENAI = "AzureOpenAI"
+const AI_PROVIDER_MISTRAL = "Mistral"
+
+const BACKEND_JWT_ENCODING_BASE64 = "Base64"
+const BACKEND_JWT_ENCODING_BASE64URL = "Base64url"
+
+const BACKEND_JWT_ALGORITHM_SHA256_RSA = "SHA256withRSA"
+const BACKEND_JWT_ALGORITHM_SHA384_RSA = "SHA384withRSA"
+const BACKEND_JWT_ALGORITHM_SHA512_RSA = "SHA512withRSA"
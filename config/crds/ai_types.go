@@ -0,0 +1,88 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package crds
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// AIProvider represents the APK AIProvider custom resource
+type AIProvider struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          AIProviderSpec `json:"spec,omitempty"`
+}
+
+// AIProviderSpec defines where the model and the token usage are found in the AI provider traffic
+type AIProviderSpec struct {
+	ProviderName       string          `json:"providerName"`
+	ProviderAPIVersion string          `json:"providerAPIVersion"`
+	Organization       string          `json:"organization"`
+	SupportedModels    []string        `json:"supportedModels,omitempty"`
+	RequestModel       ValueDetails    `json:"requestModel"`
+	ResponseModel      ValueDetails    `json:"responseModel"`
+	RateLimitFields    RateLimitFields `json:"rateLimitFields"`
+}
+
+// ValueDetails holds the location and the path of a value
+type ValueDetails struct {
+	In    string `json:"in"`
+	Value string `json:"value"`
+}
+
+// RateLimitFields holds the locations of the token usage counts
+type RateLimitFields struct {
+	PromptTokens     ValueDetails `json:"promptTokens"`
+	CompletionTokens ValueDetails `json:"completionToken"`
+	TotalTokens      ValueDetails `json:"totalToken"`
+}
+
+// AIRateLimitPolicy represents the APK AIRateLimitPolicy custom resource
+type AIRateLimitPolicy struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          AIRateLimitPolicySpec `json:"spec,omitempty"`
+}
+
+// AIRateLimitPolicySpec defines the token and request limits applied to the targeted backend
+type AIRateLimitPolicySpec struct {
+	Default   *AIRateLimit                                            `json:"default,omitempty"`
+	Override  *AIRateLimit                                            `json:"override,omitempty"`
+	TargetRef gwapiv1alpha2.LocalPolicyTargetReferenceWithSectionName `json:"targetRef"`
+}
+
+// AIRateLimit holds the token and request count limits
+type AIRateLimit struct {
+	TokenCount   *TokenCount   `json:"tokenCount,omitempty"`
+	RequestCount *RequestCount `json:"requestCount,omitempty"`
+}
+
+// TokenCount holds the number of tokens allowed per time unit
+type TokenCount struct {
+	Unit               string `json:"unit"`
+	RequestTokenCount  uint32 `json:"requestTokenCount,omitempty"`
+	ResponseTokenCount uint32 `json:"responseTokenCount,omitempty"`
+	TotalTokenCount    uint32 `json:"totalTokenCount,omitempty"`
+}
+
+// RequestCount holds the number of requests allowed per time unit
+type RequestCount struct {
+	RequestsPerUnit uint32 `json:"requestsPerUnit"`
+	Unit            string `json:"unit"`
+}
@@ -0,0 +1,56 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package crds
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// API represents the APK API custom resource
+type API struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          APISpec `json:"spec,omitempty"`
+}
+
+// APISpec defines the API details and the routes serving it in each environment
+type APISpec struct {
+	APIName           string      `json:"apiName"`
+	APIVersion        string      `json:"apiVersion"`
+	IsDefaultVersion  bool        `json:"isDefaultVersion,omitempty"`
+	DefinitionFileRef string      `json:"definitionFileRef,omitempty"`
+	DefinitionPath    string      `json:"definitionPath,omitempty"`
+	Production        []EnvConfig `json:"production,omitempty"`
+	Sandbox           []EnvConfig `json:"sandbox,omitempty"`
+	APIType           string      `json:"apiType"`
+	BasePath          string      `json:"basePath"`
+	Organization      string      `json:"organization"`
+	APIProperties     []Property  `json:"apiProperties,omitempty"`
+	Environment       string      `json:"environment,omitempty"`
+}
+
+// EnvConfig holds the names of the routes serving an environment
+type EnvConfig struct {
+	RouteRefs []string `json:"routeRefs"`
+}
+
+// Property holds a custom property of an API
+type Property struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
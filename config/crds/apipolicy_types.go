@@ -0,0 +1,72 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package crds
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// APIPolicy represents the APK APIPolicy custom resource
+type APIPolicy struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          APIPolicySpec `json:"spec,omitempty"`
+}
+
+// APIPolicySpec defines the policies applied to the targeted API or route rule
+type APIPolicySpec struct {
+	Default   *PolicySpec                                             `json:"default,omitempty"`
+	Override  *PolicySpec                                             `json:"override,omitempty"`
+	TargetRef gwapiv1alpha2.LocalPolicyTargetReferenceWithSectionName `json:"targetRef"`
+}
+
+// PolicySpec holds the policy configurations
+type PolicySpec struct {
+	RequestInterceptors  []InterceptorReference `json:"requestInterceptors,omitempty"`
+	ResponseInterceptors []InterceptorReference `json:"responseInterceptors,omitempty"`
+	BackendJWTPolicy     *BackendJWTReference   `json:"backendJwtPolicy,omitempty"`
+	CORSPolicy           *CORSPolicy            `json:"cORSPolicy,omitempty"`
+	AIProvider           *AIProviderReference   `json:"aiProvider,omitempty"`
+}
+
+// InterceptorReference holds the reference to an InterceptorService
+type InterceptorReference struct {
+	Name string `json:"name"`
+}
+
+// BackendJWTReference holds the reference to a BackendJWT
+type BackendJWTReference struct {
+	Name string `json:"name"`
+}
+
+// CORSPolicy holds the CORS configurations
+type CORSPolicy struct {
+	Enabled                       bool     `json:"enabled"`
+	AccessControlAllowCredentials bool     `json:"accessControlAllowCredentials,omitempty"`
+	AccessControlAllowOrigins     []string `json:"accessControlAllowOrigins,omitempty"`
+	AccessControlAllowHeaders     []string `json:"accessControlAllowHeaders,omitempty"`
+	AccessControlAllowMethods     []string `json:"accessControlAllowMethods,omitempty"`
+	AccessControlExposeHeaders    []string `json:"accessControlExposeHeaders,omitempty"`
+	AccessControlMaxAge           *int     `json:"accessControlMaxAge,omitempty"`
+}
+
+// AIProviderReference holds the reference to the AIProvider of the API
+type AIProviderReference struct {
+	Name string `json:"name"`
+}
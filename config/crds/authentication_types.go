@@ -0,0 +1,89 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package crds
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// Authentication represents the APK Authentication custom resource
+type Authentication struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          AuthenticationSpec `json:"spec,omitempty"`
+}
+
+// AuthenticationSpec defines the authentication applied to the targeted API or route rule
+type AuthenticationSpec struct {
+	Default   *AuthSpec                                               `json:"default,omitempty"`
+	Override  *AuthSpec                                               `json:"override,omitempty"`
+	TargetRef gwapiv1alpha2.LocalPolicyTargetReferenceWithSectionName `json:"targetRef"`
+}
+
+// AuthSpec holds the enabled authentication types
+type AuthSpec struct {
+	Disabled  *bool    `json:"disabled,omitempty"`
+	AuthTypes *APIAuth `json:"authTypes,omitempty"`
+}
+
+// APIAuth holds the configurations of each authentication type
+type APIAuth struct {
+	OAuth2    *OAuth2Auth      `json:"oauth2,omitempty"`
+	APIKey    *APIKeyAuth      `json:"apiKey,omitempty"`
+	MutualSSL *MutualSSLConfig `json:"mtls,omitempty"`
+	JWT       *JWTAuth         `json:"jwt,omitempty"`
+}
+
+// OAuth2Auth holds the OAuth2 authentication configurations
+type OAuth2Auth struct {
+	Required            string `json:"required,omitempty"`
+	Disabled            bool   `json:"disabled,omitempty"`
+	Header              string `json:"header,omitempty"`
+	SendTokenToUpstream bool   `json:"sendTokenToUpstream,omitempty"`
+}
+
+// APIKeyAuth holds the API key authentication configurations
+type APIKeyAuth struct {
+	Required string   `json:"required,omitempty"`
+	Disabled bool     `json:"disabled,omitempty"`
+	Keys     []APIKey `json:"keys,omitempty"`
+}
+
+// APIKey holds the location and the name of an API key
+type APIKey struct {
+	In                  string `json:"in"`
+	Name                string `json:"name"`
+	SendTokenToUpstream bool   `json:"sendTokenToUpstream,omitempty"`
+}
+
+// MutualSSLConfig holds the mutual SSL authentication configurations
+type MutualSSLConfig struct {
+	Required      string      `json:"required,omitempty"`
+	Disabled      bool        `json:"disabled,omitempty"`
+	SecretRefs    []RefConfig `json:"secretRefs,omitempty"`
+	ConfigMapRefs []RefConfig `json:"configMapRefs,omitempty"`
+}
+
+// JWTAuth holds the JWT authentication configurations
+type JWTAuth struct {
+	Disabled            bool     `json:"disabled,omitempty"`
+	Header              string   `json:"header,omitempty"`
+	SendTokenToUpstream bool     `json:"sendTokenToUpstream,omitempty"`
+	Audience            []string `json:"audience,omitempty"`
+}
@@ -0,0 +1,82 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package crds
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Backend represents the APK Backend custom resource
+type Backend struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          BackendSpec `json:"spec,omitempty"`
+}
+
+// BackendSpec defines the services, protocol and connection settings of a backend
+type BackendSpec struct {
+	Services []BackendService `json:"services,omitempty"`
+	Protocol string           `json:"protocol"`
+	BasePath string           `json:"basePath,omitempty"`
+	TLS      *TLSConfig       `json:"tls,omitempty"`
+	Security *SecurityConfig  `json:"security,omitempty"`
+}
+
+// BackendService holds the host and port of a backend service
+type BackendService struct {
+	Host string `json:"host"`
+	Port uint32 `json:"port"`
+}
+
+// TLSConfig holds the certificate references used to connect to a backend over TLS
+type TLSConfig struct {
+	SecretRef    *RefConfig `json:"secretRef,omitempty"`
+	ConfigMapRef *RefConfig `json:"configMapRef,omitempty"`
+	AllowedSANs  []string   `json:"allowedSANs,omitempty"`
+}
+
+// SecurityConfig holds the security configurations used to connect to a backend
+type SecurityConfig struct {
+	Basic  *BasicSecurityConfig  `json:"basic,omitempty"`
+	APIKey *APIKeySecurityConfig `json:"apiKey,omitempty"`
+}
+
+// BasicSecurityConfig holds the secret reference of the basic auth credentials
+type BasicSecurityConfig struct {
+	SecretRef SecretRef `json:"secretRef"`
+}
+
+// SecretRef holds the secret name and the keys of the basic auth credentials
+type SecretRef struct {
+	Name        string `json:"name"`
+	UsernameKey string `json:"usernameKey"`
+	PasswordKey string `json:"passwordKey"`
+}
+
+// APIKeySecurityConfig holds the API key name, location and secret reference of its value
+type APIKeySecurityConfig struct {
+	In        string   `json:"in"`
+	Name      string   `json:"name"`
+	ValueFrom ValueRef `json:"valueFrom"`
+}
+
+// ValueRef holds the secret name and the key of a value
+type ValueRef struct {
+	Name     string `json:"name"`
+	ValueKey string `json:"valueKey"`
+}
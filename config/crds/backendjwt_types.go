@@ -0,0 +1,37 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package crds
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackendJWT represents the APK BackendJWT custom resource
+type BackendJWT struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          BackendJWTSpec `json:"spec,omitempty"`
+}
+
+// BackendJWTSpec defines the JWT generated and sent to the backend
+type BackendJWTSpec struct {
+	Encoding         string `json:"encoding,omitempty"`
+	Header           string `json:"header,omitempty"`
+	SigningAlgorithm string `json:"signingAlgorithm,omitempty"`
+	TokenTTL         uint32 `json:"tokenTTL,omitempty"`
+}
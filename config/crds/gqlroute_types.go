@@ -0,0 +1,59 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package crds
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// GQLRoute represents the APK GQLRoute custom resource
+type GQLRoute struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          GQLRouteSpec `json:"spec,omitempty"`
+}
+
+// GQLRouteSpec defines the GraphQL operations routed to the backend of a GraphQL API
+type GQLRouteSpec struct {
+	gwapiv1.CommonRouteSpec `json:",inline"`
+	Hostnames               []gwapiv1.Hostname       `json:"hostnames,omitempty"`
+	BackendRefs             []gwapiv1.HTTPBackendRef `json:"backendRefs,omitempty"`
+	Rules                   []GQLRouteRules          `json:"rules,omitempty"`
+}
+
+// GQLRouteRules holds the matches of a GraphQL operation and the filters applied to it
+type GQLRouteRules struct {
+	Name    *gwapiv1.SectionName `json:"name,omitempty"`
+	Matches []GQLRouteMatch      `json:"matches,omitempty"`
+	Filters []GQLRouteFilter     `json:"filters,omitempty"`
+}
+
+// GQLType is the type of a GraphQL operation, i.e. QUERY, MUTATION or SUBSCRIPTION
+type GQLType string
+
+// GQLRouteMatch matches a field of a GraphQL operation type
+type GQLRouteMatch struct {
+	Type *GQLType `json:"type,omitempty"`
+	Path *string  `json:"path,omitempty"`
+}
+
+// GQLRouteFilter refers an extension applied to the matching GraphQL operations, such as a Scope
+type GQLRouteFilter struct {
+	ExtensionRef *gwapiv1.LocalObjectReference `json:"extensionRef,omitempty"`
+}
@@ -0,0 +1,40 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package crds
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InterceptorService represents the APK InterceptorService custom resource
+type InterceptorService struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          InterceptorServiceSpec `json:"spec,omitempty"`
+}
+
+// InterceptorServiceSpec defines the interceptor backend and the parts of the request or response sent to it
+type InterceptorServiceSpec struct {
+	BackendRef BackendReference `json:"backendRef"`
+	Includes   []string         `json:"includes,omitempty"`
+}
+
+// BackendReference holds the reference to a Backend
+type BackendReference struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,48 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package crds
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// RateLimitPolicy represents the APK RateLimitPolicy custom resource
+type RateLimitPolicy struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          RateLimitPolicySpec `json:"spec,omitempty"`
+}
+
+// RateLimitPolicySpec defines the rate limit applied to the targeted API or route rule
+type RateLimitPolicySpec struct {
+	Default   *RateLimitAPIPolicy                                     `json:"default,omitempty"`
+	Override  *RateLimitAPIPolicy                                     `json:"override,omitempty"`
+	TargetRef gwapiv1alpha2.LocalPolicyTargetReferenceWithSectionName `json:"targetRef"`
+}
+
+// RateLimitAPIPolicy holds the rate limit configurations
+type RateLimitAPIPolicy struct {
+	API *APIRateLimitPolicy `json:"api,omitempty"`
+}
+
+// APIRateLimitPolicy holds the number of requests allowed per time unit
+type APIRateLimitPolicy struct {
+	RequestsPerUnit uint32 `json:"requestsPerUnit"`
+	Unit            string `json:"unit"`
+}
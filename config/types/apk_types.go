@@ -110,13 +110,14 @@ type Certificate struct {
 	Key  string `json:"key"`
 }
 
-// AuthConfiguration represents the security configurations made for the API security
+// AuthConfiguration represents the security configurations made for the API security.
+// An authentication type is enabled unless enabled is set to false.
 type AuthConfiguration struct {
 	Required          string        `yaml:"required,omitempty"`
 	AuthType          string        `yaml:"authType,omitempty"`
 	HeaderName        string        `yaml:"headerName,omitempty"`
 	SendTokenUpStream bool          `yaml:"sendTokenToUpstream,omitempty"`
-	Enabled           bool          `yaml:"enabled"`
+	Enabled           *bool         `yaml:"enabled,omitempty"`
 	QueryParamName    string        `yaml:"queryParamName,omitempty"`
 	HeaderEnabled     bool          `yaml:"headerEnable,omitempty"`
 	QueryParamEnable  bool          `yaml:"queryParamEnable,omitempty"`
@@ -197,11 +198,12 @@ type OperationPolicies struct {
 }
 
 // Operation represents an API operation with target, verb, scopes, security, and associated policies.
+// An operation is secured unless secured is set to false.
 type Operation struct {
 	Target                 string                  `yaml:"target,omitempty"`
 	Verb                   string                  `yaml:"verb,omitempty"`
 	Scopes                 []string                `yaml:"scopes,omitempty"`
-	Secured                bool                    `yaml:"secured,omitempty"`
+	Secured                *bool                   `yaml:"secured,omitempty"`
 	EndpointConfigurations *EndpointConfigurations `yaml:"endpointConfigurations,omitempty"`
 	OperationPolicies      *OperationPolicies      `yaml:"operationPolicies,omitempty"`
 	RateLimit              *RateLimit              `yaml:"rateLimit,omitempty"`
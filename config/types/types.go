@@ -22,4 +22,12 @@ type GatewayConfigurations struct {
 	Name         string `json:"name"`
 	ListenerName string `json:"listenerName"`
 	Hostname     string `json:"hostname"`
+	VHosts       *VHost `json:"vhosts,omitempty"`
+}
+
+// RouteReference identifies a generated route and the names of its rules.
+type RouteReference struct {
+	Kind      string   `json:"kind"`
+	Name      string   `json:"name"`
+	RuleNames []string `json:"ruleNames"`
 }
@@ -18,8 +18,11 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
 
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
 	"gopkg.in/yaml.v2"
 )
 
@@ -61,3 +64,121 @@ func (ec *EndpointConfiguration) UnmarshalYAML(unmarshal func(interface{}) error
 
 	return nil
 }
+
+// Custom unmarshal logic for EndpointConfiguration in JSON form
+func (ec *EndpointConfiguration) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Endpoint       json.RawMessage
+		EndCertificate EndpointCertificate
+		EndSecurity    EndpointSecurity
+		AIRatelimit    AIRatelimit
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	// Check the endpoint type
+	var url string
+	var k8sService K8sService
+	if err := json.Unmarshal(raw.Endpoint, &url); err == nil {
+		ec.Endpoint = EndpointURL(url)
+	} else if err := json.Unmarshal(raw.Endpoint, &k8sService); err == nil {
+		ec.Endpoint = k8sService
+	} else {
+		return fmt.Errorf("unsupported endpoint type: %s", string(raw.Endpoint))
+	}
+
+	// Assign other fields
+	ec.EndCertificate = raw.EndCertificate
+	ec.EndSecurity = raw.EndSecurity
+	ec.AIRatelimit = raw.AIRatelimit
+
+	return nil
+}
+
+// Custom unmarshal logic for OperationPolicy
+func (op *OperationPolicy) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	// Use a raw map to read the YAML structure
+	var raw struct {
+		PolicyName    string      `yaml:"policyName,omitempty"`
+		PolicyVersion string      `yaml:"policyVersion,omitempty"`
+		PolicyID      string      `yaml:"policyId,omitempty"`
+		Parameters    interface{} `yaml:"parameters,omitempty"`
+	}
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	parameters, err := decodeParameters(raw.PolicyName, raw.Parameters != nil, func(out interface{}) error {
+		bytes, err := yaml.Marshal(raw.Parameters)
+		if err != nil {
+			return err
+		}
+		return yaml.Unmarshal(bytes, out)
+	})
+	if err != nil {
+		return err
+	}
+
+	op.PolicyName = raw.PolicyName
+	op.PolicyVersion = raw.PolicyVersion
+	op.PolicyID = raw.PolicyID
+	op.Parameters = parameters
+
+	return nil
+}
+
+// Custom unmarshal logic for OperationPolicy in JSON form
+func (op *OperationPolicy) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		PolicyName    string
+		PolicyVersion string
+		PolicyID      string
+		Parameters    json.RawMessage
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	hasParameters := len(raw.Parameters) > 0 && string(raw.Parameters) != "null"
+	parameters, err := decodeParameters(raw.PolicyName, hasParameters, func(out interface{}) error {
+		return json.Unmarshal(raw.Parameters, out)
+	})
+	if err != nil {
+		return err
+	}
+
+	op.PolicyName = raw.PolicyName
+	op.PolicyVersion = raw.PolicyVersion
+	op.PolicyID = raw.PolicyID
+	op.Parameters = parameters
+
+	return nil
+}
+
+// decodeParameters decodes the policy parameters into the concrete Parameter type of the given policy name.
+func decodeParameters(policyName string, hasParameters bool, decode func(interface{}) error) (Parameter, error) {
+	var parameter interface{}
+	switch policyName {
+	case constants.POLICY_ADD_HEADER, constants.POLICY_SET_HEADER, constants.POLICY_REMOVE_HEADER:
+		parameter = &Header{}
+	case constants.POLICY_REQUEST_MIRROR:
+		parameter = &URLList{}
+	case constants.POLICY_REQUEST_REDIRECT:
+		parameter = &RedirectPolicy{}
+	case constants.POLICY_INTERCEPTOR:
+		parameter = &InterceptorService{}
+	case constants.POLICY_BACKEND_JWT:
+		parameter = &BackendJWT{}
+	default:
+		return nil, fmt.Errorf("unsupported policy name: %q", policyName)
+	}
+
+	if !hasParameters {
+		return nil, nil
+	}
+	if err := decode(parameter); err != nil {
+		return nil, fmt.Errorf("invalid parameters for policy %q: %v", policyName, err)
+	}
+	return reflect.ValueOf(parameter).Elem().Interface().(Parameter), nil
+}
@@ -0,0 +1,58 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/bundle"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/output"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+)
+
+func main() {
+	// Read the configuration from the file
+	apkConf, err := utils.ReadAPKConf("./examples/assets/example.apk-conf")
+	if err != nil {
+		log.Fatalf("Failed to read apk-conf: %v", err)
+	}
+	organization := types.Organization{
+		Name: "wso2",
+	}
+	gatewayConfig := types.GatewayConfigurations{
+		Name:         "wso2-apim",
+		ListenerName: "wso2-apim-gateway",
+		Hostname:     "wso2-apim",
+	}
+
+	// Generate all resources of the API
+	resources, err := bundle.Generate(*apkConf, organization, gatewayConfig, bundle.Options{})
+	if err != nil {
+		log.Fatalf("Failed to generate resources: %v", err)
+	}
+	for _, warning := range resources.Warnings {
+		log.Println(warning.Error())
+	}
+
+	// Write the resources as a multi-document YAML stream in the order they are to be applied
+	if err := output.WriteYAML(os.Stdout, output.Objects(resources)); err != nil {
+		log.Fatalf("Failed to write resources: %v", err)
+	}
+}
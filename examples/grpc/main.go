@@ -33,7 +33,10 @@ func main() {
 	gen := grpc_generator.Generator()
 
 	// Read the configuration from the file
-	apkConf := utils.ReadAPKConf("./examples/assets/example.apk-conf")
+	apkConf, err := utils.ReadAPKConf("./examples/assets/example.apk-conf")
+	if err != nil {
+		log.Fatalf("Failed to read apk-conf: %v", err)
+	}
 	organization := types.Organization{
 		Name: "wso2",
 	}
@@ -43,13 +46,18 @@ func main() {
 		Hostname:     "wso2-apim",
 	}
 
+	uniqueId := "unique-route-id"
+
 	// Get the endpoint to use
-	endpoints := utils.GetEndpoints(*apkConf)
+	endpoints := utils.GetEndpoints(*apkConf, uniqueId)
 	// If endpoints has production type
 	if endpoint, ok := endpoints[constants.PRODUCTION_TYPE]; ok {
-		httpRoute, err := gen.GenerateGRPCRoute(*apkConf, organization, gatewayConfig, *apkConf.Operations, &endpoint, constants.PRODUCTION_TYPE, "unique-route-id", 1)
-		if err != nil {
-			log.Fatalf("Failed to generate http route: %v", err)
+		httpRoute, diags := gen.GenerateGRPCRoute(*apkConf, organization, gatewayConfig, *apkConf.Operations, &endpoint, constants.PRODUCTION_TYPE, uniqueId, 1)
+		for _, warning := range diags.Warnings() {
+			log.Println(warning.Error())
+		}
+		if diags.HasErrors() {
+			log.Fatalf("Failed to generate http route: %v", diags.Err())
 		}
 
 		jsonBytes, _ := json.MarshalIndent(httpRoute, "", " ")
@@ -25,6 +25,7 @@ import (
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/validation"
 
 	http_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/http"
 )
@@ -33,7 +34,13 @@ func main() {
 	gen := http_generator.Generator()
 
 	// Read the configuration from the file
-	apkConf := utils.ReadAPKConf("./examples/assets/example.apk-conf")
+	apkConf, err := utils.ReadAPKConf("./examples/assets/example.apk-conf")
+	if err != nil {
+		log.Fatalf("Failed to read apk-conf: %v", err)
+	}
+	if diags := validation.Validator().Validate(*apkConf); diags.HasErrors() {
+		log.Fatalf("Invalid apk-conf: %v", diags.Err())
+	}
 	organization := types.Organization{
 		Name: "wso2",
 	}
@@ -43,13 +50,18 @@ func main() {
 		Hostname:     "wso2-apim",
 	}
 
+	uniqueId := "unique-route-id"
+
 	// Get the endpoint to use
-	endpoints := utils.GetEndpoints(*apkConf)
+	endpoints := utils.GetEndpoints(*apkConf, uniqueId)
 	// If endpoints has production type
 	if endpoint, ok := endpoints[constants.PRODUCTION_TYPE]; ok {
-		httpRoute, err := gen.GenerateHTTPRoute(*apkConf, organization, gatewayConfig, *apkConf.Operations, &endpoint, constants.PRODUCTION_TYPE, "unique-route-id", 1)
-		if err != nil {
-			log.Fatalf("Failed to generate http route: %v", err)
+		httpRoute, diags := gen.GenerateHTTPRoute(*apkConf, organization, gatewayConfig, *apkConf.Operations, &endpoint, constants.PRODUCTION_TYPE, uniqueId, 1)
+		for _, warning := range diags.Warnings() {
+			log.Println(warning.Error())
+		}
+		if diags.HasErrors() {
+			log.Fatalf("Failed to generate http route: %v", diags.Err())
 		}
 
 		jsonBytes, _ := json.MarshalIndent(httpRoute, "", " ")
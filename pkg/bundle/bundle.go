@@ -0,0 +1,282 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package bundle
+
+import (
+	"slices"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/validation"
+
+	ai_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/ai"
+	api_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/api"
+	apipolicy_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/apipolicy"
+	authentication_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/authentication"
+	backend_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/backend"
+	backendjwt_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/backendjwt"
+	graphql_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/graphql"
+	grpc_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/grpc"
+	http_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/http"
+	interceptor_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/interceptor"
+	ratelimit_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/ratelimit"
+	soap_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/soap"
+	sse_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/sse"
+	websocket_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/websocket"
+	websub_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/websub"
+
+	corev1 "k8s.io/api/core/v1"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// supportedAPITypes holds the API types supported by the bundle generation.
+var supportedAPITypes = []string{constants.API_TYPE_REST, constants.API_TYPE_GRPC, constants.API_TYPE_GRAPHQL, constants.API_TYPE_SOAP, constants.API_TYPE_WS, constants.API_TYPE_SSE, constants.API_TYPE_WEBSUB}
+
+// Options holds the options of the bundle generation.
+type Options struct {
+	// UniqueID names the generated resources, defaulting to the id derived from the API and the organization.
+	UniqueID string
+	// Definition is the API definition stored in the ConfigMap referred by the API, when a definition path is configured.
+	Definition []byte
+	// EnableGatewayCORS applies the CORS configuration with Gateway API filters instead of the APIPolicy.
+	EnableGatewayCORS bool
+	// SOAPToRESTInterceptor exposes the operations of a SOAP API as REST resources, with the interceptor converting
+	// the payloads to and from SOAP envelopes. The SOAPAction of the operations is looked up in the WSDL definition.
+	SOAPToRESTInterceptor *types.InterceptorService
+	// SkipValidation skips validating the APK configuration before generating the resources.
+	SkipValidation bool
+}
+
+// Bundle holds the resources generated for an API.
+type Bundle struct {
+	API                 *crds.API                  `json:"api,omitempty"`
+	HTTPRoutes          []*gwapiv1.HTTPRoute       `json:"httpRoutes,omitempty"`
+	GRPCRoutes          []*gwapiv1.GRPCRoute       `json:"grpcRoutes,omitempty"`
+	GQLRoutes           []*crds.GQLRoute           `json:"gqlRoutes,omitempty"`
+	Scopes              []*crds.Scope              `json:"scopes,omitempty"`
+	Backends            []*crds.Backend            `json:"backends,omitempty"`
+	Authentications     []*crds.Authentication     `json:"authentications,omitempty"`
+	RateLimitPolicies   []*crds.RateLimitPolicy    `json:"rateLimitPolicies,omitempty"`
+	APIPolicies         []*crds.APIPolicy          `json:"apiPolicies,omitempty"`
+	InterceptorServices []*crds.InterceptorService `json:"interceptorServices,omitempty"`
+	BackendJWTs         []*crds.BackendJWT         `json:"backendJwts,omitempty"`
+	AIProvider          *crds.AIProvider           `json:"aiProvider,omitempty"`
+	AIRateLimitPolicies []*crds.AIRateLimitPolicy  `json:"aiRateLimitPolicies,omitempty"`
+	ConfigMaps          []*corev1.ConfigMap        `json:"configMaps,omitempty"`
+	// Warnings holds the warnings reported while generating the resources.
+	Warnings diagnostics.Diagnostics `json:"warnings,omitempty"`
+}
+
+// Generate generates the bundle of all resources of the API, with routes of the type matching the API type for
+// the production and sandbox environments with endpoints, split to stay within the Gateway API limit of rules per route.
+// The problems found in any of the resources are returned together as a single error holding their diagnostics.
+func Generate(apkConf types.APKConf, organization types.Organization, gatewayConfig types.GatewayConfigurations, opts Options) (*Bundle, error) {
+	var diags diagnostics.Diagnostics
+	if !opts.SkipValidation {
+		diags = validation.Validator().Validate(apkConf)
+		if diags.HasErrors() {
+			return nil, diags.Err()
+		}
+	}
+	apiType := apkConf.Type
+	if apiType == "" {
+		apiType = constants.API_TYPE_REST
+	}
+	if !slices.Contains(supportedAPITypes, apiType) {
+		diags.Errorf("type", "unsupported api type for the bundle generation: %q", apkConf.Type)
+		return nil, diags.Err()
+	}
+	uniqueId := opts.UniqueID
+	if uniqueId == "" {
+		uniqueId = utils.GetUniqueId(apkConf, organization)
+	}
+
+	soapGen := soap_generator.Generator()
+	if apiType == constants.API_TYPE_SOAP {
+		if len(opts.Definition) > 0 {
+			wsdlOperations, err := soap_generator.ParseWSDL(opts.Definition)
+			if err != nil {
+				diags = append(diags, diagnostics.FromError("definitionPath", err)...)
+				return nil, diags.Err()
+			}
+			soapGen.WSDLOperations = wsdlOperations
+		}
+		if opts.SOAPToRESTInterceptor != nil {
+			soapGen.EnableSOAPToREST = true
+			apkConf = soapGen.AddSOAPToRESTInterceptor(apkConf, *opts.SOAPToRESTInterceptor)
+		}
+	}
+
+	bundle := &Bundle{}
+	routeNames := make(map[string][]string)
+	var routes []types.RouteReference
+	for _, endpointType := range []string{constants.PRODUCTION_TYPE, constants.SANDBOX_TYPE} {
+		endpoint := utils.GetEndpointToUse(apkConf.EndpointConfigurations, endpointType, uniqueId)
+		operations := getOperations(apkConf, endpoint, endpointType)
+		if len(operations) == 0 {
+			continue
+		}
+		switch apiType {
+		case constants.API_TYPE_REST:
+			httpGen := http_generator.Generator()
+			httpGen.EnableGatewayCORS = opts.EnableGatewayCORS
+			httpRoutes, routeDiags := httpGen.GenerateHTTPRoutes(apkConf, organization, gatewayConfig, operations, endpoint, endpointType, uniqueId)
+			diags = appendUnique(diags, routeDiags)
+			bundle.HTTPRoutes = append(bundle.HTTPRoutes, httpRoutes...)
+			routeNames[endpointType] = utils.GetHTTPRouteNames(httpRoutes)
+			for _, httpRoute := range httpRoutes {
+				routes = append(routes, utils.GetHTTPRouteReference(httpRoute))
+			}
+		case constants.API_TYPE_GRPC:
+			grpcRoutes, routeDiags := grpc_generator.Generator().GenerateGRPCRoutes(apkConf, organization, gatewayConfig, operations, endpoint, endpointType, uniqueId)
+			diags = appendUnique(diags, routeDiags)
+			bundle.GRPCRoutes = append(bundle.GRPCRoutes, grpcRoutes...)
+			routeNames[endpointType] = utils.GetGRPCRouteNames(grpcRoutes)
+			for _, grpcRoute := range grpcRoutes {
+				routes = append(routes, utils.GetGRPCRouteReference(grpcRoute))
+			}
+		case constants.API_TYPE_GRAPHQL:
+			gqlRoutes, routeDiags := graphql_generator.Generator().GenerateGQLRoutes(apkConf, organization, gatewayConfig, operations, endpoint, endpointType, uniqueId)
+			diags = appendUnique(diags, routeDiags)
+			bundle.GQLRoutes = append(bundle.GQLRoutes, gqlRoutes...)
+			routeNames[endpointType] = utils.GetGQLRouteNames(gqlRoutes)
+			for _, gqlRoute := range gqlRoutes {
+				routes = append(routes, utils.GetGQLRouteReference(gqlRoute))
+			}
+		case constants.API_TYPE_SOAP, constants.API_TYPE_WS, constants.API_TYPE_SSE, constants.API_TYPE_WEBSUB:
+			var httpRoutes []*gwapiv1.HTTPRoute
+			var routeDiags diagnostics.Diagnostics
+			switch apiType {
+			case constants.API_TYPE_SOAP:
+				httpRoutes, routeDiags = soapGen.GenerateHTTPRoutes(apkConf, organization, gatewayConfig, operations, endpoint, endpointType, uniqueId)
+			case constants.API_TYPE_WS:
+				httpRoutes, routeDiags = websocket_generator.Generator().GenerateHTTPRoutes(apkConf, organization, gatewayConfig, operations, endpoint, endpointType, uniqueId)
+			case constants.API_TYPE_SSE:
+				httpRoutes, routeDiags = sse_generator.Generator().GenerateHTTPRoutes(apkConf, organization, gatewayConfig, operations, endpoint, endpointType, uniqueId)
+			case constants.API_TYPE_WEBSUB:
+				httpRoutes, routeDiags = websub_generator.Generator().GenerateHTTPRoutes(apkConf, organization, gatewayConfig, operations, endpoint, endpointType, uniqueId)
+			}
+			diags = appendUnique(diags, routeDiags)
+			bundle.HTTPRoutes = append(bundle.HTTPRoutes, httpRoutes...)
+			routeNames[endpointType] = utils.GetHTTPRouteNames(httpRoutes)
+			for _, httpRoute := range httpRoutes {
+				routes = append(routes, utils.GetHTTPRouteReference(httpRoute))
+			}
+		}
+	}
+	if diags.HasErrors() {
+		return nil, diags.Err()
+	}
+
+	var err error
+	apiGen := api_generator.Generator()
+	configMap, err := apiGen.GenerateDefinitionConfigMap(apkConf, uniqueId, opts.Definition)
+	diags = append(diags, diagnostics.FromError("definitionPath", err)...)
+	if configMap != nil {
+		bundle.ConfigMaps = append(bundle.ConfigMaps, configMap)
+	} else if apkConf.DefinitionPath != "" && err == nil {
+		diags.Warnf("definitionPath", "no api definition provided, the definition ConfigMap is not generated")
+	}
+	bundle.API, err = apiGen.GenerateAPI(apkConf, organization, uniqueId, routeNames[constants.PRODUCTION_TYPE], routeNames[constants.SANDBOX_TYPE], configMap != nil)
+	diags = append(diags, diagnostics.FromError("", err)...)
+
+	if apiType == constants.API_TYPE_GRAPHQL {
+		bundle.Scopes = graphql_generator.Generator().GenerateScopes(apkConf, uniqueId)
+	}
+
+	backendGen := backend_generator.Generator()
+	for _, endpointType := range []string{constants.PRODUCTION_TYPE, constants.SANDBOX_TYPE} {
+		backends, err := backendGen.GenerateBackends(apkConf, endpointType, uniqueId)
+		diags = append(diags, diagnostics.FromError("", err)...)
+		bundle.Backends = appendBackends(bundle.Backends, backends)
+	}
+	interceptorBackends, err := backendGen.GenerateInterceptorBackends(apkConf, uniqueId)
+	diags = append(diags, diagnostics.FromError("", err)...)
+	bundle.Backends = appendBackends(bundle.Backends, interceptorBackends)
+	mirrorBackends, err := backendGen.GenerateMirrorBackends(apkConf, uniqueId)
+	diags = append(diags, diagnostics.FromError("", err)...)
+	bundle.Backends = appendBackends(bundle.Backends, mirrorBackends)
+
+	bundle.Authentications, err = authentication_generator.Generator().GenerateAuthentications(apkConf, uniqueId, routes)
+	diags = append(diags, diagnostics.FromError("", err)...)
+	bundle.RateLimitPolicies, err = ratelimit_generator.Generator().GenerateRateLimitPolicies(apkConf, uniqueId, routes)
+	diags = append(diags, diagnostics.FromError("", err)...)
+
+	apiPolicyGen := apipolicy_generator.Generator()
+	if opts.EnableGatewayCORS {
+		// The CORS configuration is applied by the route filters instead.
+		apiPolicyGen.GenerateCORSPolicy = func(corsConfig types.CORSConfiguration) *crds.CORSPolicy {
+			return nil
+		}
+	}
+	bundle.APIPolicies, err = apiPolicyGen.GenerateAPIPolicies(apkConf, uniqueId, routes)
+	diags = append(diags, diagnostics.FromError("", err)...)
+	bundle.InterceptorServices, err = interceptor_generator.Generator().GenerateInterceptorServices(apkConf, uniqueId)
+	diags = append(diags, diagnostics.FromError("", err)...)
+	bundle.BackendJWTs, err = backendjwt_generator.Generator().GenerateBackendJWTs(apkConf, uniqueId)
+	diags = append(diags, diagnostics.FromError("", err)...)
+
+	aiGen := ai_generator.Generator()
+	bundle.AIProvider, err = aiGen.GenerateAIProvider(apkConf, organization, uniqueId)
+	diags = append(diags, diagnostics.FromError("aiProvider", err)...)
+	bundle.AIRateLimitPolicies, err = aiGen.GenerateAIRateLimitPolicies(apkConf, uniqueId)
+	diags = append(diags, diagnostics.FromError("", err)...)
+
+	if diags.HasErrors() {
+		return nil, diags.Err()
+	}
+	bundle.Warnings = diags.Warnings()
+	return bundle, nil
+}
+
+// getOperations returns the operations of the API with an endpoint of the given type, either their own or the API endpoint.
+func getOperations(apkConf types.APKConf, endpoint *types.EndpointDetails, endpointType string) []types.Operation {
+	var operations []types.Operation
+	if apkConf.Operations == nil {
+		return operations
+	}
+	for _, operation := range *apkConf.Operations {
+		if endpoint != nil || utils.HasEndpoint(operation.EndpointConfigurations, endpointType) {
+			operations = append(operations, operation)
+		}
+	}
+	return operations
+}
+
+// appendBackends appends the backends that are not already part of the bundle, as environments and policies may share endpoints.
+func appendBackends(backends []*crds.Backend, newBackends []*crds.Backend) []*crds.Backend {
+	for _, newBackend := range newBackends {
+		if !slices.ContainsFunc(backends, func(backend *crds.Backend) bool { return backend.Name == newBackend.Name }) {
+			backends = append(backends, newBackend)
+		}
+	}
+	return backends
+}
+
+// appendUnique appends the diagnostics that are not already reported, as the routes of both environments report the same problems.
+func appendUnique(diags diagnostics.Diagnostics, newDiags diagnostics.Diagnostics) diagnostics.Diagnostics {
+	for _, newDiagnostic := range newDiags {
+		if !slices.Contains(diags, newDiagnostic) {
+			diags = append(diags, newDiagnostic)
+		}
+	}
+	return diags
+}
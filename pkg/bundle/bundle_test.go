@@ -0,0 +1,351 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package bundle
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+// TestGenerate test for Generate
+func TestGenerate(t *testing.T) {
+	apkConf := types.APKConf{
+		Name:           "EmployeeServiceAPI",
+		Version:        "3.14",
+		BasePath:       "/employees-info",
+		Type:           "REST",
+		DefinitionPath: "/definition",
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-service:8080"),
+			},
+			Sandbox: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-service:8080"),
+			},
+		},
+		Operations: &[]types.Operation{
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true), RateLimit: &types.RateLimit{RequestsPerUnit: 5, Unit: "Minute"}},
+			{
+				Target:  "/employee",
+				Verb:    "POST",
+				Secured: ptr.To(false),
+				OperationPolicies: &types.OperationPolicies{
+					Request: []types.OperationPolicy{
+						{PolicyName: "Interceptor", Parameters: types.InterceptorService{BackendURL: "http://interceptor-service:8443", HeadersEnabled: true}},
+					},
+				},
+			},
+		},
+		Authentication: &[]types.AuthConfiguration{
+			{AuthType: "OAuth2", Enabled: ptr.To(true)},
+		},
+		APIPolicies: &types.OperationPolicies{
+			Request: []types.OperationPolicy{
+				{PolicyName: "AddHeader", Parameters: types.Header{HeaderName: "X-Env", HeaderValue: "test"}},
+			},
+		},
+	}
+	organization := types.Organization{Name: "wso2"}
+	gatewayConfig := types.GatewayConfigurations{
+		Name:         "wso2-apim",
+		ListenerName: "wso2-apim-gateway",
+		Hostname:     "wso2-apim",
+	}
+
+	bundle, err := Generate(apkConf, organization, gatewayConfig, Options{Definition: []byte("openapi: 3.0.1")})
+	assert.Nil(t, err)
+	uniqueId := utils.GetUniqueId(apkConf, organization)
+	assert.Equal(t, uniqueId, bundle.API.Name)
+	assert.Len(t, bundle.HTTPRoutes, 2)
+	assert.Equal(t, uniqueId+"-production-httproute-1", bundle.HTTPRoutes[0].Name)
+	assert.Equal(t, uniqueId+"-sandbox-httproute-1", bundle.HTTPRoutes[1].Name)
+	assert.Equal(t, []string{bundle.HTTPRoutes[0].Name}, bundle.API.Spec.Production[0].RouteRefs)
+	assert.Equal(t, []string{bundle.HTTPRoutes[1].Name}, bundle.API.Spec.Sandbox[0].RouteRefs)
+	assert.Empty(t, bundle.GRPCRoutes)
+	// The production and sandbox endpoints share a backend, the interceptor of the ignored operation policies has none
+	assert.Len(t, bundle.Backends, 1)
+	// The API authentication along with the authentication disabled for the unsecured operation,
+	// referred by its route rules of both environments
+	assert.Len(t, bundle.Authentications, 2)
+	assert.Len(t, bundle.RateLimitPolicies, 1)
+	// The operation policies are ignored in favour of the API level header policy, which needs no APIPolicy
+	assert.Empty(t, bundle.APIPolicies)
+	assert.Empty(t, bundle.InterceptorServices)
+	assert.Empty(t, bundle.BackendJWTs)
+	assert.Nil(t, bundle.AIProvider)
+	assert.Len(t, bundle.ConfigMaps, 1)
+	assert.Equal(t, bundle.API.Spec.DefinitionFileRef, bundle.ConfigMaps[0].Name)
+	// The ignored operation policies are reported once for both environments
+	assert.Len(t, bundle.Warnings, 1)
+	assert.Equal(t, "operations[1].operationPolicies", bundle.Warnings[0].Path)
+
+	// Deterministic naming
+	regenerated, err := Generate(apkConf, organization, gatewayConfig, Options{Definition: []byte("openapi: 3.0.1")})
+	assert.Nil(t, err)
+	assert.Equal(t, bundle, regenerated)
+
+	// Unique id and sandbox only operations
+	apkConf.EndpointConfigurations.Sandbox = nil
+	(*apkConf.Operations)[1].EndpointConfigurations = &types.EndpointConfigurations{
+		Sandbox: &types.EndpointConfiguration{Endpoint: types.EndpointURL("http://employee-sandbox:8080")},
+	}
+	bundle, err = Generate(apkConf, organization, gatewayConfig, Options{UniqueID: "employee-api"})
+	assert.Nil(t, err)
+	assert.Len(t, bundle.HTTPRoutes, 2)
+	assert.Equal(t, "employee-api-sandbox-httproute-1", bundle.HTTPRoutes[1].Name)
+	assert.Len(t, bundle.HTTPRoutes[1].Spec.Rules, 1)
+	assert.Empty(t, bundle.ConfigMaps)
+	assert.Empty(t, bundle.API.Spec.DefinitionFileRef)
+	assert.Contains(t, bundle.Warnings, diagnostics.Diagnostic{Severity: diagnostics.Warning, Path: "definitionPath", Message: "no api definition provided, the definition ConfigMap is not generated"})
+}
+
+func TestGenerateGRPC(t *testing.T) {
+	apkConf := types.APKConf{
+		Name:     "EmployeeGRPCAPI",
+		Version:  "v1",
+		BasePath: "/org.apk.employee",
+		Type:     "GRPC",
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-grpc:6565"),
+			},
+		},
+		Operations: &[]types.Operation{
+			{Target: "employee.EmployeeService", Verb: "GetEmployee", Secured: ptr.To(true)},
+		},
+	}
+
+	bundle, err := Generate(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, Options{})
+	assert.Nil(t, err)
+	assert.Empty(t, bundle.HTTPRoutes)
+	assert.Len(t, bundle.GRPCRoutes, 1)
+	assert.Equal(t, []string{bundle.GRPCRoutes[0].Name}, bundle.API.Spec.Production[0].RouteRefs)
+	assert.Len(t, bundle.Backends, 1)
+}
+
+func TestGenerateGraphQL(t *testing.T) {
+	apkConf := types.APKConf{
+		ID:       "employee-graphql",
+		Name:     "EmployeeGraphQLAPI",
+		Version:  "1.0",
+		BasePath: "/employees-graphql",
+		Type:     "GRAPHQL",
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-graphql:8080"),
+			},
+		},
+		Operations: &[]types.Operation{
+			{Target: "employees", Verb: "QUERY", Secured: ptr.To(true), Scopes: []string{"employees:read"}},
+			{Target: "addEmployee", Verb: "MUTATION", Secured: ptr.To(true), RateLimit: &types.RateLimit{RequestsPerUnit: 10, Unit: "Minute"}},
+		},
+	}
+
+	bundle, err := Generate(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, Options{})
+	assert.Nil(t, err)
+	assert.Empty(t, bundle.HTTPRoutes)
+	assert.Len(t, bundle.GQLRoutes, 1)
+	assert.Equal(t, []string{"employee-graphql-production-gqlroute-1"}, bundle.API.Spec.Production[0].RouteRefs)
+	assert.Equal(t, "GRAPHQL", bundle.API.Spec.APIType)
+	assert.Len(t, bundle.Scopes, 1)
+	assert.Len(t, bundle.RateLimitPolicies, 1)
+	assert.Equal(t, "Resource", string(bundle.RateLimitPolicies[0].Spec.TargetRef.Kind))
+	assert.Equal(t, bundle.RateLimitPolicies[0].Name, string(bundle.GQLRoutes[0].Spec.Rules[1].Filters[0].ExtensionRef.Name))
+}
+
+func TestGenerateWebSocket(t *testing.T) {
+	apkConf := types.APKConf{
+		ID:       "employee-notifications",
+		Name:     "EmployeeNotificationsAPI",
+		Version:  "1.0",
+		BasePath: "/employees-ws",
+		Type:     "WS",
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("wss://employee-notifications:8443"),
+			},
+		},
+		Operations: &[]types.Operation{
+			{Target: "/notifications", Verb: "SUBSCRIBE", Secured: ptr.To(true), RateLimit: &types.RateLimit{RequestsPerUnit: 10, Unit: "Minute"}},
+			{Target: "/notifications", Verb: "PUBLISH", Secured: ptr.To(true)},
+		},
+	}
+
+	bundle, err := Generate(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, Options{})
+	assert.Nil(t, err)
+	assert.Len(t, bundle.HTTPRoutes, 1)
+	assert.Len(t, bundle.HTTPRoutes[0].Spec.Rules, 1)
+	assert.Equal(t, []string{"employee-notifications-production-httproute-1"}, bundle.API.Spec.Production[0].RouteRefs)
+	assert.Equal(t, "WS", bundle.API.Spec.APIType)
+	assert.Len(t, bundle.Backends, 1)
+	assert.Equal(t, "wss", bundle.Backends[0].Spec.Protocol)
+	assert.Len(t, bundle.RateLimitPolicies, 1)
+}
+
+func TestGenerateEventAPIs(t *testing.T) {
+	apkConf := types.APKConf{
+		ID:       "employee-events",
+		Name:     "EmployeeEventsAPI",
+		Version:  "1.0",
+		BasePath: "/employees-events",
+		Type:     "SSE",
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-events:8080"),
+			},
+		},
+		Operations: &[]types.Operation{
+			{Target: "/events", Verb: "SUBSCRIBE", Secured: ptr.To(true)},
+		},
+	}
+
+	bundle, err := Generate(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, Options{})
+	assert.Nil(t, err)
+	assert.Len(t, bundle.HTTPRoutes, 1)
+	assert.NotNil(t, bundle.HTTPRoutes[0].Spec.Rules[0].Timeouts)
+	assert.Equal(t, "SSE", bundle.API.Spec.APIType)
+
+	apkConf.Type = "WEBSUB"
+	(*apkConf.Operations)[0].Target = "_issues"
+	(*apkConf.Operations)[0].RateLimit = &types.RateLimit{RequestsPerUnit: 10, Unit: "Minute"}
+	bundle, err = Generate(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, Options{})
+	assert.Nil(t, err)
+	assert.Len(t, bundle.HTTPRoutes, 1)
+	assert.Len(t, bundle.HTTPRoutes[0].Spec.Rules, 2)
+	assert.Equal(t, "WEBSUB", bundle.API.Spec.APIType)
+	// The rate limit of the topic is referred by its subscription rule
+	assert.Len(t, bundle.RateLimitPolicies, 1)
+	subscriptionFilters := bundle.HTTPRoutes[0].Spec.Rules[0].Filters
+	assert.Equal(t, bundle.RateLimitPolicies[0].Name, string(subscriptionFilters[len(subscriptionFilters)-1].ExtensionRef.Name))
+}
+
+func TestGenerateSOAP(t *testing.T) {
+	wsdl := `<definitions xmlns="http://schemas.xmlsoap.org/wsdl/" xmlns:soap="http://schemas.xmlsoap.org/wsdl/soap/">
+  <binding name="EmployeeServiceSoap" type="tns:EmployeeService">
+    <operation name="GetEmployee"><soap:operation soapAction="urn:GetEmployee"/></operation>
+  </binding>
+</definitions>`
+	apkConf := types.APKConf{
+		ID:             "employee-soap",
+		Name:           "EmployeeSOAPAPI",
+		Version:        "1.0",
+		BasePath:       "/employees-soap",
+		Type:           "SOAP",
+		DefinitionPath: "/definition",
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-service:8080/EmployeeService"),
+			},
+		},
+		Operations: &[]types.Operation{
+			{Target: "/GetEmployee", Verb: "POST", Secured: ptr.To(true)},
+		},
+	}
+
+	bundle, err := Generate(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, Options{Definition: []byte(wsdl)})
+	assert.Nil(t, err)
+	assert.Len(t, bundle.HTTPRoutes, 1)
+	assert.Equal(t, `^"?urn:GetEmployee"?$`, bundle.HTTPRoutes[0].Spec.Rules[0].Matches[0].Headers[0].Value)
+	assert.Equal(t, "SOAP", bundle.API.Spec.APIType)
+	assert.Len(t, bundle.ConfigMaps, 1)
+
+	// The REST resources are converted to SOAP requests by the interceptor
+	bundle, err = Generate(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, Options{
+		Definition:            []byte(wsdl),
+		SOAPToRESTInterceptor: &types.InterceptorService{BackendURL: "http://soap-to-rest:8080"},
+	})
+	assert.Nil(t, err)
+	assert.Empty(t, bundle.HTTPRoutes[0].Spec.Rules[0].Matches[0].Headers)
+	assert.Len(t, bundle.InterceptorServices, 2)
+	assert.Len(t, bundle.APIPolicies, 1)
+	assert.Len(t, bundle.Backends, 2)
+
+	_, err = Generate(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, Options{Definition: []byte("openapi: 3.0.0")})
+	assert.ErrorContains(t, err, "definitionPath: failed to parse the WSDL")
+}
+
+func TestGenerateSplitRoutes(t *testing.T) {
+	var operations []types.Operation
+	for i := 0; i < 20; i++ {
+		operations = append(operations, types.Operation{Target: fmt.Sprintf("/employees/%d", i), Verb: "GET", Secured: ptr.To(true)})
+	}
+	operations[18].RateLimit = &types.RateLimit{RequestsPerUnit: 10, Unit: "Minute"}
+	apkConf := types.APKConf{
+		ID:       "employee-api",
+		Name:     "EmployeeServiceAPI",
+		Version:  "3.14",
+		BasePath: "/employees-info",
+		Type:     "REST",
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-service:8080"),
+			},
+		},
+		Operations: &operations,
+	}
+
+	bundle, err := Generate(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, Options{})
+	assert.Nil(t, err)
+	assert.Len(t, bundle.HTTPRoutes, 2)
+	assert.Equal(t, []string{"employee-api-production-httproute-1", "employee-api-production-httproute-2"}, bundle.API.Spec.Production[0].RouteRefs)
+	// The operation policies are referred by the rule of the operation in the route holding it
+	assert.Len(t, bundle.RateLimitPolicies, 1)
+	ruleFilters := bundle.HTTPRoutes[1].Spec.Rules[2].Filters
+	assert.Equal(t, bundle.RateLimitPolicies[0].Name, string(ruleFilters[len(ruleFilters)-1].ExtensionRef.Name))
+}
+
+func TestGenerateErrors(t *testing.T) {
+	apkConf := types.APKConf{
+		Name:     "EmployeeServiceAPI",
+		Version:  "3.14",
+		BasePath: "/employees-info",
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-service:8080"),
+			},
+		},
+		Operations: &[]types.Operation{
+			{Target: "/employees", Verb: "get"},
+		},
+		AIProvider: &types.AIProvider{Name: "Unknown"},
+	}
+
+	// Invalid configurations are reported before generating the resources
+	bundle, err := Generate(apkConf, types.Organization{}, types.GatewayConfigurations{}, Options{})
+	assert.Nil(t, bundle)
+	assert.EqualError(t, err, `error: operations[0].verb: unsupported verb "get" for REST APIs, expected one of GET, POST, PUT, DELETE, PATCH, HEAD, OPTIONS`)
+
+	// The errors of the resources are aggregated
+	bundle, err = Generate(apkConf, types.Organization{}, types.GatewayConfigurations{}, Options{SkipValidation: true})
+	assert.Nil(t, bundle)
+	diags, ok := err.(diagnostics.Diagnostics)
+	assert.True(t, ok)
+	assert.Equal(t, "aiProvider", diags[0].Path)
+
+	// Unsupported API types
+	apkConf.Type = "ASYNC"
+	_, err = Generate(apkConf, types.Organization{}, types.GatewayConfigurations{}, Options{SkipValidation: true})
+	assert.EqualError(t, err, `error: type: unsupported api type for the bundle generation: "ASYNC"`)
+}
@@ -0,0 +1,191 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package diagnostics
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity represents the severity of a diagnostic.
+type Severity int
+
+const (
+	// Error diagnostics prevent the resources from being generated.
+	Error Severity = iota
+	// Warning diagnostics report configurations that are ignored or adjusted during the generation.
+	Warning
+)
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	if s == Warning {
+		return "warning"
+	}
+	return "error"
+}
+
+// MarshalText encodes the severity as its name.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes the severity from its name.
+func (s *Severity) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "error":
+		*s = Error
+	case "warning":
+		*s = Warning
+	default:
+		return fmt.Errorf("unknown severity: %q", text)
+	}
+	return nil
+}
+
+// Diagnostic represents an error or a warning on the field of the APK configuration at the given path,
+// e.g. operations[2].operationPolicies.response[0]. Diagnostics found in a YAML document also carry
+// the line and column of the field.
+type Diagnostic struct {
+	Severity Severity `json:"severity"`
+	Path     string   `json:"path,omitempty"`
+	Message  string   `json:"message"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+}
+
+// Error returns the diagnostic formatted as "<severity>: <path>: <message>", with the path preceded
+// by "<line>:<column>: " when the position is known.
+func (d Diagnostic) Error() string {
+	prefix := d.Severity.String() + ": "
+	if d.Line > 0 && d.Column > 0 {
+		prefix += fmt.Sprintf("%d:%d: ", d.Line, d.Column)
+	} else if d.Line > 0 {
+		prefix += fmt.Sprintf("%d: ", d.Line)
+	}
+	if d.Path == "" {
+		return prefix + d.Message
+	}
+	return prefix + d.Path + ": " + d.Message
+}
+
+// Diagnostics is a list of diagnostics accumulated while processing an APK configuration.
+type Diagnostics []Diagnostic
+
+// Errorf appends an error diagnostic on the given path.
+func (d *Diagnostics) Errorf(path string, format string, args ...interface{}) {
+	*d = append(*d, Diagnostic{Severity: Error, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// Warnf appends a warning diagnostic on the given path.
+func (d *Diagnostics) Warnf(path string, format string, args ...interface{}) {
+	*d = append(*d, Diagnostic{Severity: Warning, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// HasErrors reports whether any of the diagnostics is an error.
+func (d Diagnostics) HasErrors() bool {
+	for _, diagnostic := range d {
+		if diagnostic.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
+
+// Errors returns the error diagnostics.
+func (d Diagnostics) Errors() Diagnostics {
+	return d.filter(Error)
+}
+
+// Warnings returns the warning diagnostics.
+func (d Diagnostics) Warnings() Diagnostics {
+	return d.filter(Warning)
+}
+
+// Err returns the error diagnostics as an error, or nil when there are no errors.
+func (d Diagnostics) Err() error {
+	if !d.HasErrors() {
+		return nil
+	}
+	return d.Errors()
+}
+
+// Error returns the diagnostics joined by "; ".
+func (d Diagnostics) Error() string {
+	messages := make([]string, 0, len(d))
+	for _, diagnostic := range d {
+		messages = append(messages, diagnostic.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// WithPathPrefix returns a copy of the diagnostics with their paths nested under the given prefix.
+func (d Diagnostics) WithPathPrefix(prefix string) Diagnostics {
+	if d == nil {
+		return nil
+	}
+	prefixed := make(Diagnostics, 0, len(d))
+	for _, diagnostic := range d {
+		diagnostic.Path = JoinPath(prefix, diagnostic.Path)
+		prefixed = append(prefixed, diagnostic)
+	}
+	return prefixed
+}
+
+// FromError converts the given error into diagnostics on the given path, keeping the diagnostics it may already hold.
+func FromError(path string, err error) Diagnostics {
+	if err == nil {
+		return nil
+	}
+	switch v := err.(type) {
+	case Diagnostics:
+		return v.WithPathPrefix(path)
+	case Diagnostic:
+		return Diagnostics{v}.WithPathPrefix(path)
+	}
+	return Diagnostics{{Severity: Error, Path: path, Message: err.Error()}}
+}
+
+// JoinPath joins the given field paths, e.g. JoinPath("operations[2]", "operationPolicies") is "operations[2].operationPolicies".
+func JoinPath(prefix string, path string) string {
+	if prefix == "" {
+		return path
+	}
+	if path == "" {
+		return prefix
+	}
+	if strings.HasPrefix(path, "[") {
+		return prefix + path
+	}
+	return prefix + "." + path
+}
+
+// Index returns the path of the element at the given index of the list at the given path, e.g. operations[2].
+func Index(path string, index int) string {
+	return fmt.Sprintf("%s[%d]", path, index)
+}
+
+func (d Diagnostics) filter(severity Severity) Diagnostics {
+	var filtered Diagnostics
+	for _, diagnostic := range d {
+		if diagnostic.Severity == severity {
+			filtered = append(filtered, diagnostic)
+		}
+	}
+	return filtered
+}
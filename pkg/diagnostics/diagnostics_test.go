@@ -0,0 +1,100 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package diagnostics
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagnostics(t *testing.T) {
+	var diags Diagnostics
+	assert.False(t, diags.HasErrors())
+	assert.Nil(t, diags.Err())
+
+	diags.Warnf("operationPolicies", "operation policies are ignored")
+	assert.False(t, diags.HasErrors())
+	assert.Nil(t, diags.Err())
+
+	diags.Errorf(Index("response", 0), "%s policy cannot be used as a response policy", "RequestMirror")
+	assert.True(t, diags.HasErrors())
+	assert.Len(t, diags.Errors(), 1)
+	assert.Len(t, diags.Warnings(), 1)
+	assert.EqualError(t, diags.Err(), "error: response[0]: RequestMirror policy cannot be used as a response policy")
+
+	prefixed := diags.WithPathPrefix("operations[2].operationPolicies")
+	assert.Equal(t, "operations[2].operationPolicies.operationPolicies", prefixed[0].Path)
+	assert.Equal(t, "operations[2].operationPolicies.response[0]", prefixed[1].Path)
+	assert.Equal(t, "response[0]", diags[1].Path)
+	assert.Equal(t, "warning: operations[2].operationPolicies.operationPolicies: operation policies are ignored; error: operations[2].operationPolicies.response[0]: RequestMirror policy cannot be used as a response policy", prefixed.Error())
+}
+
+func TestJoinPath(t *testing.T) {
+	tests := []struct {
+		prefix   string
+		path     string
+		expected string
+	}{
+		{"operations[2]", "operationPolicies", "operations[2].operationPolicies"},
+		{"operations", "[2]", "operations[2]"},
+		{"", "operations", "operations"},
+		{"operations[2]", "", "operations[2]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, JoinPath(tt.prefix, tt.path))
+		})
+	}
+}
+
+func TestFromError(t *testing.T) {
+	assert.Nil(t, FromError("operations[0]", nil))
+
+	diags := FromError("corsConfiguration", errors.New("invalid origin"))
+	assert.Equal(t, Diagnostics{{Severity: Error, Path: "corsConfiguration", Message: "invalid origin"}}, diags)
+
+	var nested Diagnostics
+	nested.Errorf("parameters.url", "invalid url")
+	diags = FromError("operations[0]", nested)
+	assert.Equal(t, "operations[0].parameters.url", diags[0].Path)
+}
+
+func TestDiagnosticJSON(t *testing.T) {
+	diags := Diagnostics{{Severity: Warning, Path: "operations[0]", Message: "ignored"}}
+
+	jsonBytes, err := json.Marshal(diags)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[{"severity":"warning","path":"operations[0]","message":"ignored"}]`, string(jsonBytes))
+
+	var decoded Diagnostics
+	assert.Nil(t, json.Unmarshal(jsonBytes, &decoded))
+	assert.Equal(t, diags, decoded)
+}
+
+func TestDiagnosticPosition(t *testing.T) {
+	diagnostic := Diagnostic{Severity: Error, Path: "operations[0].verb", Message: "value is required", Line: 12, Column: 3}
+	assert.EqualError(t, diagnostic, "error: 12:3: operations[0].verb: value is required")
+
+	jsonBytes, err := json.Marshal(diagnostic)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"severity":"error","path":"operations[0].verb","message":"value is required","line":12,"column":3}`, string(jsonBytes))
+}
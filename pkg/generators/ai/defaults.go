@@ -0,0 +1,80 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package ai_generator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+)
+
+// getAIProviderPreset returns a copy of the built-in preset of the provider, matching the name regardless of case, spaces and dashes.
+func (g *aiGenerator) getAIProviderPreset(aiProvider types.AIProvider) (*crds.AIProviderSpec, error) {
+	normalize := strings.NewReplacer(" ", "", "-", "", "_", "")
+	for name, preset := range aiProviderPresets {
+		if strings.EqualFold(normalize.Replace(aiProvider.Name), name) {
+			aiProviderSpec := preset
+			aiProviderSpec.SupportedModels = append([]string(nil), preset.SupportedModels...)
+			if aiProvider.APIVersion != "" {
+				aiProviderSpec.ProviderAPIVersion = aiProvider.APIVersion
+			}
+			return &aiProviderSpec, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported ai provider: %q", aiProvider.Name)
+}
+
+// generateAIRateLimit generates the token and request count limits after validating their time units.
+func (g *aiGenerator) generateAIRateLimit(aiRatelimit types.AIRatelimit) (*crds.AIRateLimit, error) {
+	aiRateLimit := crds.AIRateLimit{}
+	token := aiRatelimit.Token
+	request := aiRatelimit.Request
+	if token.PromptLimit < 0 || token.CompletionLimit < 0 || token.TotalLimit < 0 || request.RequestLimit < 0 {
+		return nil, errors.New("ai rate limits cannot be negative")
+	}
+	if token.PromptLimit > 0 || token.CompletionLimit > 0 || token.TotalLimit > 0 {
+		unit, ok := utils.GetRateLimitUnit(token.Unit)
+		if !ok {
+			return nil, fmt.Errorf("invalid ai token rate limit unit: %q", token.Unit)
+		}
+		aiRateLimit.TokenCount = &crds.TokenCount{
+			Unit:               unit,
+			RequestTokenCount:  uint32(token.PromptLimit),
+			ResponseTokenCount: uint32(token.CompletionLimit),
+			TotalTokenCount:    uint32(token.TotalLimit),
+		}
+	}
+	if request.RequestLimit > 0 {
+		unit, ok := utils.GetRateLimitUnit(request.Unit)
+		if !ok {
+			return nil, fmt.Errorf("invalid ai request rate limit unit: %q", request.Unit)
+		}
+		aiRateLimit.RequestCount = &crds.RequestCount{
+			RequestsPerUnit: uint32(request.RequestLimit),
+			Unit:            unit,
+		}
+	}
+	if aiRateLimit.TokenCount == nil && aiRateLimit.RequestCount == nil {
+		return nil, errors.New("ai rate limit is enabled without token or request limits")
+	}
+	return &aiRateLimit, nil
+}
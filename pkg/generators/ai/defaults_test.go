@@ -0,0 +1,75 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package ai_generator
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAIProviderPreset(t *testing.T) {
+	tests := []struct {
+		name         string
+		aiProvider   types.AIProvider
+		providerName string
+		hasError     bool
+	}{
+		{"OpenAI", types.AIProvider{Name: "OpenAI"}, "OpenAI", false},
+		{"Azure OpenAI with spaces", types.AIProvider{Name: "Azure OpenAI"}, "AzureOpenAI", false},
+		{"Mistral lower case", types.AIProvider{Name: "mistral"}, "Mistral", false},
+		{"Unsupported provider", types.AIProvider{Name: "Unknown"}, "", true},
+	}
+
+	g := Generator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aiProviderSpec, err := g.GetAIProviderPreset(tt.aiProvider)
+			if tt.hasError {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.providerName, aiProviderSpec.ProviderName)
+			assert.Equal(t, "$.model", aiProviderSpec.RequestModel.Value)
+		})
+	}
+}
+
+func TestGenerateAIRateLimit(t *testing.T) {
+	g := Generator()
+
+	aiRateLimit, err := g.GenerateAIRateLimit(types.AIRatelimit{
+		Enabled: true,
+		Token:   types.TokenAIRL{TotalLimit: 1000, Unit: "day"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Day", aiRateLimit.TokenCount.Unit)
+	assert.Nil(t, aiRateLimit.RequestCount)
+
+	_, err = g.GenerateAIRateLimit(types.AIRatelimit{
+		Enabled: true,
+		Request: types.RequestAIRL{RequestLimit: 10, Unit: "Week"},
+	})
+	assert.NotNil(t, err)
+
+	_, err = g.GenerateAIRateLimit(types.AIRatelimit{Enabled: true})
+	assert.NotNil(t, err)
+}
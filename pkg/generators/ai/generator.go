@@ -0,0 +1,132 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package ai_generator
+
+import (
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// aiGenerator is the interface for the AIProvider and AIRateLimitPolicy generator.
+type aiGenerator struct {
+	GetAIProviderPreset func(aiProvider types.AIProvider) (*crds.AIProviderSpec, error)
+	GenerateAIRateLimit func(aiRatelimit types.AIRatelimit) (*crds.AIRateLimit, error)
+}
+
+// Generator creates a new AI generator.
+func Generator() *aiGenerator {
+	gen := &aiGenerator{}
+	gen.GetAIProviderPreset = gen.getAIProviderPreset
+	gen.GenerateAIRateLimit = gen.generateAIRateLimit
+	return gen
+}
+
+// GenerateAIProvider generates the AIProvider of the API from the built-in preset of the configured provider.
+func (g *aiGenerator) GenerateAIProvider(apkConf types.APKConf, organization types.Organization, uniqueId string) (*crds.AIProvider, error) {
+	if apkConf.AIProvider == nil {
+		return nil, nil
+	}
+	aiProviderSpec, err := g.GetAIProviderPreset(*apkConf.AIProvider)
+	if err != nil {
+		return nil, err
+	}
+	aiProviderSpec.Organization = organization.Name
+	aiProvider := crds.AIProvider{
+		TypeMeta: v1.TypeMeta{
+			Kind:       constants.AI_PROVIDER_KIND,
+			APIVersion: constants.DP_V1ALPHA3,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: utils.GetAIProviderName(uniqueId),
+		},
+		Spec: *aiProviderSpec,
+	}
+	return &aiProvider, nil
+}
+
+// GenerateAIRateLimitPolicies generates an AIRateLimitPolicy targeting the Backend of each endpoint with AI rate limiting enabled.
+// Kubernetes services referred directly, without a Backend, are reported as error diagnostics, returned as the error.
+func (g *aiGenerator) GenerateAIRateLimitPolicies(apkConf types.APKConf, uniqueId string) ([]*crds.AIRateLimitPolicy, error) {
+	var aiRateLimitPolicies []*crds.AIRateLimitPolicy
+	var diags diagnostics.Diagnostics
+	generatedPolicies := make(map[string]bool)
+	endpointConfigurations := []*types.EndpointConfigurations{apkConf.EndpointConfigurations}
+	endpointConfigurationsPaths := []string{"endpointConfigurations"}
+	if apkConf.Operations != nil {
+		for _, operation := range *apkConf.Operations {
+			endpointConfigurations = append(endpointConfigurations, operation.EndpointConfigurations)
+			endpointConfigurationsPaths = append(endpointConfigurationsPaths, diagnostics.JoinPath(utils.GetOperationPath(apkConf, operation), "endpointConfigurations"))
+		}
+	}
+
+	for i, endpointConfigs := range endpointConfigurations {
+		for _, endpointType := range []string{constants.PRODUCTION_TYPE, constants.SANDBOX_TYPE} {
+			endpointConfig := utils.GetEndpointConfiguration(endpointConfigs, endpointType)
+			if endpointConfig == nil || endpointConfig.Endpoint == nil || !endpointConfig.AIRatelimit.Enabled {
+				continue
+			}
+			endpoint := utils.CreateEndpointDetails(uniqueId, *endpointConfig)
+			if endpoint.ServiceEntry {
+				diags.Errorf(diagnostics.JoinPath(endpointConfigurationsPaths[i], diagnostics.JoinPath(endpointType, "aiRatelimit")), "ai rate limiting is not supported for kubernetes service endpoints without endpoint security or a certificate")
+				continue
+			}
+			name := endpoint.Name + "-ai-ratelimit"
+			if generatedPolicies[name] {
+				continue
+			}
+			aiRateLimit, err := g.GenerateAIRateLimit(endpointConfig.AIRatelimit)
+			if err != nil {
+				return nil, err
+			}
+			backendRef := utils.GenerateBackendObjectReference(endpoint)
+			targetRef := gwapiv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+				LocalPolicyTargetReference: gwapiv1alpha2.LocalPolicyTargetReference{
+					Kind: *backendRef.Kind,
+					Name: backendRef.Name,
+				},
+			}
+			if backendRef.Group != nil {
+				targetRef.Group = *backendRef.Group
+			}
+			generatedPolicies[name] = true
+			aiRateLimitPolicies = append(aiRateLimitPolicies, &crds.AIRateLimitPolicy{
+				TypeMeta: v1.TypeMeta{
+					Kind:       constants.AI_RATELIMIT_POLICY_KIND,
+					APIVersion: constants.DP_V1ALPHA3,
+				},
+				ObjectMeta: v1.ObjectMeta{
+					Name: name,
+				},
+				Spec: crds.AIRateLimitPolicySpec{
+					Default:   aiRateLimit,
+					TargetRef: targetRef,
+				},
+			})
+		}
+	}
+	if diags.HasErrors() {
+		return nil, diags.Err()
+	}
+	return aiRateLimitPolicies, nil
+}
@@ -0,0 +1,98 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package ai_generator
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+// TestGenerator test for Generator
+func TestGenerator(t *testing.T) {
+	apkConf := types.APKConf{
+		Name:     "ChatAPI",
+		Version:  "1.0",
+		BasePath: "/chat",
+		Type:     "REST",
+		AIProvider: &types.AIProvider{
+			Name:       "Azure OpenAI",
+			APIVersion: "2024-02-01",
+		},
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("https://example.openai.azure.com/openai"),
+				AIRatelimit: types.AIRatelimit{
+					Enabled: true,
+					Token:   types.TokenAIRL{PromptLimit: 1000, CompletionLimit: 500, TotalLimit: 1500, Unit: "Minute"},
+					Request: types.RequestAIRL{RequestLimit: 100, Unit: "Hour"},
+				},
+			},
+			Sandbox: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("https://sandbox.openai.azure.com/openai"),
+			},
+		},
+		Operations: &[]types.Operation{
+			{Target: "/chat/completions", Verb: "POST", Secured: ptr.To(true)},
+		},
+	}
+	organization := types.Organization{
+		Name: "wso2",
+	}
+	uniqueId := "unique-id"
+
+	gen := Generator()
+
+	aiProvider, err := gen.GenerateAIProvider(apkConf, organization, uniqueId)
+	assert.Nil(t, err)
+	assert.Equal(t, "AzureOpenAI", aiProvider.Spec.ProviderName)
+	assert.Equal(t, "2024-02-01", aiProvider.Spec.ProviderAPIVersion)
+	assert.Equal(t, "wso2", aiProvider.Spec.Organization)
+	assert.Equal(t, "$.usage.total_tokens", aiProvider.Spec.RateLimitFields.TotalTokens.Value)
+
+	aiRateLimitPolicies, err := gen.GenerateAIRateLimitPolicies(apkConf, uniqueId)
+	assert.Nil(t, err)
+	assert.Len(t, aiRateLimitPolicies, 1)
+	backendName := utils.CreateEndpointDetails(uniqueId, *apkConf.EndpointConfigurations.Production).Name
+	assert.Equal(t, backendName+"-ai-ratelimit", aiRateLimitPolicies[0].Name)
+	assert.Equal(t, "Backend", string(aiRateLimitPolicies[0].Spec.TargetRef.Kind))
+	assert.Equal(t, backendName, string(aiRateLimitPolicies[0].Spec.TargetRef.Name))
+	assert.Equal(t, uint32(1500), aiRateLimitPolicies[0].Spec.Default.TokenCount.TotalTokenCount)
+	assert.Equal(t, "Hour", aiRateLimitPolicies[0].Spec.Default.RequestCount.Unit)
+
+	// Kubernetes services referred directly have no Backend to target
+	(*apkConf.Operations)[0].EndpointConfigurations = &types.EndpointConfigurations{
+		Production: &types.EndpointConfiguration{
+			Endpoint:    types.K8sService{Name: "chat-service", Namespace: "default", Port: "8080", Protocol: "http"},
+			AIRatelimit: apkConf.EndpointConfigurations.Production.AIRatelimit,
+		},
+	}
+	aiRateLimitPolicies, err = gen.GenerateAIRateLimitPolicies(apkConf, uniqueId)
+	assert.Nil(t, aiRateLimitPolicies)
+	assert.EqualError(t, err, "error: operations[0].endpointConfigurations.production.aiRatelimit: ai rate limiting is not supported for kubernetes service endpoints without endpoint security or a certificate")
+
+	// No AI provider configured
+	apkConf.AIProvider = nil
+	aiProvider, err = gen.GenerateAIProvider(apkConf, organization, uniqueId)
+	assert.Nil(t, err)
+	assert.Nil(t, aiProvider)
+}
@@ -0,0 +1,58 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package ai_generator
+
+import (
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+)
+
+// openAICompatibleFields are the token usage fields of the OpenAI compatible chat completion responses.
+var openAICompatibleFields = crds.RateLimitFields{
+	PromptTokens:     crds.ValueDetails{In: "Body", Value: "$.usage.prompt_tokens"},
+	CompletionTokens: crds.ValueDetails{In: "Body", Value: "$.usage.completion_tokens"},
+	TotalTokens:      crds.ValueDetails{In: "Body", Value: "$.usage.total_tokens"},
+}
+
+// aiProviderPresets holds the built-in AI provider specifications keyed by the provider name.
+var aiProviderPresets = map[string]crds.AIProviderSpec{
+	constants.AI_PROVIDER_OPENAI: {
+		ProviderName:       constants.AI_PROVIDER_OPENAI,
+		ProviderAPIVersion: "v1",
+		SupportedModels:    []string{"gpt-4o", "gpt-4o-mini", "gpt-4-turbo", "gpt-3.5-turbo"},
+		RequestModel:       crds.ValueDetails{In: "Body", Value: "$.model"},
+		ResponseModel:      crds.ValueDetails{In: "Body", Value: "$.model"},
+		RateLimitFields:    openAICompatibleFields,
+	},
+	constants.AI_PROVIDER_AZURE_OPENAI: {
+		ProviderName:       constants.AI_PROVIDER_AZURE_OPENAI,
+		ProviderAPIVersion: "2024-06-01",
+		SupportedModels:    []string{"gpt-4o", "gpt-4o-mini", "gpt-4", "gpt-35-turbo"},
+		RequestModel:       crds.ValueDetails{In: "Body", Value: "$.model"},
+		ResponseModel:      crds.ValueDetails{In: "Body", Value: "$.model"},
+		RateLimitFields:    openAICompatibleFields,
+	},
+	constants.AI_PROVIDER_MISTRAL: {
+		ProviderName:       constants.AI_PROVIDER_MISTRAL,
+		ProviderAPIVersion: "v1",
+		SupportedModels:    []string{"mistral-large-latest", "mistral-small-latest", "open-mistral-nemo", "codestral-latest"},
+		RequestModel:       crds.ValueDetails{In: "Body", Value: "$.model"},
+		ResponseModel:      crds.ValueDetails{In: "Body", Value: "$.model"},
+		RateLimitFields:    openAICompatibleFields,
+	},
+}
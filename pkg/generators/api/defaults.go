@@ -0,0 +1,53 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package api_generator
+
+import (
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+)
+
+// generateEnvConfigs generates the environment configurations referring the provided routes.
+func (g *apiGenerator) generateEnvConfigs(routeNames []string) []crds.EnvConfig {
+	if len(routeNames) == 0 {
+		return nil
+	}
+	return []crds.EnvConfig{{RouteRefs: routeNames}}
+}
+
+// generateAPIProperties generates the API properties from the additional properties of the APK configuration.
+func (g *apiGenerator) generateAPIProperties(apkConf types.APKConf) []crds.Property {
+	var properties []crds.Property
+	if apkConf.AdditionalProperties != nil {
+		for _, additionalProperty := range *apkConf.AdditionalProperties {
+			properties = append(properties, crds.Property{
+				Name:  additionalProperty.Name,
+				Value: additionalProperty.Value,
+			})
+		}
+	}
+	return properties
+}
+
+// getDefinitionFileRef returns the name of the ConfigMap holding the API definition, if the ConfigMap is generated.
+func (g *apiGenerator) getDefinitionFileRef(uniqueId string, hasDefinitionConfigMap bool) string {
+	if !hasDefinitionConfigMap {
+		return ""
+	}
+	return uniqueId + "-definition"
+}
@@ -0,0 +1,65 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package api_generator
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+)
+
+func TestGenerateEnvConfigs(t *testing.T) {
+	g := Generator()
+
+	if envConfigs := g.GenerateEnvConfigs(nil); envConfigs != nil {
+		t.Errorf("Expected no environment configurations, got %v", envConfigs)
+	}
+
+	envConfigs := g.GenerateEnvConfigs([]string{"route-1", "route-2"})
+	if len(envConfigs) != 1 || len(envConfigs[0].RouteRefs) != 2 {
+		t.Errorf("Expected one environment configuration with two routes, got %v", envConfigs)
+	}
+}
+
+func TestGenerateAPIProperties(t *testing.T) {
+	g := Generator()
+	apkConf := types.APKConf{
+		AdditionalProperties: &[]types.AdditionalProperty{
+			{Name: "owner", Value: "hr"},
+		},
+	}
+
+	properties := g.GenerateAPIProperties(apkConf)
+	if len(properties) != 1 || properties[0] != (crds.Property{Name: "owner", Value: "hr"}) {
+		t.Errorf("Expected property owner=hr, got %v", properties)
+	}
+}
+
+func TestGetDefinitionFileRef(t *testing.T) {
+	g := Generator()
+
+	if definitionFileRef := g.GetDefinitionFileRef("unique-id", false); definitionFileRef != "" {
+		t.Errorf("Expected no definition file reference, got %s", definitionFileRef)
+	}
+
+	definitionFileRef := g.GetDefinitionFileRef("unique-id", true)
+	if definitionFileRef != "unique-id-definition" {
+		t.Errorf("Expected definition file reference unique-id-definition, got %s", definitionFileRef)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package api_generator
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// apiGenerator is the interface for the API generator.
+type apiGenerator struct {
+	GenerateEnvConfigs    func(routeNames []string) []crds.EnvConfig
+	GenerateAPIProperties func(apkConf types.APKConf) []crds.Property
+	GetDefinitionFileRef  func(uniqueId string, hasDefinitionConfigMap bool) string
+}
+
+// Generator creates a new API generator.
+func Generator() *apiGenerator {
+	gen := &apiGenerator{}
+	gen.GenerateEnvConfigs = gen.generateEnvConfigs
+	gen.GenerateAPIProperties = gen.generateAPIProperties
+	gen.GetDefinitionFileRef = gen.getDefinitionFileRef
+	return gen
+}
+
+// GenerateAPI generates an API referring the production and sandbox routes based on the provided configurations.
+// The API refers the ConfigMap of its definition only when it is generated, as given by hasDefinitionConfigMap.
+func (g *apiGenerator) GenerateAPI(apkConf types.APKConf, organization types.Organization, uniqueId string, productionRouteNames []string, sandboxRouteNames []string, hasDefinitionConfigMap bool) (*crds.API, error) {
+	if apkConf.Name == "" || apkConf.Version == "" {
+		return nil, errors.New("api name and version are required")
+	}
+	apiType := apkConf.Type
+	if apiType == "" {
+		apiType = constants.API_TYPE_REST
+	}
+	api := crds.API{
+		TypeMeta: v1.TypeMeta{
+			Kind:       constants.API_KIND,
+			APIVersion: constants.DP_V1ALPHA3,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: uniqueId,
+		},
+		Spec: crds.APISpec{
+			APIName:           apkConf.Name,
+			APIVersion:        apkConf.Version,
+			IsDefaultVersion:  apkConf.DefaultVersion,
+			DefinitionFileRef: g.GetDefinitionFileRef(uniqueId, hasDefinitionConfigMap),
+			DefinitionPath:    apkConf.DefinitionPath,
+			Production:        g.GenerateEnvConfigs(productionRouteNames),
+			Sandbox:           g.GenerateEnvConfigs(sandboxRouteNames),
+			APIType:           apiType,
+			BasePath:          utils.RetrieveFullBasePath(apkConf.BasePath, apkConf.Version),
+			Organization:      organization.Name,
+			APIProperties:     g.GenerateAPIProperties(apkConf),
+			Environment:       apkConf.Environment,
+		},
+	}
+	return &api, nil
+}
+
+// GenerateDefinitionConfigMap generates the ConfigMap holding the gzipped API definition referred by the API.
+// No ConfigMap is generated when the API does not expose a definition or no definition is provided.
+func (g *apiGenerator) GenerateDefinitionConfigMap(apkConf types.APKConf, uniqueId string, definition []byte) (*corev1.ConfigMap, error) {
+	if apkConf.DefinitionPath == "" || len(definition) == 0 {
+		return nil, nil
+	}
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(definition); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	configMap := corev1.ConfigMap{
+		TypeMeta: v1.TypeMeta{
+			Kind:       constants.CONFIGMAP_KIND,
+			APIVersion: "v1",
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: g.GetDefinitionFileRef(uniqueId, true),
+		},
+		BinaryData: map[string][]byte{
+			"definition": compressed.Bytes(),
+		},
+	}
+	return &configMap, nil
+}
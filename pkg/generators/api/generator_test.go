@@ -0,0 +1,114 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package api_generator
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	http_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/http"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+// TestGenerator test for Generator
+func TestGenerator(t *testing.T) {
+	apkConf := types.APKConf{
+		Name:           "EmployeeServiceAPI",
+		Version:        "3.14",
+		BasePath:       "/employees-info",
+		Type:           "REST",
+		Environment:    "dev",
+		DefaultVersion: true,
+		DefinitionPath: "/definition",
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-service:8080"),
+			},
+		},
+		Operations: &[]types.Operation{
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true)},
+			{Target: "/employee", Verb: "POST", Secured: ptr.To(true)},
+		},
+	}
+	organization := types.Organization{
+		Name: "wso2",
+	}
+	gatewayConfig := types.GatewayConfigurations{
+		Name:         "wso2-apim",
+		ListenerName: "wso2-apim-gateway",
+		Hostname:     "wso2-apim",
+	}
+	uniqueId := "unique-id"
+
+	endpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.PRODUCTION_TYPE]
+	httpRoute, diags := http_generator.Generator().GenerateHTTPRoute(apkConf, organization, gatewayConfig, *apkConf.Operations, &endpoint, constants.PRODUCTION_TYPE, uniqueId, 1)
+	assert.False(t, diags.HasErrors())
+
+	api, err := Generator().GenerateAPI(apkConf, organization, uniqueId, []string{httpRoute.Name}, nil, true)
+	assert.Nil(t, err)
+	assert.Equal(t, uniqueId, api.Name)
+	assert.Equal(t, []crds.EnvConfig{{RouteRefs: []string{httpRoute.Name}}}, api.Spec.Production)
+	assert.Nil(t, api.Spec.Sandbox)
+	assert.Equal(t, "/employees-info/3.14", api.Spec.BasePath)
+	assert.Equal(t, "wso2", api.Spec.Organization)
+	assert.Equal(t, "dev", api.Spec.Environment)
+	assert.Equal(t, uniqueId+"-definition", api.Spec.DefinitionFileRef)
+	assert.True(t, api.Spec.IsDefaultVersion)
+
+	// The definition ConfigMap is not generated without a definition
+	api, err = Generator().GenerateAPI(apkConf, organization, uniqueId, []string{httpRoute.Name}, nil, false)
+	assert.Nil(t, err)
+	assert.Empty(t, api.Spec.DefinitionFileRef)
+	assert.Equal(t, apkConf.DefinitionPath, api.Spec.DefinitionPath)
+}
+
+func TestGenerateAPIWithoutVersion(t *testing.T) {
+	apkConf := types.APKConf{Name: "EmployeeServiceAPI"}
+
+	api, err := Generator().GenerateAPI(apkConf, types.Organization{}, "unique-id", nil, nil, false)
+	assert.NotNil(t, err)
+	assert.Nil(t, api)
+}
+
+func TestGenerateDefinitionConfigMap(t *testing.T) {
+	apkConf := types.APKConf{Name: "EmployeeServiceAPI", Version: "3.14"}
+	definition := []byte("openapi: 3.0.1")
+
+	configMap, err := Generator().GenerateDefinitionConfigMap(apkConf, "unique-id", definition)
+	assert.Nil(t, err)
+	assert.Nil(t, configMap)
+
+	apkConf.DefinitionPath = "/definition"
+	configMap, err = Generator().GenerateDefinitionConfigMap(apkConf, "unique-id", definition)
+	assert.Nil(t, err)
+	assert.Equal(t, "unique-id-definition", configMap.Name)
+	reader, err := gzip.NewReader(bytes.NewReader(configMap.BinaryData["definition"]))
+	assert.Nil(t, err)
+	decompressed, err := io.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, definition, decompressed)
+}
@@ -0,0 +1,131 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package apipolicy_generator
+
+import (
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// generateAPILevelPolicy generates the APIPolicy targeting the API, or nil when the API has no policies to apply.
+func (g *apiPolicyGenerator) generateAPILevelPolicy(apkConf types.APKConf, uniqueId string) (*crds.APIPolicy, error) {
+	var policySpec crds.PolicySpec
+	hasPolicies := false
+	if apkConf.APIPolicies != nil {
+		policySpec.RequestInterceptors = g.GenerateInterceptorReferences(apkConf.APIPolicies.Request, uniqueId, true)
+		policySpec.ResponseInterceptors = g.GenerateInterceptorReferences(apkConf.APIPolicies.Response, uniqueId, false)
+		policySpec.BackendJWTPolicy = g.GenerateBackendJWTReference(apkConf.APIPolicies.Request, uniqueId)
+		hasPolicies = len(policySpec.RequestInterceptors) > 0 || len(policySpec.ResponseInterceptors) > 0 || policySpec.BackendJWTPolicy != nil
+	}
+	if apkConf.CorsConfig != nil && apkConf.CorsConfig.CORSConfigurationEnabled {
+		policySpec.CORSPolicy = g.GenerateCORSPolicy(*apkConf.CorsConfig)
+		hasPolicies = true
+	}
+	if apkConf.AIProvider != nil {
+		policySpec.AIProvider = &crds.AIProviderReference{
+			Name: utils.GetAIProviderName(uniqueId),
+		}
+		hasPolicies = true
+	}
+	if !hasPolicies {
+		return nil, nil
+	}
+
+	apiPolicy := crds.APIPolicy{
+		TypeMeta: v1.TypeMeta{
+			Kind:       constants.API_POLICY_KIND,
+			APIVersion: constants.DP_V1ALPHA3,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: uniqueId + "-api-policy",
+		},
+		Spec: crds.APIPolicySpec{
+			Default:   &policySpec,
+			TargetRef: utils.GetAPITargetRef(uniqueId),
+		},
+	}
+	return &apiPolicy, nil
+}
+
+// generateOperationPolicy generates the resource level APIPolicy of the operation, applied to the route rules of the operation
+// referring it through ExtensionRef filters, or nil when the operation has no policies applied through an APIPolicy.
+func (g *apiPolicyGenerator) generateOperationPolicy(operation types.Operation, uniqueId string) (*crds.APIPolicy, error) {
+	if operation.OperationPolicies == nil {
+		return nil, nil
+	}
+	policySpec := crds.PolicySpec{
+		RequestInterceptors:  g.GenerateInterceptorReferences(operation.OperationPolicies.Request, uniqueId, true),
+		ResponseInterceptors: g.GenerateInterceptorReferences(operation.OperationPolicies.Response, uniqueId, false),
+		BackendJWTPolicy:     g.GenerateBackendJWTReference(operation.OperationPolicies.Request, uniqueId),
+	}
+	if len(policySpec.RequestInterceptors) == 0 && len(policySpec.ResponseInterceptors) == 0 && policySpec.BackendJWTPolicy == nil {
+		return nil, nil
+	}
+
+	apiPolicy := crds.APIPolicy{
+		TypeMeta: v1.TypeMeta{
+			Kind:       constants.API_POLICY_KIND,
+			APIVersion: constants.DP_V1ALPHA3,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: utils.GetOperationPolicyName(uniqueId, operation, "api-policy"),
+		},
+		Spec: crds.APIPolicySpec{
+			Default:   &policySpec,
+			TargetRef: utils.GetResourceTargetRef(uniqueId),
+		},
+	}
+	return &apiPolicy, nil
+}
+
+// generateCORSPolicy generates the CORS policy based on the provided CORS configuration.
+func (g *apiPolicyGenerator) generateCORSPolicy(corsConfig types.CORSConfiguration) *crds.CORSPolicy {
+	return &crds.CORSPolicy{
+		Enabled:                       corsConfig.CORSConfigurationEnabled,
+		AccessControlAllowCredentials: corsConfig.AccessControlAllowCredentials,
+		AccessControlAllowOrigins:     corsConfig.AccessControlAllowOrigins,
+		AccessControlAllowHeaders:     corsConfig.AccessControlAllowHeaders,
+		AccessControlAllowMethods:     corsConfig.AccessControlAllowMethods,
+	}
+}
+
+// generateInterceptorReferences generates the references to the InterceptorServices of the interceptor policies.
+func (g *apiPolicyGenerator) generateInterceptorReferences(policies []types.OperationPolicy, uniqueId string, isRequest bool) []crds.InterceptorReference {
+	var interceptorReferences []crds.InterceptorReference
+	for _, interceptor := range utils.GetInterceptors(policies) {
+		interceptorReferences = append(interceptorReferences, crds.InterceptorReference{
+			Name: utils.GetInterceptorServiceName(uniqueId, interceptor, isRequest),
+		})
+	}
+	return interceptorReferences
+}
+
+// generateBackendJWTReference generates the reference to the BackendJWT of the backend JWT policy, if any.
+func (g *apiPolicyGenerator) generateBackendJWTReference(policies []types.OperationPolicy, uniqueId string) *crds.BackendJWTReference {
+	backendJWT := utils.GetBackendJWT(policies)
+	if backendJWT == nil {
+		return nil
+	}
+	return &crds.BackendJWTReference{
+		Name: utils.GetBackendJWTName(uniqueId, *backendJWT),
+	}
+}
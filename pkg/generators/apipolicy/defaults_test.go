@@ -0,0 +1,90 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package apipolicy_generator
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateAPILevelPolicy(t *testing.T) {
+	g := Generator()
+	apkConf := types.APKConf{
+		Name:       "ChatAPI",
+		Version:    "1.0",
+		BasePath:   "/chat",
+		AIProvider: &types.AIProvider{Name: "OpenAI"},
+	}
+
+	apiPolicy, err := g.GenerateAPILevelPolicy(apkConf, "unique-id")
+	assert.Nil(t, err)
+	assert.Equal(t, utils.GetAIProviderName("unique-id"), apiPolicy.Spec.Default.AIProvider.Name)
+	assert.Nil(t, apiPolicy.Spec.Default.CORSPolicy)
+
+	apkConf.AIProvider = nil
+	apiPolicy, err = g.GenerateAPILevelPolicy(apkConf, "unique-id")
+	assert.Nil(t, err)
+	assert.Nil(t, apiPolicy)
+}
+
+func TestGenerateCORSPolicy(t *testing.T) {
+	g := Generator()
+	corsConfig := types.CORSConfiguration{
+		CORSConfigurationEnabled:      true,
+		AccessControlAllowOrigins:     []string{"https://example.com"},
+		AccessControlAllowCredentials: true,
+		AccessControlAllowHeaders:     []string{"authorization"},
+		AccessControlAllowMethods:     []string{"GET"},
+	}
+
+	corsPolicy := g.GenerateCORSPolicy(corsConfig)
+	assert.True(t, corsPolicy.Enabled)
+	assert.True(t, corsPolicy.AccessControlAllowCredentials)
+	assert.Equal(t, corsConfig.AccessControlAllowOrigins, corsPolicy.AccessControlAllowOrigins)
+	assert.Equal(t, corsConfig.AccessControlAllowHeaders, corsPolicy.AccessControlAllowHeaders)
+	assert.Equal(t, corsConfig.AccessControlAllowMethods, corsPolicy.AccessControlAllowMethods)
+}
+
+func TestGenerateInterceptorReferences(t *testing.T) {
+	g := Generator()
+	interceptor := types.InterceptorService{BackendURL: "https://interceptor-service:8443", BodyEnabled: true}
+	policies := []types.OperationPolicy{
+		{PolicyName: "AddHeader", Parameters: types.Header{HeaderName: "x-request", HeaderValue: "value"}},
+		{PolicyName: "Interceptor", Parameters: interceptor},
+	}
+
+	interceptorReferences := g.GenerateInterceptorReferences(policies, "unique-id", false)
+	assert.Len(t, interceptorReferences, 1)
+	assert.Equal(t, utils.GetInterceptorServiceName("unique-id", interceptor, false), interceptorReferences[0].Name)
+}
+
+func TestGenerateBackendJWTReference(t *testing.T) {
+	g := Generator()
+	backendJWT := types.BackendJWT{Encoding: "Base64", SigningAlgorithm: "SHA256withRSA", TokenTTL: 3600}
+	policies := []types.OperationPolicy{
+		{PolicyName: "BackendJwt", Parameters: backendJWT},
+	}
+
+	backendJWTReference := g.GenerateBackendJWTReference(policies, "unique-id")
+	assert.Equal(t, utils.GetBackendJWTName("unique-id", backendJWT), backendJWTReference.Name)
+	assert.Nil(t, g.GenerateBackendJWTReference(nil, "unique-id"))
+}
@@ -0,0 +1,74 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package apipolicy_generator
+
+import (
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+)
+
+// apiPolicyGenerator is the interface for the APIPolicy generator.
+type apiPolicyGenerator struct {
+	GenerateAPILevelPolicy        func(apkConf types.APKConf, uniqueId string) (*crds.APIPolicy, error)
+	GenerateOperationPolicy       func(operation types.Operation, uniqueId string) (*crds.APIPolicy, error)
+	GenerateCORSPolicy            func(corsConfig types.CORSConfiguration) *crds.CORSPolicy
+	GenerateInterceptorReferences func(policies []types.OperationPolicy, uniqueId string, isRequest bool) []crds.InterceptorReference
+	GenerateBackendJWTReference   func(policies []types.OperationPolicy, uniqueId string) *crds.BackendJWTReference
+}
+
+// Generator creates a new APIPolicy generator.
+func Generator() *apiPolicyGenerator {
+	gen := &apiPolicyGenerator{}
+	gen.GenerateAPILevelPolicy = gen.generateAPILevelPolicy
+	gen.GenerateOperationPolicy = gen.generateOperationPolicy
+	gen.GenerateCORSPolicy = gen.generateCORSPolicy
+	gen.GenerateInterceptorReferences = gen.generateInterceptorReferences
+	gen.GenerateBackendJWTReference = gen.generateBackendJWTReference
+	return gen
+}
+
+// GenerateAPIPolicies generates the API level APIPolicy and an APIPolicy for each operation with its own policies
+// that is served by any of the route rules, unless API level policies are configured as they take
+// precedence over the operation policies.
+func (g *apiPolicyGenerator) GenerateAPIPolicies(apkConf types.APKConf, uniqueId string, routes []types.RouteReference) ([]*crds.APIPolicy, error) {
+	var apiPolicies []*crds.APIPolicy
+	apiLevelPolicy, err := g.GenerateAPILevelPolicy(apkConf, uniqueId)
+	if err != nil {
+		return nil, err
+	}
+	if apiLevelPolicy != nil {
+		apiPolicies = append(apiPolicies, apiLevelPolicy)
+	}
+
+	if apkConf.Operations != nil && !utils.HasAPIPolicies(apkConf) {
+		for _, operation := range *apkConf.Operations {
+			if operation.OperationPolicies == nil || !utils.HasRouteRule(routes, operation) {
+				continue
+			}
+			operationPolicy, err := g.GenerateOperationPolicy(operation, uniqueId)
+			if err != nil {
+				return nil, err
+			}
+			if operationPolicy != nil {
+				apiPolicies = append(apiPolicies, operationPolicy)
+			}
+		}
+	}
+	return apiPolicies, nil
+}
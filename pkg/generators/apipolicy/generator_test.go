@@ -0,0 +1,103 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package apipolicy_generator
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+// TestGenerator test for Generator
+func TestGenerator(t *testing.T) {
+	apkConf := types.APKConf{
+		Name:     "EmployeeServiceAPI",
+		Version:  "3.14",
+		BasePath: "/employees-info",
+		Type:     "REST",
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-service:8080"),
+			},
+		},
+		APIPolicies: &types.OperationPolicies{
+			Request: []types.OperationPolicy{
+				{PolicyName: "BackendJwt", Parameters: types.BackendJWT{SigningAlgorithm: "SHA256withRSA"}},
+			},
+		},
+		CorsConfig: &types.CORSConfiguration{
+			CORSConfigurationEnabled:  true,
+			AccessControlAllowOrigins: []string{"*"},
+			AccessControlAllowMethods: []string{"GET", "POST"},
+		},
+		Operations: &[]types.Operation{
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true)},
+			{
+				Target:  "/employee",
+				Verb:    "POST",
+				Secured: ptr.To(true),
+				OperationPolicies: &types.OperationPolicies{
+					Request: []types.OperationPolicy{
+						{PolicyName: "Interceptor", Parameters: types.InterceptorService{BackendURL: "https://interceptor-service:8443", HeadersEnabled: true}},
+					},
+				},
+			},
+		},
+	}
+	uniqueId := "unique-id"
+	routes := []types.RouteReference{
+		{
+			Kind:      "HTTPRoute",
+			Name:      "unique-id-production-httproute-1",
+			RuleNames: []string{utils.GetRuleName((*apkConf.Operations)[0]), utils.GetRuleName((*apkConf.Operations)[1])},
+		},
+	}
+
+	gen := Generator()
+
+	// Operation policies are ignored as API level policies are configured
+	apiPolicies, err := gen.GenerateAPIPolicies(apkConf, uniqueId, routes)
+	assert.Nil(t, err)
+	assert.Len(t, apiPolicies, 1)
+	assert.Equal(t, "unique-id-api-policy", apiPolicies[0].Name)
+	assert.Equal(t, "API", string(apiPolicies[0].Spec.TargetRef.Kind))
+	assert.Equal(t, []string{"*"}, apiPolicies[0].Spec.Default.CORSPolicy.AccessControlAllowOrigins)
+	assert.Nil(t, apiPolicies[0].Spec.Default.AIProvider)
+	assert.NotNil(t, apiPolicies[0].Spec.Default.BackendJWTPolicy)
+
+	apkConf.APIPolicies = nil
+	apiPolicies, err = gen.GenerateAPIPolicies(apkConf, uniqueId, routes)
+	assert.Nil(t, err)
+	assert.Len(t, apiPolicies, 2)
+	assert.Nil(t, apiPolicies[0].Spec.Default.BackendJWTPolicy)
+	assert.Equal(t, utils.GetResourceTargetRef(uniqueId), apiPolicies[1].Spec.TargetRef)
+	assert.Equal(t, apiPolicies[1].Name, string(utils.GetOperationExtensionRefs(apkConf, (*apkConf.Operations)[1], uniqueId)[0].Name))
+	assert.Len(t, apiPolicies[1].Spec.Default.RequestInterceptors, 1)
+	assert.Empty(t, apiPolicies[1].Spec.Default.ResponseInterceptors)
+
+	// No policies configured
+	apkConf.CorsConfig.CORSConfigurationEnabled = false
+	apkConf.Operations = &[]types.Operation{(*apkConf.Operations)[0]}
+	apiPolicies, err = gen.GenerateAPIPolicies(apkConf, uniqueId, routes)
+	assert.Nil(t, err)
+	assert.Empty(t, apiPolicies)
+}
@@ -0,0 +1,139 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package authentication_generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// generateAPIAuthentication generates the Authentication targeting the API based on the provided configurations.
+func (g *authenticationGenerator) generateAPIAuthentication(apkConf types.APKConf, uniqueId string) (*crds.Authentication, error) {
+	if apkConf.Authentication == nil || len(*apkConf.Authentication) == 0 {
+		return nil, nil
+	}
+	authTypes, err := g.GenerateAuthTypes(*apkConf.Authentication)
+	if err != nil {
+		return nil, err
+	}
+	authentication := crds.Authentication{
+		TypeMeta: v1.TypeMeta{
+			Kind:       constants.AUTHENTICATION_KIND,
+			APIVersion: constants.DP_V1ALPHA2,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: uniqueId + "-authentication",
+		},
+		Spec: crds.AuthenticationSpec{
+			Default: &crds.AuthSpec{
+				AuthTypes: authTypes,
+			},
+			TargetRef: utils.GetAPITargetRef(uniqueId),
+		},
+	}
+	return &authentication, nil
+}
+
+// generateOperationAuthentication generates the resource level Authentication disabling the authentication of the operation,
+// applied to the route rules of the operation referring it through ExtensionRef filters.
+func (g *authenticationGenerator) generateOperationAuthentication(operation types.Operation, uniqueId string) *crds.Authentication {
+	disabled := true
+	return &crds.Authentication{
+		TypeMeta: v1.TypeMeta{
+			Kind:       constants.AUTHENTICATION_KIND,
+			APIVersion: constants.DP_V1ALPHA2,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: utils.GetOperationPolicyName(uniqueId, operation, "authentication"),
+		},
+		Spec: crds.AuthenticationSpec{
+			Default: &crds.AuthSpec{
+				Disabled: &disabled,
+			},
+			TargetRef: utils.GetResourceTargetRef(uniqueId),
+		},
+	}
+}
+
+// generateAuthTypes generates the authentication type configurations from the APK authentication configurations.
+func (g *authenticationGenerator) generateAuthTypes(authConfigs []types.AuthConfiguration) (*crds.APIAuth, error) {
+	authTypes := crds.APIAuth{}
+	for _, authConfig := range authConfigs {
+		if strings.EqualFold(authConfig.AuthType, constants.AUTH_TYPE_OAUTH2) {
+			authTypes.OAuth2 = &crds.OAuth2Auth{
+				Required:            authConfig.Required,
+				Disabled:            !utils.IsAuthenticationEnabled(authConfig),
+				Header:              authConfig.HeaderName,
+				SendTokenToUpstream: authConfig.SendTokenUpStream,
+			}
+		} else if strings.EqualFold(authConfig.AuthType, constants.AUTH_TYPE_API_KEY) {
+			authTypes.APIKey = &crds.APIKeyAuth{
+				Required: authConfig.Required,
+				Disabled: !utils.IsAuthenticationEnabled(authConfig),
+				Keys:     generateAPIKeys(authConfig),
+			}
+		} else if strings.EqualFold(authConfig.AuthType, constants.AUTH_TYPE_MTLS) {
+			var configMapRefs []crds.RefConfig
+			for _, certificate := range authConfig.Certificates {
+				configMapRefs = append(configMapRefs, crds.RefConfig{Name: certificate.Name, Key: certificate.Key})
+			}
+			authTypes.MutualSSL = &crds.MutualSSLConfig{
+				Required:      authConfig.Required,
+				Disabled:      !utils.IsAuthenticationEnabled(authConfig),
+				ConfigMapRefs: configMapRefs,
+			}
+		} else if strings.EqualFold(authConfig.AuthType, constants.AUTH_TYPE_JWT) {
+			authTypes.JWT = &crds.JWTAuth{
+				Disabled:            !utils.IsAuthenticationEnabled(authConfig),
+				Header:              authConfig.HeaderName,
+				SendTokenToUpstream: authConfig.SendTokenUpStream,
+				Audience:            authConfig.Audience,
+			}
+		} else {
+			return nil, fmt.Errorf("unsupported authentication type: %q", authConfig.AuthType)
+		}
+	}
+	return &authTypes, nil
+}
+
+// generateAPIKeys generates the header and query parameter API keys, defaulting to the apiKey header.
+func generateAPIKeys(authConfig types.AuthConfiguration) []crds.APIKey {
+	var apiKeys []crds.APIKey
+	if authConfig.HeaderEnabled || !authConfig.QueryParamEnable {
+		headerName := authConfig.HeaderName
+		if headerName == "" {
+			headerName = "apiKey"
+		}
+		apiKeys = append(apiKeys, crds.APIKey{In: "Header", Name: headerName, SendTokenToUpstream: authConfig.SendTokenUpStream})
+	}
+	if authConfig.QueryParamEnable {
+		queryParamName := authConfig.QueryParamName
+		if queryParamName == "" {
+			queryParamName = "apiKey"
+		}
+		apiKeys = append(apiKeys, crds.APIKey{In: "Query", Name: queryParamName, SendTokenToUpstream: authConfig.SendTokenUpStream})
+	}
+	return apiKeys
+}
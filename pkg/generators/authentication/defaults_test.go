@@ -0,0 +1,83 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package authentication_generator
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func TestGenerateAPIAuthentication(t *testing.T) {
+	g := Generator()
+
+	authentication, err := g.GenerateAPIAuthentication(types.APKConf{}, "unique-id")
+	assert.Nil(t, err)
+	assert.Nil(t, authentication)
+
+	apkConf := types.APKConf{
+		Authentication: &[]types.AuthConfiguration{
+			{AuthType: "OAuth2", Enabled: ptr.To(true), Required: "mandatory", HeaderName: "Authorization"},
+		},
+	}
+	authentication, err = g.GenerateAPIAuthentication(apkConf, "unique-id")
+	assert.Nil(t, err)
+	assert.Equal(t, "unique-id-authentication", authentication.Name)
+	assert.Equal(t, utils.GetAPITargetRef("unique-id"), authentication.Spec.TargetRef)
+	assert.Equal(t, &crds.OAuth2Auth{Required: "mandatory", Header: "Authorization"}, authentication.Spec.Default.AuthTypes.OAuth2)
+}
+
+func TestGenerateOperationAuthentication(t *testing.T) {
+	g := Generator()
+	operation := types.Operation{Target: "/employees", Verb: "GET"}
+	authentication := g.GenerateOperationAuthentication(operation, "unique-id")
+	assert.Equal(t, "unique-id-"+utils.GetRuleName(operation)+"-authentication", authentication.Name)
+	assert.Equal(t, utils.GetResourceTargetRef("unique-id"), authentication.Spec.TargetRef)
+	assert.True(t, *authentication.Spec.Default.Disabled)
+}
+
+func TestGenerateAuthTypes(t *testing.T) {
+	g := Generator()
+	authConfigs := []types.AuthConfiguration{
+		{AuthType: "APIKey", Enabled: ptr.To(true), HeaderEnabled: true, HeaderName: "x-api-key", QueryParamEnable: true, QueryParamName: "key"},
+		{AuthType: "mTLS", Enabled: ptr.To(true), Required: "optional", Certificates: []types.Certificate{{Name: "mtls-configmap", Key: "tls.crt"}}},
+		{AuthType: "JWT", Enabled: ptr.To(true), Audience: []string{"employees"}},
+	}
+
+	authTypes, err := g.GenerateAuthTypes(authConfigs)
+	assert.Nil(t, err)
+	assert.Equal(t, []crds.APIKey{{In: "Header", Name: "x-api-key"}, {In: "Query", Name: "key"}}, authTypes.APIKey.Keys)
+	assert.Equal(t, []crds.RefConfig{{Name: "mtls-configmap", Key: "tls.crt"}}, authTypes.MutualSSL.ConfigMapRefs)
+	assert.Equal(t, "optional", authTypes.MutualSSL.Required)
+	assert.Equal(t, []string{"employees"}, authTypes.JWT.Audience)
+	assert.Nil(t, authTypes.OAuth2)
+
+	// Authentication types are enabled unless enabled is set to false
+	authTypes, err = g.GenerateAuthTypes([]types.AuthConfiguration{{AuthType: "OAuth2"}, {AuthType: "APIKey", Enabled: ptr.To(false)}})
+	assert.Nil(t, err)
+	assert.False(t, authTypes.OAuth2.Disabled)
+	assert.True(t, authTypes.APIKey.Disabled)
+
+	_, err = g.GenerateAuthTypes([]types.AuthConfiguration{{AuthType: "Basic"}})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,63 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package authentication_generator
+
+import (
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+)
+
+// authenticationGenerator is the interface for the Authentication generator.
+type authenticationGenerator struct {
+	GenerateAPIAuthentication       func(apkConf types.APKConf, uniqueId string) (*crds.Authentication, error)
+	GenerateOperationAuthentication func(operation types.Operation, uniqueId string) *crds.Authentication
+	GenerateAuthTypes               func(authConfigs []types.AuthConfiguration) (*crds.APIAuth, error)
+}
+
+// Generator creates a new Authentication generator.
+func Generator() *authenticationGenerator {
+	gen := &authenticationGenerator{}
+	gen.GenerateAPIAuthentication = gen.generateAPIAuthentication
+	gen.GenerateOperationAuthentication = gen.generateOperationAuthentication
+	gen.GenerateAuthTypes = gen.generateAuthTypes
+	return gen
+}
+
+// GenerateAuthentications generates the API level Authentication and an Authentication disabling
+// the authentication of each operation that is not secured and is served by any of the route rules.
+func (g *authenticationGenerator) GenerateAuthentications(apkConf types.APKConf, uniqueId string, routes []types.RouteReference) ([]*crds.Authentication, error) {
+	var authentications []*crds.Authentication
+	apiAuthentication, err := g.GenerateAPIAuthentication(apkConf, uniqueId)
+	if err != nil {
+		return nil, err
+	}
+	if apiAuthentication != nil {
+		authentications = append(authentications, apiAuthentication)
+	}
+
+	if apkConf.Operations != nil {
+		for _, operation := range *apkConf.Operations {
+			if utils.IsOperationSecured(operation) || !utils.HasRouteRule(routes, operation) {
+				continue
+			}
+			authentications = append(authentications, g.GenerateOperationAuthentication(operation, uniqueId))
+		}
+	}
+	return authentications, nil
+}
@@ -0,0 +1,89 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package authentication_generator
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	http_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/http"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+// TestGenerator test for Generator
+func TestGenerator(t *testing.T) {
+	apkConf := types.APKConf{
+		Name:     "EmployeeServiceAPI",
+		Version:  "3.14",
+		BasePath: "/employees-info",
+		Type:     "REST",
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-service:8080"),
+			},
+		},
+		Authentication: &[]types.AuthConfiguration{
+			{
+				AuthType: "APIKey",
+				Enabled:  ptr.To(true),
+			},
+		},
+		Operations: &[]types.Operation{
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(false)},
+			{Target: "/employee", Verb: "POST", Secured: ptr.To(true)},
+			// Operations omitting secured are secured
+			{Target: "/employee/{employeeId}", Verb: "PUT"},
+		},
+	}
+	organization := types.Organization{
+		Name: "wso2",
+	}
+	gatewayConfig := types.GatewayConfigurations{
+		Name:         "wso2-apim",
+		ListenerName: "wso2-apim-gateway",
+		Hostname:     "wso2-apim",
+	}
+	uniqueId := "unique-id"
+
+	endpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.PRODUCTION_TYPE]
+	httpRoute, diags := http_generator.Generator().GenerateHTTPRoute(apkConf, organization, gatewayConfig, *apkConf.Operations, &endpoint, constants.PRODUCTION_TYPE, uniqueId, 1)
+	assert.False(t, diags.HasErrors())
+
+	authentications, err := Generator().GenerateAuthentications(apkConf, uniqueId, []types.RouteReference{utils.GetHTTPRouteReference(httpRoute)})
+	assert.Nil(t, err)
+	assert.Len(t, authentications, 2)
+
+	// API level authentication
+	assert.Equal(t, "API", string(authentications[0].Spec.TargetRef.Kind))
+	assert.Equal(t, uniqueId, string(authentications[0].Spec.TargetRef.Name))
+	assert.NotNil(t, authentications[0].Spec.Default.AuthTypes.APIKey)
+
+	// Authentication disabled for the unsecured operation, referred by the route rule of the operation
+	assert.Equal(t, "Resource", string(authentications[1].Spec.TargetRef.Kind))
+	assert.Equal(t, uniqueId, string(authentications[1].Spec.TargetRef.Name))
+	assert.Nil(t, authentications[1].Spec.TargetRef.SectionName)
+	assert.True(t, *authentications[1].Spec.Default.Disabled)
+	extensionRef := httpRoute.Spec.Rules[0].Filters[len(httpRoute.Spec.Rules[0].Filters)-1].ExtensionRef
+	assert.Equal(t, "Authentication", string(extensionRef.Kind))
+	assert.Equal(t, authentications[1].Name, string(extensionRef.Name))
+}
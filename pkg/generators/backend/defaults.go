@@ -0,0 +1,106 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package backend_generator
+
+import (
+	"fmt"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// generateBackend generates a Backend based on the provided endpoint configuration and endpoint details.
+func (g *backendGenerator) generateBackend(endpointConfig types.EndpointConfiguration, endpoint types.EndpointDetails) (*crds.Backend, error) {
+	services, err := g.GenerateBackendServices(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	backend := crds.Backend{
+		TypeMeta: v1.TypeMeta{
+			Kind:       constants.BACKEND_KIND,
+			APIVersion: constants.DP_V1ALPHA2,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: endpoint.Name,
+		},
+		Spec: crds.BackendSpec{
+			Services: services,
+			Protocol: utils.GetProtocol(endpoint.URL),
+			BasePath: utils.GetPath(endpoint.URL),
+			TLS:      g.GenerateBackendTLS(endpointConfig.EndCertificate),
+			Security: g.GenerateBackendSecurity(endpointConfig.EndSecurity),
+		},
+	}
+	return &backend, nil
+}
+
+// generateBackendServices generates the Backend services from the host and port of the endpoint URL.
+func (g *backendGenerator) generateBackendServices(endpoint types.EndpointDetails) ([]crds.BackendService, error) {
+	host := utils.GetHost(types.EndpointURL(endpoint.URL))
+	port := utils.GetPort(endpoint.URL)
+	if host == "" || port <= 0 {
+		return nil, fmt.Errorf("invalid endpoint url: %q", endpoint.URL)
+	}
+	return []crds.BackendService{{Host: host, Port: uint32(port)}}, nil
+}
+
+// generateBackendTLS generates the Backend TLS configuration from the endpoint certificate.
+func (g *backendGenerator) generateBackendTLS(certificate types.EndpointCertificate) *crds.TLSConfig {
+	if certificate.Name == "" {
+		return nil
+	}
+	return &crds.TLSConfig{
+		SecretRef: &crds.RefConfig{
+			Name: certificate.Name,
+			Key:  certificate.Key,
+		},
+	}
+}
+
+// generateBackendSecurity generates the Backend security configuration from the endpoint security.
+func (g *backendGenerator) generateBackendSecurity(security types.EndpointSecurity) *crds.SecurityConfig {
+	if !security.Enabled {
+		return nil
+	}
+	secretInfo := security.SecurityType
+	if secretInfo.APIKeyNameKey != "" {
+		return &crds.SecurityConfig{
+			APIKey: &crds.APIKeySecurityConfig{
+				In:   secretInfo.In,
+				Name: secretInfo.APIKeyNameKey,
+				ValueFrom: crds.ValueRef{
+					Name:     secretInfo.SecretName,
+					ValueKey: secretInfo.APIKeyValueKey,
+				},
+			},
+		}
+	}
+	return &crds.SecurityConfig{
+		Basic: &crds.BasicSecurityConfig{
+			SecretRef: crds.SecretRef{
+				Name:        secretInfo.SecretName,
+				UsernameKey: secretInfo.UsernameKey,
+				PasswordKey: secretInfo.PasswordKey,
+			},
+		},
+	}
+}
@@ -0,0 +1,120 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package backend_generator
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+)
+
+func TestGenerateBackend(t *testing.T) {
+	g := Generator()
+	endpointConfig := types.EndpointConfiguration{
+		Endpoint: types.EndpointURL("https://employee-service:8443/api"),
+		EndCertificate: types.EndpointCertificate{
+			Name: "employee-service-cert",
+			Key:  "ca.crt",
+		},
+	}
+	endpoint := utils.CreateEndpointDetails("unique-id", endpointConfig)
+
+	backend, err := g.GenerateBackend(endpointConfig, endpoint)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if backend.ObjectMeta.Name != endpoint.Name {
+		t.Errorf("Expected name %s, got %s", endpoint.Name, backend.ObjectMeta.Name)
+	}
+	if backend.Spec.Protocol != "https" {
+		t.Errorf("Expected protocol https, got %s", backend.Spec.Protocol)
+	}
+	if backend.Spec.TLS == nil || backend.Spec.TLS.SecretRef.Name != "employee-service-cert" {
+		t.Errorf("Expected TLS secret reference employee-service-cert, got %v", backend.Spec.TLS)
+	}
+	if backend.Spec.Security != nil {
+		t.Errorf("Expected no security configuration, got %v", backend.Spec.Security)
+	}
+}
+
+func TestGenerateBackendServices(t *testing.T) {
+	g := Generator()
+	endpoint := types.EndpointDetails{URL: "http://employee-service:8080/api"}
+
+	services, err := g.GenerateBackendServices(endpoint)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(services) != 1 || services[0].Host != "employee-service" || services[0].Port != 8080 {
+		t.Errorf("Expected service employee-service:8080, got %v", services)
+	}
+
+	if _, err := g.GenerateBackendServices(types.EndpointDetails{URL: "employee-service"}); err == nil {
+		t.Errorf("Expected an error for an invalid endpoint url, got nil")
+	}
+}
+
+func TestGenerateBackendTLS(t *testing.T) {
+	g := Generator()
+
+	if tls := g.GenerateBackendTLS(types.EndpointCertificate{}); tls != nil {
+		t.Errorf("Expected no TLS configuration, got %v", tls)
+	}
+
+	tls := g.GenerateBackendTLS(types.EndpointCertificate{Name: "cert-secret", Key: "ca.crt"})
+	if tls == nil || *tls.SecretRef != (crds.RefConfig{Name: "cert-secret", Key: "ca.crt"}) {
+		t.Errorf("Expected TLS secret reference cert-secret/ca.crt, got %v", tls)
+	}
+}
+
+func TestGenerateBackendSecurity(t *testing.T) {
+	g := Generator()
+
+	if security := g.GenerateBackendSecurity(types.EndpointSecurity{Enabled: false}); security != nil {
+		t.Errorf("Expected no security configuration, got %v", security)
+	}
+
+	basic := g.GenerateBackendSecurity(types.EndpointSecurity{
+		Enabled: true,
+		SecurityType: types.SecretInfo{
+			SecretName:  "backend-creds",
+			UsernameKey: "username",
+			PasswordKey: "password",
+		},
+	})
+	if basic == nil || basic.Basic == nil || basic.Basic.SecretRef.Name != "backend-creds" {
+		t.Errorf("Expected basic auth security configuration, got %v", basic)
+	}
+
+	apiKey := g.GenerateBackendSecurity(types.EndpointSecurity{
+		Enabled: true,
+		SecurityType: types.SecretInfo{
+			SecretName:     "backend-api-key",
+			In:             "Header",
+			APIKeyNameKey:  "x-api-key",
+			APIKeyValueKey: "key",
+		},
+	})
+	if apiKey == nil || apiKey.APIKey == nil || apiKey.APIKey.Name != "x-api-key" || apiKey.APIKey.ValueFrom.Name != "backend-api-key" {
+		t.Errorf("Expected API key security configuration, got %v", apiKey)
+	}
+}
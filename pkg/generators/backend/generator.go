@@ -0,0 +1,123 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package backend_generator
+
+import (
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+)
+
+// backendGenerator is the interface for the Backend generator.
+type backendGenerator struct {
+	GenerateBackend         func(endpointConfig types.EndpointConfiguration, endpoint types.EndpointDetails) (*crds.Backend, error)
+	GenerateBackendServices func(endpoint types.EndpointDetails) ([]crds.BackendService, error)
+	GenerateBackendTLS      func(certificate types.EndpointCertificate) *crds.TLSConfig
+	GenerateBackendSecurity func(security types.EndpointSecurity) *crds.SecurityConfig
+}
+
+// Generator creates a new Backend generator.
+func Generator() *backendGenerator {
+	gen := &backendGenerator{}
+	gen.GenerateBackend = gen.generateBackend
+	gen.GenerateBackendServices = gen.generateBackendServices
+	gen.GenerateBackendTLS = gen.generateBackendTLS
+	gen.GenerateBackendSecurity = gen.generateBackendSecurity
+	return gen
+}
+
+// GenerateBackends generates the Backends of the given endpoint type from the API and operation level endpoint configurations.
+// Kubernetes services without endpoint security or a certificate are referred to directly and need no Backend.
+func (g *backendGenerator) GenerateBackends(apkConf types.APKConf, endpointType string, uniqueId string) ([]*crds.Backend, error) {
+	var backends []*crds.Backend
+	generatedBackends := make(map[string]bool)
+	endpointConfigurations := []*types.EndpointConfigurations{apkConf.EndpointConfigurations}
+	if apkConf.Operations != nil {
+		for _, operation := range *apkConf.Operations {
+			endpointConfigurations = append(endpointConfigurations, operation.EndpointConfigurations)
+		}
+	}
+
+	for _, endpointConfigs := range endpointConfigurations {
+		endpointConfig := utils.GetEndpointConfiguration(endpointConfigs, endpointType)
+		if endpointConfig == nil || endpointConfig.Endpoint == nil {
+			continue
+		}
+		endpoint := utils.CreateEndpointDetails(uniqueId, *endpointConfig)
+		if endpoint.ServiceEntry || generatedBackends[endpoint.Name] {
+			continue
+		}
+		backend, err := g.GenerateBackend(*endpointConfig, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		generatedBackends[endpoint.Name] = true
+		backends = append(backends, backend)
+	}
+	return backends, nil
+}
+
+// GenerateInterceptorBackends generates the Backends of the interceptor services configured in the API and operation policies.
+func (g *backendGenerator) GenerateInterceptorBackends(apkConf types.APKConf, uniqueId string) ([]*crds.Backend, error) {
+	return g.generatePolicyBackends(apkConf, uniqueId, func(policies types.OperationPolicies) []types.EndpointConfiguration {
+		var endpointConfigs []types.EndpointConfiguration
+		interceptors := append(utils.GetInterceptors(policies.Request), utils.GetInterceptors(policies.Response)...)
+		for _, interceptor := range interceptors {
+			endpointConfigs = append(endpointConfigs, utils.GetInterceptorEndpointConfiguration(interceptor))
+		}
+		return endpointConfigs
+	})
+}
+
+// GenerateMirrorBackends generates the Backends of the request mirror URLs configured in the API and operation policies.
+func (g *backendGenerator) GenerateMirrorBackends(apkConf types.APKConf, uniqueId string) ([]*crds.Backend, error) {
+	return g.generatePolicyBackends(apkConf, uniqueId, func(policies types.OperationPolicies) []types.EndpointConfiguration {
+		var endpointConfigs []types.EndpointConfiguration
+		for _, url := range utils.GetMirrorURLs(policies.Request) {
+			endpointConfigs = append(endpointConfigs, types.EndpointConfiguration{
+				Endpoint: types.EndpointURL(url),
+			})
+		}
+		return endpointConfigs
+	})
+}
+
+// generatePolicyBackends generates a Backend for each endpoint configuration referred by the API and operation policies,
+// leaving out the operation policies when API level policies are configured.
+func (g *backendGenerator) generatePolicyBackends(apkConf types.APKConf, uniqueId string, getEndpointConfigs func(policies types.OperationPolicies) []types.EndpointConfiguration) ([]*crds.Backend, error) {
+	var backends []*crds.Backend
+	generatedBackends := make(map[string]bool)
+	for _, policies := range utils.GetAppliedPolicies(apkConf) {
+		if policies == nil {
+			continue
+		}
+		for _, endpointConfig := range getEndpointConfigs(*policies) {
+			endpoint := utils.CreateEndpointDetails(uniqueId, endpointConfig)
+			if generatedBackends[endpoint.Name] {
+				continue
+			}
+			backend, err := g.GenerateBackend(endpointConfig, endpoint)
+			if err != nil {
+				return nil, err
+			}
+			generatedBackends[endpoint.Name] = true
+			backends = append(backends, backend)
+		}
+	}
+	return backends, nil
+}
@@ -0,0 +1,140 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package backend_generator
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+// TestGenerator test for Generator
+func TestGenerator(t *testing.T) {
+	apkConf := types.APKConf{
+		Name:     "EmployeeServiceAPI",
+		Version:  "3.14",
+		BasePath: "/employees-info",
+		Type:     "REST",
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-service:8080"),
+			},
+			Sandbox: &types.EndpointConfiguration{
+				Endpoint: types.K8sService{Name: "employee-service", Namespace: "apk", Port: "8080", Protocol: "http"},
+			},
+		},
+		Operations: &[]types.Operation{
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true)},
+			{
+				Target: "/employee",
+				Verb:   "POST",
+				EndpointConfigurations: &types.EndpointConfigurations{
+					Production: &types.EndpointConfiguration{
+						Endpoint: types.EndpointURL("https://employee-writer:8443/api"),
+					},
+				},
+			},
+			{
+				Target: "/employee/{employeeId}",
+				Verb:   "PUT",
+				EndpointConfigurations: &types.EndpointConfigurations{
+					Production: &types.EndpointConfiguration{
+						Endpoint: types.EndpointURL("http://employee-service:8080"),
+					},
+				},
+			},
+		},
+	}
+
+	uniqueId := "unique-id"
+	gen := Generator()
+
+	backends, err := gen.GenerateBackends(apkConf, constants.PRODUCTION_TYPE, uniqueId)
+	assert.Nil(t, err)
+	assert.Len(t, backends, 2)
+
+	// Backend names match the names referred by the route backend refs
+	endpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.PRODUCTION_TYPE]
+	assert.Equal(t, string(utils.GenerateBackendObjectReference(endpoint).Name), backends[0].Name)
+	operationEndpoint := utils.GetEndpointToUse((*apkConf.Operations)[1].EndpointConfigurations, constants.PRODUCTION_TYPE, uniqueId)
+	assert.Equal(t, operationEndpoint.Name, backends[1].Name)
+	assert.Equal(t, []crds.BackendService{{Host: "employee-writer", Port: 8443}}, backends[1].Spec.Services)
+	assert.Equal(t, "https", backends[1].Spec.Protocol)
+	assert.Equal(t, "/api", backends[1].Spec.BasePath)
+
+	// Kubernetes service endpoints are referred directly
+	sandboxBackends, err := gen.GenerateBackends(apkConf, constants.SANDBOX_TYPE, uniqueId)
+	assert.Nil(t, err)
+	assert.Empty(t, sandboxBackends)
+
+	// Kubernetes services with endpoint security are referred through a Backend carrying the security
+	apkConf.EndpointConfigurations.Sandbox.EndSecurity = types.EndpointSecurity{
+		Enabled:      true,
+		SecurityType: types.SecretInfo{SecretName: "employee-creds", UsernameKey: "username", PasswordKey: "password"},
+	}
+	sandboxBackends, err = gen.GenerateBackends(apkConf, constants.SANDBOX_TYPE, uniqueId)
+	assert.Nil(t, err)
+	assert.Len(t, sandboxBackends, 1)
+	sandboxEndpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.SANDBOX_TYPE]
+	assert.Equal(t, string(utils.GenerateBackendObjectReference(sandboxEndpoint).Name), sandboxBackends[0].Name)
+	assert.Equal(t, []crds.BackendService{{Host: "employee-service.apk.svc.cluster.local", Port: 8080}}, sandboxBackends[0].Spec.Services)
+	assert.Equal(t, "employee-creds", sandboxBackends[0].Spec.Security.Basic.SecretRef.Name)
+	apkConf.EndpointConfigurations.Sandbox.EndSecurity = types.EndpointSecurity{}
+
+	// Interceptor backends
+	apkConf.APIPolicies = &types.OperationPolicies{
+		Request: []types.OperationPolicy{
+			{PolicyName: "Interceptor", Parameters: types.InterceptorService{BackendURL: "https://interceptor-service:8443", TLSSecretName: "interceptor-cert", TLSSecretKey: "ca.crt"}},
+		},
+		Response: []types.OperationPolicy{
+			{PolicyName: "Interceptor", Parameters: types.InterceptorService{BackendURL: "https://interceptor-service:8443", BodyEnabled: true}},
+		},
+	}
+	interceptorBackends, err := gen.GenerateInterceptorBackends(apkConf, uniqueId)
+	assert.Nil(t, err)
+	// The interceptors share the URL but not the TLS configuration, so each gets its own backend
+	assert.Len(t, interceptorBackends, 2)
+	assert.Equal(t, utils.GetBackendName(uniqueId, utils.GetInterceptorEndpointConfiguration(apkConf.APIPolicies.Request[0].Parameters.(types.InterceptorService))), interceptorBackends[0].Name)
+	assert.Equal(t, "interceptor-cert", interceptorBackends[0].Spec.TLS.SecretRef.Name)
+	assert.NotEqual(t, interceptorBackends[0].Name, interceptorBackends[1].Name)
+	assert.Nil(t, interceptorBackends[1].Spec.TLS)
+
+	// Request mirror backends
+	(*apkConf.Operations)[0].OperationPolicies = &types.OperationPolicies{
+		Request: []types.OperationPolicy{
+			{PolicyName: "RequestMirror", Parameters: types.URLList{URLs: []string{"http://mirror-service:9090", "http://mirror-service:9090"}}},
+		},
+	}
+	mirrorBackends, err := gen.GenerateMirrorBackends(apkConf, uniqueId)
+	assert.Nil(t, err)
+	// Operation policies are ignored as API level policies are configured
+	assert.Empty(t, mirrorBackends)
+
+	apkConf.APIPolicies = nil
+	mirrorBackends, err = gen.GenerateMirrorBackends(apkConf, uniqueId)
+	assert.Nil(t, err)
+	assert.Len(t, mirrorBackends, 1)
+	assert.Equal(t, utils.GetBackendName(uniqueId, types.EndpointConfiguration{Endpoint: types.EndpointURL("http://mirror-service:9090")}), mirrorBackends[0].Name)
+	assert.Equal(t, []crds.BackendService{{Host: "mirror-service", Port: 9090}}, mirrorBackends[0].Spec.Services)
+}
@@ -0,0 +1,84 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package backendjwt_generator
+
+import (
+	"fmt"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// generateBackendJWT generates a BackendJWT after validating the signing algorithm, encoding and token TTL.
+func (g *backendJWTGenerator) generateBackendJWT(backendJWT types.BackendJWT, name string) (*crds.BackendJWT, error) {
+	signingAlgorithm, err := g.GetSigningAlgorithm(backendJWT.SigningAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	encoding, err := g.GetEncoding(backendJWT.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	if backendJWT.TokenTTL < 0 {
+		return nil, fmt.Errorf("invalid backend jwt token ttl: %d", backendJWT.TokenTTL)
+	}
+	backendJWTResource := crds.BackendJWT{
+		TypeMeta: v1.TypeMeta{
+			Kind:       constants.BACKEND_JWT_KIND,
+			APIVersion: constants.DP_V1ALPHA1,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: name,
+		},
+		Spec: crds.BackendJWTSpec{
+			Encoding:         encoding,
+			Header:           backendJWT.Header,
+			SigningAlgorithm: signingAlgorithm,
+			TokenTTL:         uint32(backendJWT.TokenTTL),
+		},
+	}
+	return &backendJWTResource, nil
+}
+
+// getSigningAlgorithm returns the supported signing algorithm matching the given algorithm, ignoring case.
+// An empty algorithm is left to the BackendJWT default.
+func (g *backendJWTGenerator) getSigningAlgorithm(signingAlgorithm string) (string, error) {
+	if signingAlgorithm == "" {
+		return "", nil
+	}
+	if supportedAlgorithm, ok := utils.GetBackendJWTSigningAlgorithm(signingAlgorithm); ok {
+		return supportedAlgorithm, nil
+	}
+	return "", fmt.Errorf("unsupported backend jwt signing algorithm: %q", signingAlgorithm)
+}
+
+// getEncoding returns the supported encoding matching the given encoding, ignoring case.
+// An empty encoding is left to the BackendJWT default.
+func (g *backendJWTGenerator) getEncoding(encoding string) (string, error) {
+	if encoding == "" {
+		return "", nil
+	}
+	if supportedEncoding, ok := utils.GetBackendJWTEncoding(encoding); ok {
+		return supportedEncoding, nil
+	}
+	return "", fmt.Errorf("unsupported backend jwt encoding: %q", encoding)
+}
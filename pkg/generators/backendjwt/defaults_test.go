@@ -0,0 +1,96 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package backendjwt_generator
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+)
+
+func TestGenerateBackendJWT(t *testing.T) {
+	g := Generator()
+
+	backendJWT, err := g.GenerateBackendJWT(types.BackendJWT{TokenTTL: 600}, "backend-jwt")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if backendJWT.Name != "backend-jwt" {
+		t.Errorf("Expected name backend-jwt, got %s", backendJWT.Name)
+	}
+	if backendJWT.Spec.Encoding != "" || backendJWT.Spec.SigningAlgorithm != "" {
+		t.Errorf("Expected the encoding and signing algorithm to be left to the defaults, got %v", backendJWT.Spec)
+	}
+
+	if _, err := g.GenerateBackendJWT(types.BackendJWT{TokenTTL: -1}, "backend-jwt"); err == nil {
+		t.Errorf("Expected an error for a negative token ttl, got nil")
+	}
+}
+
+func TestGetSigningAlgorithm(t *testing.T) {
+	g := Generator()
+	tests := []struct {
+		signingAlgorithm string
+		expected         string
+		hasError         bool
+	}{
+		{"SHA256withRSA", "SHA256withRSA", false},
+		{"sha384withrsa", "SHA384withRSA", false},
+		{"SHA512withRSA", "SHA512withRSA", false},
+		{"", "", false},
+		{"HS256", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.signingAlgorithm, func(t *testing.T) {
+			signingAlgorithm, err := g.GetSigningAlgorithm(tt.signingAlgorithm)
+			if (err != nil) != tt.hasError {
+				t.Fatalf("Expected error %v, got %v", tt.hasError, err)
+			}
+			if signingAlgorithm != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, signingAlgorithm)
+			}
+		})
+	}
+}
+
+func TestGetEncoding(t *testing.T) {
+	g := Generator()
+	tests := []struct {
+		encoding string
+		expected string
+		hasError bool
+	}{
+		{"Base64", "Base64", false},
+		{"base64url", "Base64url", false},
+		{"", "", false},
+		{"hex", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			encoding, err := g.GetEncoding(tt.encoding)
+			if (err != nil) != tt.hasError {
+				t.Fatalf("Expected error %v, got %v", tt.hasError, err)
+			}
+			if encoding != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, encoding)
+			}
+		})
+	}
+}
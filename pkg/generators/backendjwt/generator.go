@@ -0,0 +1,73 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package backendjwt_generator
+
+import (
+	"fmt"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+)
+
+// backendJWTGenerator is the interface for the BackendJWT generator.
+type backendJWTGenerator struct {
+	GenerateBackendJWT  func(backendJWT types.BackendJWT, name string) (*crds.BackendJWT, error)
+	GetSigningAlgorithm func(signingAlgorithm string) (string, error)
+	GetEncoding         func(encoding string) (string, error)
+}
+
+// Generator creates a new BackendJWT generator.
+func Generator() *backendJWTGenerator {
+	gen := &backendJWTGenerator{}
+	gen.GenerateBackendJWT = gen.generateBackendJWT
+	gen.GetSigningAlgorithm = gen.getSigningAlgorithm
+	gen.GetEncoding = gen.getEncoding
+	return gen
+}
+
+// GenerateBackendJWTs generates a BackendJWT for the BackendJwt request policies of the API and its operations,
+// leaving out the operation policies when API level policies are configured.
+func (g *backendJWTGenerator) GenerateBackendJWTs(apkConf types.APKConf, uniqueId string) ([]*crds.BackendJWT, error) {
+	var backendJWTs []*crds.BackendJWT
+	generatedBackendJWTs := make(map[string]bool)
+	for _, policies := range utils.GetAppliedPolicies(apkConf) {
+		if policies == nil {
+			continue
+		}
+		if utils.GetBackendJWT(policies.Response) != nil {
+			return nil, fmt.Errorf("%s policy cannot be used as a response policy", constants.POLICY_BACKEND_JWT)
+		}
+		backendJWTConfig := utils.GetBackendJWT(policies.Request)
+		if backendJWTConfig == nil {
+			continue
+		}
+		name := utils.GetBackendJWTName(uniqueId, *backendJWTConfig)
+		if generatedBackendJWTs[name] {
+			continue
+		}
+		backendJWT, err := g.GenerateBackendJWT(*backendJWTConfig, name)
+		if err != nil {
+			return nil, err
+		}
+		generatedBackendJWTs[name] = true
+		backendJWTs = append(backendJWTs, backendJWT)
+	}
+	return backendJWTs, nil
+}
@@ -0,0 +1,90 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package backendjwt_generator
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+// TestGenerator test for Generator
+func TestGenerator(t *testing.T) {
+	apiBackendJWT := types.BackendJWT{Encoding: "base64", SigningAlgorithm: "SHA256withRSA", TokenTTL: 3600}
+	operationBackendJWT := types.BackendJWT{Encoding: "Base64url", Header: "X-JWT-Assertion", SigningAlgorithm: "SHA512withRSA"}
+	apkConf := types.APKConf{
+		Name:     "EmployeeServiceAPI",
+		Version:  "3.14",
+		BasePath: "/employees-info",
+		Type:     "REST",
+		APIPolicies: &types.OperationPolicies{
+			Request: []types.OperationPolicy{
+				{PolicyName: "BackendJwt", Parameters: apiBackendJWT},
+			},
+		},
+		Operations: &[]types.Operation{
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true)},
+			{
+				Target:  "/employee",
+				Verb:    "POST",
+				Secured: ptr.To(true),
+				OperationPolicies: &types.OperationPolicies{
+					Request: []types.OperationPolicy{
+						{PolicyName: "BackendJwt", Parameters: operationBackendJWT},
+					},
+				},
+			},
+		},
+	}
+	uniqueId := "unique-id"
+
+	gen := Generator()
+
+	// Operation policies are ignored as API level policies are configured
+	backendJWTs, err := gen.GenerateBackendJWTs(apkConf, uniqueId)
+	assert.Nil(t, err)
+	assert.Len(t, backendJWTs, 1)
+	assert.Equal(t, utils.GetBackendJWTName(uniqueId, apiBackendJWT), backendJWTs[0].Name)
+	assert.Equal(t, "Base64", backendJWTs[0].Spec.Encoding)
+	assert.Equal(t, uint32(3600), backendJWTs[0].Spec.TokenTTL)
+
+	apkConf.APIPolicies = nil
+	backendJWTs, err = gen.GenerateBackendJWTs(apkConf, uniqueId)
+	assert.Nil(t, err)
+	assert.Len(t, backendJWTs, 1)
+	assert.Equal(t, utils.GetBackendJWTName(uniqueId, operationBackendJWT), backendJWTs[0].Name)
+	assert.Equal(t, "SHA512withRSA", backendJWTs[0].Spec.SigningAlgorithm)
+	assert.Equal(t, "X-JWT-Assertion", backendJWTs[0].Spec.Header)
+
+	// Response policies
+	(*apkConf.Operations)[0].OperationPolicies = &types.OperationPolicies{
+		Response: []types.OperationPolicy{{PolicyName: "BackendJwt", Parameters: apiBackendJWT}},
+	}
+	_, err = gen.GenerateBackendJWTs(apkConf, uniqueId)
+	assert.EqualError(t, err, "BackendJwt policy cannot be used as a response policy")
+	(*apkConf.Operations)[0].OperationPolicies = nil
+
+	// Unsupported signing algorithm
+	(*apkConf.Operations)[1].OperationPolicies.Request[0].Parameters = types.BackendJWT{SigningAlgorithm: "HS256"}
+	_, err = gen.GenerateBackendJWTs(apkConf, uniqueId)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,142 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package graphql_generator
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// gqlTypes holds the supported GraphQL operation types.
+var gqlTypes = []string{constants.GRAPHQL_QUERY, constants.GRAPHQL_MUTATION, constants.GRAPHQL_SUBSCRIPTION}
+
+// generateGQLRouteRules generates a list of GQLRouteRules based on the provided configurations.
+func (g *gqlRouteGenerator) generateGQLRouteRules(apkConf types.APKConf, operations []types.Operation, uniqueId string) ([]crds.GQLRouteRules, diagnostics.Diagnostics) {
+	var gqlRouteRules []crds.GQLRouteRules
+	var diags diagnostics.Diagnostics
+	for _, operation := range operations {
+		gqlRouteRule, ruleDiags := g.GenerateGQLRouteRule(apkConf, operation, uniqueId)
+		diags = append(diags, ruleDiags...)
+		if gqlRouteRule != nil {
+			gqlRouteRules = append(gqlRouteRules, *gqlRouteRule)
+		}
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return gqlRouteRules, diags
+}
+
+// generateGQLRouteRule generates the GQLRoute rule matching the field of the operation type.
+func (g *gqlRouteGenerator) generateGQLRouteRule(apkConf types.APKConf, operation types.Operation, uniqueId string) (*crds.GQLRouteRules, diagnostics.Diagnostics) {
+	var diags diagnostics.Diagnostics
+	operationPath := utils.GetOperationPath(apkConf, operation)
+	if !slices.Contains(gqlTypes, strings.ToUpper(operation.Verb)) {
+		diags.Errorf(diagnostics.JoinPath(operationPath, "verb"), "unsupported graphql operation type %q, expected one of %s", operation.Verb, strings.Join(gqlTypes, ", "))
+	}
+	if operation.Target == "" {
+		diags.Errorf(diagnostics.JoinPath(operationPath, "target"), "no field specified for the graphql operation")
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	ruleName := gwapiv1.SectionName(utils.GetRuleName(operation))
+	return &crds.GQLRouteRules{
+		Name:    &ruleName,
+		Matches: g.RetrieveGQLMatches(operation),
+		Filters: g.GenerateGQLRouteFilters(apkConf, operation, uniqueId),
+	}, diags
+}
+
+// retrieveGQLMatches retrieves the GQLRouteMatches based on the provided configurations.
+func (g *gqlRouteGenerator) retrieveGQLMatches(operation types.Operation) []crds.GQLRouteMatch {
+	return []crds.GQLRouteMatch{g.RetrieveGQLMatch(operation)}
+}
+
+// retrieveGQLMatch retrieves the GQLRouteMatch of the operation type and field.
+func (g *gqlRouteGenerator) retrieveGQLMatch(operation types.Operation) crds.GQLRouteMatch {
+	gqlType := crds.GQLType(strings.ToUpper(operation.Verb))
+	path := operation.Target
+	return crds.GQLRouteMatch{
+		Type: &gqlType,
+		Path: &path,
+	}
+}
+
+// generateGQLRouteFilters generates the filters of the operation, referring the Scope of the operation scopes
+// and the resource level policies of the operation.
+func (g *gqlRouteGenerator) generateGQLRouteFilters(apkConf types.APKConf, operation types.Operation, uniqueId string) []crds.GQLRouteFilter {
+	var filters []crds.GQLRouteFilter
+	if len(operation.Scopes) > 0 {
+		filters = append(filters, crds.GQLRouteFilter{
+			ExtensionRef: &gwapiv1.LocalObjectReference{
+				Group: gwapiv1.Group(constants.DP_GROUP),
+				Kind:  gwapiv1.Kind(constants.SCOPE_KIND),
+				Name:  gwapiv1.ObjectName(getScopeName(operation, uniqueId)),
+			},
+		})
+	}
+	extensionRefs := utils.GetOperationExtensionRefs(apkConf, operation, uniqueId)
+	for i := range extensionRefs {
+		filters = append(filters, crds.GQLRouteFilter{ExtensionRef: &extensionRefs[i]})
+	}
+	return filters
+}
+
+// generateGQLBackEndRef generates the backend references of the GQLRoute to the API endpoint.
+func (g *gqlRouteGenerator) generateGQLBackEndRef(endpoint types.EndpointDetails) []gwapiv1.HTTPBackendRef {
+	httpBackEndRef := gwapiv1.HTTPBackendRef{
+		BackendRef: gwapiv1.BackendRef{
+			BackendObjectReference: utils.GenerateBackendObjectReference(endpoint),
+		},
+	}
+	return []gwapiv1.HTTPBackendRef{httpBackEndRef}
+}
+
+// generateScope generates the Scope holding the scopes required by the operation, or nil when it requires none.
+func (g *gqlRouteGenerator) generateScope(operation types.Operation, uniqueId string) *crds.Scope {
+	if len(operation.Scopes) == 0 {
+		return nil
+	}
+	return &crds.Scope{
+		TypeMeta: v1.TypeMeta{
+			Kind:       constants.SCOPE_KIND,
+			APIVersion: constants.DP_V1ALPHA1,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: getScopeName(operation, uniqueId),
+		},
+		Spec: crds.ScopeSpec{
+			Names: operation.Scopes,
+		},
+	}
+}
+
+// getScopeName returns the name of the Scope of the operation.
+func getScopeName(operation types.Operation, uniqueId string) string {
+	return uniqueId + "-" + utils.GetRuleName(operation) + "-scope"
+}
@@ -0,0 +1,104 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package graphql_generator
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestGenerateGQLRouteRule(t *testing.T) {
+	g := Generator()
+	operation := types.Operation{Target: "employee", Verb: "query", Scopes: []string{"employees:read"}}
+	apkConf := types.APKConf{
+		Name:     "EmployeeGraphQLAPI",
+		Version:  "1.0",
+		BasePath: "/employees-graphql",
+		Type:     constants.API_TYPE_GRAPHQL,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-graphql:8080"),
+			},
+		},
+		Operations: &[]types.Operation{operation},
+	}
+
+	gqlRouteRule, diags := g.generateGQLRouteRule(apkConf, operation, "test-id")
+	assert.False(t, diags.HasErrors())
+	// The operation type is matched in upper case
+	assert.Equal(t, crds.GQLType(constants.GRAPHQL_QUERY), *gqlRouteRule.Matches[0].Type)
+	assert.Equal(t, "employee", *gqlRouteRule.Matches[0].Path)
+	assert.NotNil(t, gqlRouteRule.Name)
+	assert.Len(t, gqlRouteRule.Filters, 1)
+}
+
+func TestGenerateGQLRouteFilters(t *testing.T) {
+	g := Generator()
+
+	filters := g.generateGQLRouteFilters(types.APKConf{}, types.Operation{Target: "employee", Verb: "QUERY"}, "test-id")
+	assert.Empty(t, filters)
+
+	operation := types.Operation{Target: "employee", Verb: "QUERY", Scopes: []string{"employees:read"}}
+	filters = g.generateGQLRouteFilters(types.APKConf{}, operation, "test-id")
+	assert.Equal(t, []crds.GQLRouteFilter{{
+		ExtensionRef: &gwapiv1.LocalObjectReference{
+			Group: constants.DP_GROUP,
+			Kind:  constants.SCOPE_KIND,
+			Name:  gwapiv1.ObjectName(getScopeName(operation, "test-id")),
+		},
+	}}, filters)
+
+	secured := false
+	operation = types.Operation{Target: "employee", Verb: "QUERY", Secured: &secured}
+	filters = g.generateGQLRouteFilters(types.APKConf{}, operation, "test-id")
+	assert.Equal(t, []crds.GQLRouteFilter{{
+		ExtensionRef: &gwapiv1.LocalObjectReference{
+			Group: constants.DP_GROUP,
+			Kind:  constants.AUTHENTICATION_KIND,
+			Name:  gwapiv1.ObjectName(utils.GetOperationPolicyName("test-id", operation, "authentication")),
+		},
+	}}, filters)
+}
+
+func TestGenerateGQLBackEndRef(t *testing.T) {
+	g := Generator()
+	endpoint := types.EndpointDetails{Name: "test-endpoint"}
+
+	backendRefs := g.generateGQLBackEndRef(endpoint)
+	assert.Len(t, backendRefs, 1)
+	assert.Equal(t, gwapiv1.ObjectName(endpoint.Name), backendRefs[0].Name)
+	assert.Equal(t, gwapiv1.Kind(constants.BACKEND_KIND), *backendRefs[0].Kind)
+}
+
+func TestGenerateScope(t *testing.T) {
+	g := Generator()
+
+	assert.Nil(t, g.generateScope(types.Operation{Target: "employee", Verb: "QUERY"}, "test-id"))
+
+	scope := g.generateScope(types.Operation{Target: "employee", Verb: "QUERY", Scopes: []string{"employees:read"}}, "test-id")
+	assert.Equal(t, constants.SCOPE_KIND, scope.Kind)
+	assert.Equal(t, constants.DP_V1ALPHA1, scope.APIVersion)
+	assert.Equal(t, []string{"employees:read"}, scope.Spec.Names)
+}
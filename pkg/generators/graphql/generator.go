@@ -0,0 +1,144 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package graphql_generator
+
+import (
+	"strconv"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
+	http_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/http"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// gqlRouteGenerator is the interface for the GQLRoute generator.
+type gqlRouteGenerator struct {
+	GenerateGQLRouteRules         func(apkConf types.APKConf, operations []types.Operation, uniqueId string) ([]crds.GQLRouteRules, diagnostics.Diagnostics)
+	GenerateGQLRouteRule          func(apkConf types.APKConf, operation types.Operation, uniqueId string) (*crds.GQLRouteRules, diagnostics.Diagnostics)
+	GenerateAndRetrieveParentRefs func(gatewayConfig types.GatewayConfigurations, uniqueId string) []gwapiv1.ParentReference
+	GetHostNames                  func(apkConf types.APKConf, endpointType string, organization types.Organization, gatewayConfig types.GatewayConfigurations) []gwapiv1.Hostname
+	RetrieveGQLMatches            func(operation types.Operation) []crds.GQLRouteMatch
+	RetrieveGQLMatch              func(operation types.Operation) crds.GQLRouteMatch
+	GenerateGQLRouteFilters       func(apkConf types.APKConf, operation types.Operation, uniqueId string) []crds.GQLRouteFilter
+	GenerateGQLBackEndRef         func(endpoint types.EndpointDetails) []gwapiv1.HTTPBackendRef
+	GenerateScope                 func(operation types.Operation, uniqueId string) *crds.Scope
+}
+
+// Generator creates a new GQLRoute generator.
+func Generator() *gqlRouteGenerator {
+	gen := &gqlRouteGenerator{}
+	httpGen := http_generator.Generator()
+	gen.GenerateGQLRouteRules = gen.generateGQLRouteRules
+	gen.GenerateGQLRouteRule = gen.generateGQLRouteRule
+	gen.GenerateAndRetrieveParentRefs = httpGen.GenerateAndRetrieveParentRefs
+	gen.GetHostNames = utils.GetHostNames
+	gen.RetrieveGQLMatches = gen.retrieveGQLMatches
+	gen.RetrieveGQLMatch = gen.retrieveGQLMatch
+	gen.GenerateGQLRouteFilters = gen.generateGQLRouteFilters
+	gen.GenerateGQLBackEndRef = gen.generateGQLBackEndRef
+	gen.GenerateScope = gen.generateScope
+	return gen
+}
+
+// GenerateGQLRoute generates a GQLRoute routing the GraphQL operations to the API endpoint of the given type.
+// The GQLRoute is nil when the diagnostics contain errors, while warnings are returned along with the GQLRoute.
+func (g *gqlRouteGenerator) GenerateGQLRoute(apkConf types.APKConf, organization types.Organization, gatewayConfiguration types.GatewayConfigurations, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string, count int) (*crds.GQLRoute, diagnostics.Diagnostics) {
+	gqlRouteRules, diags := g.generateRules(apkConf, operations, endpoint, endpointType, uniqueId)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return g.newGQLRoute(apkConf, organization, gatewayConfiguration, gqlRouteRules, *endpoint, endpointType, uniqueId, count), diags
+}
+
+// GenerateGQLRoutes generates the GQLRoutes of the operations, splitting the rules into as many GQLRoutes as needed
+// to stay within the limit of rules per route. The GQLRoutes are named <uniqueId>-<endpointType>-gqlroute-<n>.
+func (g *gqlRouteGenerator) GenerateGQLRoutes(apkConf types.APKConf, organization types.Organization, gatewayConfiguration types.GatewayConfigurations, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) ([]*crds.GQLRoute, diagnostics.Diagnostics) {
+	gqlRouteRules, diags := g.generateRules(apkConf, operations, endpoint, endpointType, uniqueId)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	var gqlRoutes []*crds.GQLRoute
+	// All the operations of a GQLRoute are routed to the API endpoint, so the rules are not grouped.
+	ruleGroups := utils.SplitRules(gqlRouteRules, func(rule crds.GQLRouteRules) string {
+		return ""
+	})
+	for i, rules := range ruleGroups {
+		gqlRoutes = append(gqlRoutes, g.newGQLRoute(apkConf, organization, gatewayConfiguration, rules, *endpoint, endpointType, uniqueId, i+1))
+	}
+	return gqlRoutes, diags
+}
+
+// GenerateScopes generates a Scope for each operation requiring scopes, referred by the GQLRoute rule of the operation.
+func (g *gqlRouteGenerator) GenerateScopes(apkConf types.APKConf, uniqueId string) []*crds.Scope {
+	var scopes []*crds.Scope
+	if apkConf.Operations == nil {
+		return scopes
+	}
+	for _, operation := range *apkConf.Operations {
+		if scope := g.GenerateScope(operation, uniqueId); scope != nil {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// generateRules generates the GQLRoute rules after checking the API endpoint, as the operations of a GraphQL API
+// are all routed to the API endpoint.
+func (g *gqlRouteGenerator) generateRules(apkConf types.APKConf, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) ([]crds.GQLRouteRules, diagnostics.Diagnostics) {
+	var diags diagnostics.Diagnostics
+	if endpoint == nil {
+		diags.Errorf("endpointConfigurations", "no %s endpoint specified for the API", endpointType)
+	}
+	for _, operation := range operations {
+		if operation.EndpointConfigurations != nil {
+			diags.Errorf(diagnostics.JoinPath(utils.GetOperationPath(apkConf, operation), "endpointConfigurations"), "operation level endpoints are not supported for GraphQL APIs")
+		}
+	}
+	gqlRouteRules, ruleDiags := g.GenerateGQLRouteRules(apkConf, operations, uniqueId)
+	diags = append(diags, ruleDiags...)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return gqlRouteRules, diags
+}
+
+// newGQLRoute creates the GQLRoute with the given rules.
+func (g *gqlRouteGenerator) newGQLRoute(apkConf types.APKConf, organization types.Organization, gatewayConfiguration types.GatewayConfigurations, gqlRouteRules []crds.GQLRouteRules, endpoint types.EndpointDetails, endpointType string, uniqueId string, count int) *crds.GQLRoute {
+	return &crds.GQLRoute{
+		TypeMeta: v1.TypeMeta{
+			Kind:       constants.GQLROUTE_KIND,
+			APIVersion: constants.DP_V1ALPHA2,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: uniqueId + "-" + endpointType + "-gqlroute-" + strconv.Itoa(count),
+		},
+		Spec: crds.GQLRouteSpec{
+			CommonRouteSpec: gwapiv1.CommonRouteSpec{
+				ParentRefs: g.GenerateAndRetrieveParentRefs(gatewayConfiguration, uniqueId),
+			},
+			Hostnames:   g.GetHostNames(apkConf, endpointType, organization, gatewayConfiguration),
+			BackendRefs: g.GenerateGQLBackEndRef(endpoint),
+			Rules:       gqlRouteRules,
+		},
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package graphql_generator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+// TestGenerator test for Generator
+func TestGenerator(t *testing.T) {
+	apkConf := types.APKConf{
+		Name:     "EmployeeGraphQLAPI",
+		Version:  "1.0",
+		BasePath: "/employees-graphql",
+		Type:     constants.API_TYPE_GRAPHQL,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-graphql:8080"),
+			},
+		},
+		Operations: &[]types.Operation{
+			{Target: "employees", Verb: "QUERY", Secured: ptr.To(true), Scopes: []string{"employees:read"}},
+			{Target: "addEmployee", Verb: "MUTATION", Secured: ptr.To(true)},
+			{Target: "employeeAdded", Verb: "SUBSCRIPTION", Secured: ptr.To(true)},
+		},
+	}
+	organization := types.Organization{
+		Name: "wso2",
+	}
+	gatewayConfig := types.GatewayConfigurations{
+		Name:         "wso2-apim",
+		ListenerName: "wso2-apim-gateway",
+		Hostname:     "wso2-apim",
+	}
+
+	gen := Generator()
+
+	uniqueId := "unique-id"
+	endpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.PRODUCTION_TYPE]
+	gqlRoute, diags := gen.GenerateGQLRoute(apkConf, organization, gatewayConfig, *apkConf.Operations, &endpoint, constants.PRODUCTION_TYPE, uniqueId, 1)
+	assert.False(t, diags.HasErrors())
+	assert.Equal(t, constants.GQLROUTE_KIND, gqlRoute.Kind)
+	assert.Equal(t, constants.DP_V1ALPHA2, gqlRoute.APIVersion)
+	assert.Equal(t, "unique-id-production-gqlroute-1", gqlRoute.Name)
+	assert.Equal(t, endpoint.Name, string(gqlRoute.Spec.BackendRefs[0].Name))
+	assert.Len(t, gqlRoute.Spec.Rules, 3)
+	assert.Equal(t, crds.GQLType("MUTATION"), *gqlRoute.Spec.Rules[1].Matches[0].Type)
+	assert.Equal(t, "addEmployee", *gqlRoute.Spec.Rules[1].Matches[0].Path)
+	assert.Equal(t, "unique-id-"+utils.GetRuleName((*apkConf.Operations)[0])+"-scope", string(gqlRoute.Spec.Rules[0].Filters[0].ExtensionRef.Name))
+	assert.Empty(t, gqlRoute.Spec.Rules[1].Filters)
+
+	scopes := gen.GenerateScopes(apkConf, "unique-id")
+	assert.Len(t, scopes, 1)
+	assert.Equal(t, string(gqlRoute.Spec.Rules[0].Filters[0].ExtensionRef.Name), scopes[0].Name)
+	assert.Equal(t, []string{"employees:read"}, scopes[0].Spec.Names)
+
+	// The per-field policies are generated for the fields served by the GQLRoute
+	assert.True(t, utils.HasRouteRule([]types.RouteReference{utils.GetGQLRouteReference(gqlRoute)}, (*apkConf.Operations)[2]))
+}
+
+func TestGenerateGQLRoutes(t *testing.T) {
+	var operations []types.Operation
+	for i := 0; i < 20; i++ {
+		operations = append(operations, types.Operation{Target: fmt.Sprintf("employee%d", i), Verb: "QUERY"})
+	}
+	apkConf := types.APKConf{
+		Name:     "EmployeeGraphQLAPI",
+		Version:  "1.0",
+		BasePath: "/employees-graphql",
+		Type:     constants.API_TYPE_GRAPHQL,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-graphql:8080"),
+			},
+		},
+		Operations: &operations,
+	}
+	uniqueId := "unique-id"
+	endpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.PRODUCTION_TYPE]
+
+	gqlRoutes, diags := Generator().GenerateGQLRoutes(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, operations, &endpoint, constants.PRODUCTION_TYPE, "unique-id")
+	assert.False(t, diags.HasErrors())
+	assert.Equal(t, []string{"unique-id-production-gqlroute-1", "unique-id-production-gqlroute-2"}, utils.GetGQLRouteNames(gqlRoutes))
+	assert.Len(t, gqlRoutes[0].Spec.Rules, 16)
+	assert.Len(t, gqlRoutes[1].Spec.Rules, 4)
+}
+
+func TestGenerateGQLRouteErrors(t *testing.T) {
+	operations := []types.Operation{
+		{Target: "employees", Verb: "GET"},
+		{Target: "", Verb: "QUERY"},
+		{Target: "addEmployee", Verb: "MUTATION", EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{Endpoint: types.EndpointURL("http://employee-mutations:8080")},
+		}},
+	}
+	apkConf := types.APKConf{
+		Name:     "EmployeeGraphQLAPI",
+		Version:  "1.0",
+		BasePath: "/employees-graphql",
+		Type:     constants.API_TYPE_GRAPHQL,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-graphql:8080"),
+			},
+		},
+		Operations: &operations,
+	}
+
+	gqlRoute, diags := Generator().GenerateGQLRoute(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, operations, nil, constants.PRODUCTION_TYPE, "unique-id", 1)
+	assert.Nil(t, gqlRoute)
+	var messages []string
+	for _, diagnostic := range diags.Errors() {
+		messages = append(messages, diagnostic.Error())
+	}
+	assert.Equal(t, []string{
+		"error: endpointConfigurations: no production endpoint specified for the API",
+		"error: operations[2].endpointConfigurations: operation level endpoints are not supported for GraphQL APIs",
+		`error: operations[0].verb: unsupported graphql operation type "GET", expected one of QUERY, MUTATION, SUBSCRIPTION`,
+		"error: operations[1].target: no field specified for the graphql operation",
+	}, messages)
+}
@@ -18,42 +18,55 @@
 package grpc_generator
 
 import (
-	"errors"
-
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
 
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 // generateGRPCRouteRules generates a list of GRPCRouteRules based on the provided configurations.
-func (g *grpcRouteGenerator) generateGRPCRouteRules(apkConf types.APKConf, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string) ([]gwapiv1.GRPCRouteRule, error) {
+func (g *grpcRouteGenerator) generateGRPCRouteRules(apkConf types.APKConf, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) ([]gwapiv1.GRPCRouteRule, diagnostics.Diagnostics) {
 	var grpcRouteRules []gwapiv1.GRPCRouteRule
+	var diags diagnostics.Diagnostics
 	for _, operation := range operations {
-		grpcRouteRule, err := g.GenerateGRPCRouteRule(apkConf, operation, endpoint, endpointType)
-		if err != nil {
-			return nil, err
-		} else {
+		grpcRouteRule, ruleDiags := g.GenerateGRPCRouteRule(apkConf, operation, endpoint, endpointType, uniqueId)
+		diags = append(diags, ruleDiags...)
+		if grpcRouteRule != nil {
 			grpcRouteRules = append(grpcRouteRules, *grpcRouteRule)
 		}
 	}
-	return grpcRouteRules, nil
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return grpcRouteRules, diags
 }
 
 // generateRouteRule generates a route rule based on the operation and endpoint details.
-func (g *grpcRouteGenerator) generateGRPCRouteRule(apkConf types.APKConf, operation types.Operation, endpoint *types.EndpointDetails, endpointType string) (*gwapiv1.GRPCRouteRule, error) {
-	var endpointToUse *types.EndpointDetails = utils.GetEndpointToUse(operation.EndpointConfigurations, endpointType)
+func (g *grpcRouteGenerator) generateGRPCRouteRule(apkConf types.APKConf, operation types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) (*gwapiv1.GRPCRouteRule, diagnostics.Diagnostics) {
+	var diags diagnostics.Diagnostics
+	var endpointToUse *types.EndpointDetails = utils.GetEndpointToUse(operation.EndpointConfigurations, endpointType, uniqueId)
 	if endpointToUse == nil && endpoint != nil {
 		endpointToUse = endpoint
 	}
 	if endpointToUse != nil {
+		ruleName := gwapiv1.SectionName(utils.GetRuleName(operation))
 		grpcRouteRule := gwapiv1.GRPCRouteRule{
+			Name:        &ruleName,
 			Matches:     g.RetrieveGRPCMatches(operation),
 			BackendRefs: g.GenerateGRPCBackEndRef(*endpointToUse, operation),
 		}
-		return &grpcRouteRule, nil
+		extensionRefs := utils.GetOperationExtensionRefs(apkConf, operation, uniqueId)
+		for i := range extensionRefs {
+			grpcRouteRule.Filters = append(grpcRouteRule.Filters, gwapiv1.GRPCRouteFilter{
+				Type:         gwapiv1.GRPCRouteFilterExtensionRef,
+				ExtensionRef: &extensionRefs[i],
+			})
+		}
+		return &grpcRouteRule, diags
 	} else {
-		return nil, errors.New("invalid endpoint specified")
+		diags.Errorf(utils.GetOperationPath(apkConf, operation), "no %s endpoint specified for the operation or the API", endpointType)
+		return nil, diags
 	}
 }
 
@@ -77,13 +90,9 @@ func (g *grpcRouteGenerator) generateAndRetrieveParentRefs(gatewayConfig types.G
 
 // generateGRPCBackEndRef generates a list of GRPCBackendRefs based on the provided configurations.
 func (g *grpcRouteGenerator) generateGRPCBackEndRef(endpoint types.EndpointDetails, operation types.Operation) []gwapiv1.GRPCBackendRef {
-	kind := gwapiv1.Kind("Service")
 	grpcBackEndRef := gwapiv1.GRPCBackendRef{
 		BackendRef: gwapiv1.BackendRef{
-			BackendObjectReference: gwapiv1.BackendObjectReference{
-				Kind: &kind,
-				Name: gwapiv1.ObjectName(endpoint.Name),
-			},
+			BackendObjectReference: utils.GenerateBackendObjectReference(endpoint),
 		},
 	}
 	return []gwapiv1.GRPCBackendRef{grpcBackEndRef}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
 
+	"k8s.io/utils/ptr"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
@@ -47,14 +48,14 @@ func TestGenerateGRPCRoute(t *testing.T) {
 		Authentication: &[]types.AuthConfiguration{
 			{
 				AuthType: "APIKey",
-				Enabled:  true,
+				Enabled:  ptr.To(true),
 			},
 		},
 		Operations: &[]types.Operation{
-			{Target: "/employees", Verb: "GET", Secured: true, Scopes: []string{}},
-			{Target: "/employee", Verb: "POST", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: true, Scopes: []string{}},
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee", Verb: "POST", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: ptr.To(true), Scopes: []string{}},
 		},
 	}
 
@@ -72,9 +73,9 @@ func TestGenerateGRPCRoute(t *testing.T) {
 	uniqueId := "test-id"
 	count := 1
 
-	grpcRoute, err := g.GenerateGRPCRoute(apkConf, organization, gatewayConfiguration, operations, endpoint, endpointType, uniqueId, 1)
-	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
+	grpcRoute, diags := g.GenerateGRPCRoute(apkConf, organization, gatewayConfiguration, operations, endpoint, endpointType, uniqueId, 1)
+	if diags.HasErrors() {
+		t.Fatalf("Expected no error, got %v", diags)
 	}
 
 	if grpcRoute.ObjectMeta.Name != uniqueId+"-"+endpointType+"-grpcroute-"+strconv.Itoa(count) {
@@ -103,23 +104,24 @@ func TestGenerateGRPCRouteRules(t *testing.T) {
 		Authentication: &[]types.AuthConfiguration{
 			{
 				AuthType: "APIKey",
-				Enabled:  true,
+				Enabled:  ptr.To(true),
 			},
 		},
 		Operations: &[]types.Operation{
-			{Target: "/employees", Verb: "GET", Secured: true, Scopes: []string{}},
-			{Target: "/employee", Verb: "POST", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: true, Scopes: []string{}},
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee", Verb: "POST", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: ptr.To(true), Scopes: []string{}},
 		},
 	}
 	operations := *apkConf.Operations
 	endpoint := &types.EndpointDetails{Name: "employee-service"}
 	endpointType := "test-endpoint"
 
-	grpcRouteRules, err := g.generateGRPCRouteRules(apkConf, operations, endpoint, endpointType)
-	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
+	uniqueId := "unique-id"
+	grpcRouteRules, diags := g.generateGRPCRouteRules(apkConf, operations, endpoint, endpointType, uniqueId)
+	if diags.HasErrors() {
+		t.Fatalf("Expected no error, got %v", diags)
 	}
 
 	if grpcRouteRules == nil {
@@ -148,23 +150,24 @@ func TestGenerateGRPCRouteRule(t *testing.T) {
 		Authentication: &[]types.AuthConfiguration{
 			{
 				AuthType: "APIKey",
-				Enabled:  true,
+				Enabled:  ptr.To(true),
 			},
 		},
 		Operations: &[]types.Operation{
-			{Target: "/employees", Verb: "GET", Secured: true, Scopes: []string{}},
-			{Target: "/employee", Verb: "POST", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: true, Scopes: []string{}},
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee", Verb: "POST", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: ptr.To(true), Scopes: []string{}},
 		},
 	}
 	operation := (*apkConf.Operations)[0]
 	endpoint := &types.EndpointDetails{Name: "employee-service"}
 	endpointType := "test-endpoint"
 
-	grpcRouteRule, err := g.generateGRPCRouteRule(apkConf, operation, endpoint, endpointType)
-	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
+	uniqueId := "unique-id"
+	grpcRouteRule, diags := g.generateGRPCRouteRule(apkConf, operation, endpoint, endpointType, uniqueId)
+	if diags.HasErrors() {
+		t.Fatalf("Expected no error, got %v", diags)
 	}
 
 	if grpcRouteRule == nil {
@@ -228,14 +231,14 @@ func TestRetrieveGRPCMatches(t *testing.T) {
 		Authentication: &[]types.AuthConfiguration{
 			{
 				AuthType: "APIKey",
-				Enabled:  true,
+				Enabled:  ptr.To(true),
 			},
 		},
 		Operations: &[]types.Operation{
-			{Target: "/employees", Verb: "GET", Secured: true, Scopes: []string{}},
-			{Target: "/employee", Verb: "POST", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: true, Scopes: []string{}},
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee", Verb: "POST", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: ptr.To(true), Scopes: []string{}},
 		},
 	}
 	operation := (*apkConf.Operations)[0]
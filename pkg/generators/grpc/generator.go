@@ -20,7 +20,9 @@ package grpc_generator
 import (
 	"strconv"
 
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,10 +31,10 @@ import (
 
 // grpcRouteGenerator is the interface for the GRPC route generator.
 type grpcRouteGenerator struct {
-	GenerateGRPCRouteRules        func(apkConf types.APKConf, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string) ([]gwapiv1.GRPCRouteRule, error)
-	GenerateGRPCRouteRule         func(apkConf types.APKConf, operation types.Operation, endpoint *types.EndpointDetails, endpointType string) (*gwapiv1.GRPCRouteRule, error)
+	GenerateGRPCRouteRules        func(apkConf types.APKConf, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) ([]gwapiv1.GRPCRouteRule, diagnostics.Diagnostics)
+	GenerateGRPCRouteRule         func(apkConf types.APKConf, operation types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) (*gwapiv1.GRPCRouteRule, diagnostics.Diagnostics)
 	GenerateAndRetrieveParentRefs func(gatewayConfig types.GatewayConfigurations, uniqueId string) []gwapiv1.ParentReference
-	GetHostNames                  func(apkConf types.APKConf, endpointType string, organization types.Organization) []gwapiv1.Hostname
+	GetHostNames                  func(apkConf types.APKConf, endpointType string, organization types.Organization, gatewayConfig types.GatewayConfigurations) []gwapiv1.Hostname
 	RetrieveGRPCMatches           func(operation types.Operation) []gwapiv1.GRPCRouteMatch
 	RetrieveGRPCMatch             func(operation types.Operation) gwapiv1.GRPCRouteMatch
 	GenerateGRPCBackEndRef        func(endpoint types.EndpointDetails, operation types.Operation) []gwapiv1.GRPCBackendRef
@@ -52,15 +54,42 @@ func Generator() *grpcRouteGenerator {
 }
 
 // GenerateGRPCRoute generates a GRPCRoute based on the provided configurations.
-func (g *grpcRouteGenerator) GenerateGRPCRoute(apkConf types.APKConf, organization types.Organization, gatewayConfiguration types.GatewayConfigurations, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string, count int) (*gwapiv1.GRPCRoute, error) {
-	grpcRouteRules, err := g.GenerateGRPCRouteRules(apkConf, operations, endpoint, endpointType)
-	if err != nil {
-		return nil, err
+// The GRPCRoute is nil when the diagnostics contain errors, while warnings are returned along with the GRPCRoute.
+func (g *grpcRouteGenerator) GenerateGRPCRoute(apkConf types.APKConf, organization types.Organization, gatewayConfiguration types.GatewayConfigurations, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string, count int) (*gwapiv1.GRPCRoute, diagnostics.Diagnostics) {
+	grpcRouteRules, diags := g.GenerateGRPCRouteRules(apkConf, operations, endpoint, endpointType, uniqueId)
+	if diags.HasErrors() {
+		return nil, diags
 	}
-	grpcRoute := gwapiv1.GRPCRoute{
+	return g.newGRPCRoute(apkConf, organization, gatewayConfiguration, grpcRouteRules, endpointType, uniqueId, count), diags
+}
+
+// GenerateGRPCRoutes generates the GRPCRoutes of the operations, splitting the rules into as many GRPCRoutes as needed
+// to stay within the Gateway API limit of rules per route. Rules of the same backend are kept in the same GRPCRoute
+// when they fit, and the GRPCRoutes are named <uniqueId>-<endpointType>-grpcroute-<n>.
+func (g *grpcRouteGenerator) GenerateGRPCRoutes(apkConf types.APKConf, organization types.Organization, gatewayConfiguration types.GatewayConfigurations, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) ([]*gwapiv1.GRPCRoute, diagnostics.Diagnostics) {
+	grpcRouteRules, diags := g.GenerateGRPCRouteRules(apkConf, operations, endpoint, endpointType, uniqueId)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	var grpcRoutes []*gwapiv1.GRPCRoute
+	ruleGroups := utils.SplitRules(grpcRouteRules, func(rule gwapiv1.GRPCRouteRule) string {
+		if len(rule.BackendRefs) == 0 {
+			return ""
+		}
+		return string(rule.BackendRefs[0].Name)
+	})
+	for i, rules := range ruleGroups {
+		grpcRoutes = append(grpcRoutes, g.newGRPCRoute(apkConf, organization, gatewayConfiguration, rules, endpointType, uniqueId, i+1))
+	}
+	return grpcRoutes, diags
+}
+
+// newGRPCRoute creates the GRPCRoute with the given rules.
+func (g *grpcRouteGenerator) newGRPCRoute(apkConf types.APKConf, organization types.Organization, gatewayConfiguration types.GatewayConfigurations, grpcRouteRules []gwapiv1.GRPCRouteRule, endpointType string, uniqueId string, count int) *gwapiv1.GRPCRoute {
+	return &gwapiv1.GRPCRoute{
 		TypeMeta: v1.TypeMeta{
 			Kind:       "GRPCRoute",
-			APIVersion: "gateway.sigs.k8s.io/v1",
+			APIVersion: constants.GATEWAY_V1,
 		},
 		ObjectMeta: v1.ObjectMeta{
 			Name: uniqueId + "-" + endpointType + "-grpcroute-" + strconv.Itoa(count),
@@ -70,8 +99,7 @@ func (g *grpcRouteGenerator) GenerateGRPCRoute(apkConf types.APKConf, organizati
 				ParentRefs: g.GenerateAndRetrieveParentRefs(gatewayConfiguration, uniqueId),
 			},
 			Rules:     grpcRouteRules,
-			Hostnames: g.GetHostNames(apkConf, endpointType, organization),
+			Hostnames: g.GetHostNames(apkConf, endpointType, organization, gatewayConfiguration),
 		},
 	}
-	return &grpcRoute, nil
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
@@ -50,14 +51,14 @@ func TestGenerator(t *testing.T) {
 		Authentication: &[]types.AuthConfiguration{
 			{
 				AuthType: "APIKey",
-				Enabled:  true,
+				Enabled:  ptr.To(true),
 			},
 		},
 		Operations: &[]types.Operation{
-			{Target: "/employees", Verb: "GET", Secured: true, Scopes: []string{}},
-			{Target: "/employee", Verb: "POST", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: true, Scopes: []string{}},
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee", Verb: "POST", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: ptr.To(true), Scopes: []string{}},
 		},
 	}
 	organization := types.Organization{
@@ -72,15 +73,43 @@ func TestGenerator(t *testing.T) {
 	gen := Generator()
 
 	// Get the endpoint to use
-	endpoints := utils.GetEndpoints(apkConf)
+	uniqueId := "unique-id"
+	endpoints := utils.GetEndpoints(apkConf, uniqueId)
 	// If endpoints has production type
 	if endpoint, ok := endpoints[constants.PRODUCTION_TYPE]; ok {
-		grpcRoute, err := gen.GenerateGRPCRoute(apkConf, organization, gatewayConfig, *apkConf.Operations, &endpoint, constants.PRODUCTION_TYPE, "unique-id", 1)
-		if err != nil {
-			fmt.Println(err)
+		grpcRoute, diags := gen.GenerateGRPCRoute(apkConf, organization, gatewayConfig, *apkConf.Operations, &endpoint, constants.PRODUCTION_TYPE, uniqueId, 1)
+		if diags.HasErrors() {
+			fmt.Println(diags)
 		}
 
-		assert.Nil(t, err)
+		assert.False(t, diags.HasErrors())
 		assert.IsType(t, &gwapiv1.GRPCRoute{}, grpcRoute)
 	}
 }
+
+func TestGenerateGRPCRoutes(t *testing.T) {
+	apkConf := types.APKConf{
+		Name:     "EmployeeGRPCAPI",
+		Version:  "v1",
+		BasePath: "/org.apk.employee",
+		Type:     "GRPC",
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-grpc:6565"),
+			},
+		},
+	}
+	var operations []types.Operation
+	for i := 0; i < 17; i++ {
+		operations = append(operations, types.Operation{Target: "employee.EmployeeService", Verb: fmt.Sprintf("Method%d", i)})
+	}
+	apkConf.Operations = &operations
+	uniqueId := "unique-id"
+	endpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.PRODUCTION_TYPE]
+
+	grpcRoutes, diags := Generator().GenerateGRPCRoutes(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, operations, &endpoint, constants.PRODUCTION_TYPE, "unique-id")
+	assert.False(t, diags.HasErrors())
+	assert.Equal(t, []string{"unique-id-production-grpcroute-1", "unique-id-production-grpcroute-2"}, utils.GetGRPCRouteNames(grpcRoutes))
+	assert.Len(t, grpcRoutes[0].Spec.Rules, 16)
+	assert.Len(t, grpcRoutes[1].Spec.Rules, 1)
+}
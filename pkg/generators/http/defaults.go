@@ -18,54 +18,87 @@
 package http_generator
 
 import (
-	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
 
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 // generateHTTPRouteRules generates a list of HTTPRouteRules based on the provided configurations.
-func (g *httpRouteGenerator) generateHTTPRouteRules(apkConf types.APKConf, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string) ([]gwapiv1.HTTPRouteRule, error) {
+func (g *httpRouteGenerator) generateHTTPRouteRules(apkConf types.APKConf, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) ([]gwapiv1.HTTPRouteRule, diagnostics.Diagnostics) {
 	var httpRouteRules []gwapiv1.HTTPRouteRule
+	var diags diagnostics.Diagnostics
 	for _, operation := range operations {
-		httpRouteRule, err := g.GenerateHTTPRouteRule(apkConf, operation, endpoint, endpointType)
-		if err != nil {
-			return nil, err
-		} else {
+		httpRouteRule, ruleDiags := g.GenerateHTTPRouteRule(apkConf, operation, endpoint, endpointType, uniqueId)
+		diags = append(diags, ruleDiags...)
+		if httpRouteRule != nil {
 			httpRouteRules = append(httpRouteRules, *httpRouteRule)
 		}
 	}
-	return httpRouteRules, nil
+
+	if g.EnableGatewayCORS && apkConf.CorsConfig != nil && apkConf.CorsConfig.CORSConfigurationEnabled {
+		corsHeaders, err := g.GenerateCORSHeaders(*apkConf.CorsConfig)
+		if err != nil {
+			diags = append(diags, diagnostics.FromError("corsConfiguration", err)...)
+			return nil, diags
+		}
+		for i := range httpRouteRules {
+			httpRouteRules[i].Filters = appendResponseHeaders(httpRouteRules[i].Filters, corsHeaders)
+		}
+		preflightRule, err := g.GenerateCORSPreflightRule(apkConf, endpoint, endpointType, corsHeaders)
+		if err != nil {
+			diags = append(diags, diagnostics.FromError("corsConfiguration", err)...)
+			return nil, diags
+		}
+		if preflightRule != nil {
+			httpRouteRules = append(httpRouteRules, *preflightRule)
+		}
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return httpRouteRules, diags
 }
 
 // generateRouteRule generates a route rule based on the operation and endpoint details.
-func (g *httpRouteGenerator) generateHTTPRouteRule(apkConf types.APKConf, operation types.Operation, endpoint *types.EndpointDetails, endpointType string) (*gwapiv1.HTTPRouteRule, error) {
-	var endpointToUse *types.EndpointDetails = utils.GetEndpointToUse(operation.EndpointConfigurations, endpointType)
+func (g *httpRouteGenerator) generateHTTPRouteRule(apkConf types.APKConf, operation types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) (*gwapiv1.HTTPRouteRule, diagnostics.Diagnostics) {
+	var diags diagnostics.Diagnostics
+	operationPath := utils.GetOperationPath(apkConf, operation)
+	var endpointToUse *types.EndpointDetails = utils.GetEndpointToUse(operation.EndpointConfigurations, endpointType, uniqueId)
 	if endpointToUse == nil && endpoint != nil {
 		endpointToUse = endpoint
 	}
 	if endpointToUse != nil {
-		var filters []gwapiv1.HTTPRouteFilter
-		var hasRedirectPolicy bool
-		filters, hasRedirectPolicy = g.GenerateHTTPRouteFilters(apkConf, *endpointToUse, operation, endpointType)
+		filters, hasRedirectPolicy, filterDiags := g.GenerateHTTPRouteFilters(apkConf, *endpointToUse, operation, endpointType, uniqueId)
+		diags = append(diags, filterDiags...)
+		if filterDiags.HasErrors() {
+			return nil, diags
+		}
 		matches, err := g.RetrieveHTTPMatches(apkConf, operation)
 		if err != nil {
-			return nil, err
+			diags = append(diags, diagnostics.FromError(operationPath, err)...)
+			return nil, diags
 		}
 
+		ruleName := gwapiv1.SectionName(utils.GetRuleName(operation))
 		httpRouteRule := gwapiv1.HTTPRouteRule{
+			Name:    &ruleName,
 			Matches: matches,
 			Filters: filters,
 		}
 		if !hasRedirectPolicy {
 			httpRouteRule.BackendRefs = g.GenerateHTTPBackEndRef(*endpointToUse, operation, endpointType)
+		} else if operation.EndpointConfigurations != nil {
+			diags.Warnf(diagnostics.JoinPath(operationPath, "endpointConfigurations"), "endpoint is not used as the requests are redirected")
 		}
-		return &httpRouteRule, nil
+		return &httpRouteRule, diags
 	} else {
-		return nil, errors.New("invalid endpoint specified")
+		diags.Errorf(operationPath, "no %s endpoint specified for the operation or the API", endpointType)
+		return nil, diags
 	}
 }
 
@@ -89,30 +122,28 @@ func (g *httpRouteGenerator) generateAndRetrieveParentRefs(gatewayConfig types.G
 
 // generateHTTPBackEndRef generates a list of HTTPBackendRefs based on the provided configurations.
 func (g *httpRouteGenerator) generateHTTPBackEndRef(endpoint types.EndpointDetails, operation types.Operation, endpointType string) []gwapiv1.HTTPBackendRef {
-	kind := gwapiv1.Kind("Service")
 	httpBackEndRef := gwapiv1.HTTPBackendRef{
 		BackendRef: gwapiv1.BackendRef{
-			BackendObjectReference: gwapiv1.BackendObjectReference{
-				Kind: &kind,
-				Name: gwapiv1.ObjectName(endpoint.Name),
-			},
+			BackendObjectReference: utils.GenerateBackendObjectReference(endpoint),
 		},
 	}
 	return []gwapiv1.HTTPBackendRef{httpBackEndRef}
 }
 
 // generateHTTPRouteFilters generates a list of HTTPRouteFilters based on the provided configurations.
-func (g *httpRouteGenerator) generateHTTPRouteFilters(apkConf types.APKConf, endpointToUse types.EndpointDetails, operation types.Operation, endpointType string) ([]gwapiv1.HTTPRouteFilter, bool) {
+func (g *httpRouteGenerator) generateHTTPRouteFilters(apkConf types.APKConf, endpointToUse types.EndpointDetails, operation types.Operation, endpointType string, uniqueId string) ([]gwapiv1.HTTPRouteFilter, bool, diagnostics.Diagnostics) {
 	routeFilters := make([]gwapiv1.HTTPRouteFilter, 0)
-	var operationPoliciesToUse *types.OperationPolicies
+	var diags diagnostics.Diagnostics
+	operationPoliciesPath := diagnostics.JoinPath(utils.GetOperationPath(apkConf, operation), "operationPolicies")
+	operationPoliciesToUse := operation.OperationPolicies
+	policiesPath := operationPoliciesPath
 	hasRedirectPolicy := false
-	operationPolicies := apkConf.APIPolicies
-	if operationPolicies != nil {
-		if operationPolicies.Request != nil || operationPolicies.Response != nil {
-			operationPoliciesToUse = operationPolicies
+	if utils.HasAPIPolicies(apkConf) {
+		if operation.OperationPolicies != nil {
+			diags.Warnf(operationPoliciesPath, "operation policies are ignored as API level policies are configured")
 		}
-	} else {
-		operationPoliciesToUse = operation.OperationPolicies
+		operationPoliciesToUse = apkConf.APIPolicies
+		policiesPath = "apiPolicies"
 	}
 
 	if operationPoliciesToUse != nil {
@@ -120,15 +151,19 @@ func (g *httpRouteGenerator) generateHTTPRouteFilters(apkConf types.APKConf, end
 		responsePolicies := operationPoliciesToUse.Response
 
 		if len(requestPolicies) > 0 {
-			var requestHttpRouteFilters []gwapiv1.HTTPRouteFilter
-			requestHttpRouteFilters, hasRedirectPolicy = g.ExtractHTTPRouteFilter(&apkConf, endpointToUse, operation, requestPolicies, true)
+			requestHttpRouteFilters, hasRequestRedirectPolicy, requestDiags := g.ExtractHTTPRouteFilter(&apkConf, endpointToUse, operation, requestPolicies, true, uniqueId)
+			diags = append(diags, requestDiags.WithPathPrefix(policiesPath)...)
+			hasRedirectPolicy = hasRequestRedirectPolicy
 			routeFilters = append(routeFilters, requestHttpRouteFilters...)
 		}
 		if len(responsePolicies) > 0 {
-			var responseHttpRouteFilters []gwapiv1.HTTPRouteFilter
-			responseHttpRouteFilters, _ = g.ExtractHTTPRouteFilter(&apkConf, endpointToUse, operation, responsePolicies, false)
+			responseHttpRouteFilters, _, responseDiags := g.ExtractHTTPRouteFilter(&apkConf, endpointToUse, operation, responsePolicies, false, uniqueId)
+			diags = append(diags, responseDiags.WithPathPrefix(policiesPath)...)
 			routeFilters = append(routeFilters, responseHttpRouteFilters...)
 		}
+		if diags.HasErrors() {
+			return nil, false, diags
+		}
 	}
 	if !hasRedirectPolicy {
 		generatedPath := utils.GeneratePrefixMatch(endpointToUse, operation)
@@ -143,18 +178,26 @@ func (g *httpRouteGenerator) generateHTTPRouteFilters(apkConf types.APKConf, end
 		}
 		routeFilters = append(routeFilters, replacePathFilter)
 	}
-	return routeFilters, hasRedirectPolicy
+	routeFilters = append(routeFilters, utils.GetOperationExtensionRefFilters(apkConf, operation, uniqueId)...)
+	return routeFilters, hasRedirectPolicy, diags
 }
 
 // extractHTTPRouteFilter extracts the HTTPRouteFilters based on the provided configurations.
-func (g *httpRouteGenerator) extractHTTPRouteFilter(apkConf *types.APKConf, endpoint types.EndpointDetails, operation types.Operation, operationPolicies []types.OperationPolicy, isRequest bool) ([]gwapiv1.HTTPRouteFilter, bool) {
+// The paths of the returned diagnostics are relative to the policies, e.g. response[0].
+func (g *httpRouteGenerator) extractHTTPRouteFilter(apkConf *types.APKConf, endpoint types.EndpointDetails, operation types.Operation, operationPolicies []types.OperationPolicy, isRequest bool, uniqueId string) ([]gwapiv1.HTTPRouteFilter, bool, diagnostics.Diagnostics) {
 	var httpRouteFilters = make([]gwapiv1.HTTPRouteFilter, 0)
 	var addHeaders = make([]gwapiv1.HTTPHeader, 0)
 	var setHeaders = make([]gwapiv1.HTTPHeader, 0)
 	var removeHeaders = make([]string, 0)
 	var hasRedirectPolicy bool = false
+	var diags diagnostics.Diagnostics
+	flow := "response"
+	if isRequest {
+		flow = "request"
+	}
 
-	for _, policy := range operationPolicies {
+	for i, policy := range operationPolicies {
+		policyPath := diagnostics.Index(flow, i)
 		if policyParameters, ok := policy.Parameters.(types.Header); ok {
 			switch policy.PolicyName {
 			case "AddHeader":
@@ -171,53 +214,57 @@ func (g *httpRouteGenerator) extractHTTPRouteFilter(apkConf *types.APKConf, endp
 				removeHeaders = append(removeHeaders, policyParameters.HeaderName)
 			}
 		} else if policyParameters, ok := policy.Parameters.(types.URLList); ok {
-			urls := policyParameters.URLs
-			for _, url := range urls {
-				mirrorFilter := gwapiv1.HTTPRouteFilter{Type: "RequestMirror"}
-				if !isRequest {
-					fmt.Println("Mirror filter cannot be appended as a response policy.")
+			if !isRequest {
+				diags.Errorf(policyPath, "%s policy cannot be used as a response policy", policy.PolicyName)
+				continue
+			}
+			for j, url := range policyParameters.URLs {
+				if utils.GetHost(types.EndpointURL(url)) == "" {
+					diags.Errorf(diagnostics.JoinPath(policyPath, diagnostics.Index("parameters.urls", j)), "invalid request mirror url: %q", url)
+					continue
 				}
-				port := utils.GetPort(url)
-				if port > 0 {
-					backendRef := g.GenerateHTTPBackEndRef(endpoint, operation, "")[0]
-					mirrorFilter.RequestMirror = &gwapiv1.HTTPRequestMirrorFilter{
-						BackendRef: gwapiv1.BackendObjectReference{
-							Name:      backendRef.Name,
-							Namespace: backendRef.Namespace,
-							Group:     backendRef.Group,
-							Kind:      backendRef.Kind,
-							Port:      backendRef.Port,
-						},
-					}
-					httpRouteFilters = append(httpRouteFilters, mirrorFilter)
+				// Each mirror URL is referred through its own backend, generated by the Backend generator.
+				mirrorFilter := gwapiv1.HTTPRouteFilter{
+					Type: "RequestMirror",
+					RequestMirror: &gwapiv1.HTTPRequestMirrorFilter{
+						BackendRef: utils.GenerateBackendObjectReference(utils.CreateEndpointDetails(uniqueId, types.EndpointConfiguration{Endpoint: types.EndpointURL(url)})),
+					},
 				}
+				httpRouteFilters = append(httpRouteFilters, mirrorFilter)
 			}
 		} else if policyParameters, ok := policy.Parameters.(types.RedirectPolicy); ok {
-			hasRedirectPolicy = true
 			if !isRequest {
-				fmt.Println("Redirect filter cannot be appended as a response policy.")
+				diags.Errorf(policyPath, "%s policy cannot be used as a response policy", policy.PolicyName)
+				continue
 			}
+			hasRedirectPolicy = true
 			url := policyParameters.URL
-			redirectFilter := gwapiv1.HTTPRouteFilter{Type: "RequestRedirect"}
-			port := utils.GetPort(url)
-			if port > 0 {
-				host := gwapiv1.PreciseHostname(utils.GetHost(types.EndpointURL(url)))
-				schema := utils.GetProtocol(url)
-				replaceFullPath := utils.GetPath(url)
-				redirectFilter.RequestRedirect = &gwapiv1.HTTPRequestRedirectFilter{
+			if utils.GetHost(types.EndpointURL(url)) == "" {
+				diags.Errorf(diagnostics.JoinPath(policyPath, "parameters.url"), "invalid request redirect url: %q", url)
+				continue
+			}
+			host := gwapiv1.PreciseHostname(utils.GetHost(types.EndpointURL(url)))
+			schema := utils.GetProtocol(url)
+			replaceFullPath := utils.GetPath(url)
+			redirectFilter := gwapiv1.HTTPRouteFilter{
+				Type: "RequestRedirect",
+				RequestRedirect: &gwapiv1.HTTPRequestRedirectFilter{
 					Hostname: &host,
 					Scheme:   &schema,
 					Path: &gwapiv1.HTTPPathModifier{
 						Type:            "ReplaceFullPath",
 						ReplaceFullPath: &replaceFullPath,
 					},
-				}
-				if policyParameters.StatusCode > 0 {
-					redirectFilter.RequestRedirect.StatusCode = &policyParameters.StatusCode
-				}
+				},
+			}
+			if policyParameters.StatusCode > 0 {
+				redirectFilter.RequestRedirect.StatusCode = &policyParameters.StatusCode
 			}
 			httpRouteFilters = append(httpRouteFilters, redirectFilter)
+		} else if _, ok := policy.Parameters.(types.BackendJWT); ok && !isRequest {
+			diags.Errorf(policyPath, "%s policy cannot be used as a response policy", policy.PolicyName)
 		}
+		// Interceptor and BackendJwt request policies are applied through the APIPolicy of the route rule.
 	}
 	var headerModifier gwapiv1.HTTPHeaderFilter
 	if len(addHeaders) != 0 {
@@ -244,29 +291,43 @@ func (g *httpRouteGenerator) extractHTTPRouteFilter(apkConf *types.APKConf, endp
 	if len(addHeaders) > 0 || len(setHeaders) > 0 || len(removeHeaders) > 0 {
 		httpRouteFilters = append(httpRouteFilters, headerModifierFilter)
 	}
-	return httpRouteFilters, hasRedirectPolicy
+	if diags.HasErrors() {
+		return nil, hasRedirectPolicy, diags
+	}
+	return httpRouteFilters, hasRedirectPolicy, diags
 }
 
 // retrieveHTTPMatches retrieves the HTTPRouteMatches based on the provided configurations.
 func (g *httpRouteGenerator) retrieveHTTPMatches(apkConf types.APKConf, operation types.Operation) ([]gwapiv1.HTTPRouteMatch, error) {
 	var httpRouteMatches []gwapiv1.HTTPRouteMatch
-	httpRouteMatch, err := g.RetrieveHTTPMatch(apkConf, operation)
+	basePath := utils.RetrieveFullBasePath(apkConf.BasePath, apkConf.Version)
+	httpRouteMatch, err := g.RetrieveHTTPMatch(apkConf, operation, basePath)
 	if err != nil {
 		return nil, err
 	}
 	httpRouteMatches = append(httpRouteMatches, httpRouteMatch)
+	if apkConf.DefaultVersion {
+		defaultBasePath := utils.RetrieveBasePathWithoutVersion(apkConf.BasePath, apkConf.Version)
+		if defaultBasePath != basePath {
+			defaultHttpRouteMatch, err := g.RetrieveHTTPMatch(apkConf, operation, defaultBasePath)
+			if err != nil {
+				return nil, err
+			}
+			httpRouteMatches = append(httpRouteMatches, defaultHttpRouteMatch)
+		}
+	}
 	return httpRouteMatches, nil
 }
 
-// retrieveHTTPMatch retrieves the HTTPRouteMatch based on the provided configurations.
-func (g *httpRouteGenerator) retrieveHTTPMatch(apkConf types.APKConf, operation types.Operation) (gwapiv1.HTTPRouteMatch, error) {
+// retrieveHTTPMatch retrieves the HTTPRouteMatch for the given base path based on the provided configurations.
+func (g *httpRouteGenerator) retrieveHTTPMatch(apkConf types.APKConf, operation types.Operation, basePath string) (gwapiv1.HTTPRouteMatch, error) {
 	method := gwapiv1.HTTPMethod(operation.Verb)
 	pathType := gwapiv1.PathMatchRegularExpression
 	operationTarget := "/*"
 	if operation.Target != "" {
 		operationTarget = operation.Target
 	}
-	pathValue := utils.RetrievePathPrefix(operationTarget, apkConf.BasePath)
+	pathValue := utils.RetrievePathPrefix(operationTarget, basePath)
 	httpRouteMatch := gwapiv1.HTTPRouteMatch{
 		Method: &method,
 		Path: &gwapiv1.HTTPPathMatch{
@@ -276,3 +337,81 @@ func (g *httpRouteGenerator) retrieveHTTPMatch(apkConf types.APKConf, operation
 	}
 	return httpRouteMatch, nil
 }
+
+// generateCORSHeaders generates the CORS response headers based on the provided CORS configuration.
+func (g *httpRouteGenerator) generateCORSHeaders(corsConfig types.CORSConfiguration) ([]gwapiv1.HTTPHeader, error) {
+	// A header modifier cannot echo the request origin, hence only a single allowed origin is supported.
+	if len(corsConfig.AccessControlAllowOrigins) != 1 {
+		return nil, fmt.Errorf("gateway CORS requires exactly one allowed origin, found %d", len(corsConfig.AccessControlAllowOrigins))
+	}
+	corsHeaders := []gwapiv1.HTTPHeader{
+		{Name: "Access-Control-Allow-Origin", Value: corsConfig.AccessControlAllowOrigins[0]},
+	}
+	if corsConfig.AccessControlAllowCredentials {
+		corsHeaders = append(corsHeaders, gwapiv1.HTTPHeader{Name: "Access-Control-Allow-Credentials", Value: "true"})
+	}
+	if len(corsConfig.AccessControlAllowHeaders) > 0 {
+		corsHeaders = append(corsHeaders, gwapiv1.HTTPHeader{Name: "Access-Control-Allow-Headers", Value: strings.Join(corsConfig.AccessControlAllowHeaders, ", ")})
+	}
+	if len(corsConfig.AccessControlAllowMethods) > 0 {
+		corsHeaders = append(corsHeaders, gwapiv1.HTTPHeader{Name: "Access-Control-Allow-Methods", Value: strings.Join(corsConfig.AccessControlAllowMethods, ", ")})
+	}
+	return corsHeaders, nil
+}
+
+// generateCORSPreflightRule generates the route rule forwarding the preflight OPTIONS requests of the API
+// to the given endpoint with the CORS headers set on the response.
+func (g *httpRouteGenerator) generateCORSPreflightRule(apkConf types.APKConf, endpoint *types.EndpointDetails, endpointType string, corsHeaders []gwapiv1.HTTPHeader) (*gwapiv1.HTTPRouteRule, error) {
+	if endpoint == nil {
+		return nil, nil
+	}
+	operation := types.Operation{Target: "/*", Verb: "OPTIONS"}
+	if apkConf.Operations != nil {
+		for _, apiOperation := range *apkConf.Operations {
+			// The API serves its own preflight requests.
+			if strings.EqualFold(apiOperation.Verb, operation.Verb) && apiOperation.Target == operation.Target {
+				return nil, nil
+			}
+		}
+	}
+	matches, err := g.RetrieveHTTPMatches(apkConf, operation)
+	if err != nil {
+		return nil, err
+	}
+	generatedPath := utils.GeneratePrefixMatch(*endpoint, operation)
+	ruleName := gwapiv1.SectionName(utils.GetRuleName(operation))
+	httpRouteRule := gwapiv1.HTTPRouteRule{
+		Name:    &ruleName,
+		Matches: matches,
+		Filters: appendResponseHeaders([]gwapiv1.HTTPRouteFilter{
+			{
+				Type: "URLRewrite",
+				URLRewrite: &gwapiv1.HTTPURLRewriteFilter{
+					Path: &gwapiv1.HTTPPathModifier{
+						Type:            gwapiv1.FullPathHTTPPathModifier,
+						ReplaceFullPath: &generatedPath,
+					},
+				},
+			},
+		}, corsHeaders),
+		BackendRefs: g.GenerateHTTPBackEndRef(*endpoint, operation, endpointType),
+	}
+	return &httpRouteRule, nil
+}
+
+// appendResponseHeaders sets the given headers on the response header modifier of the filters,
+// adding the modifier when the filters do not have one.
+func appendResponseHeaders(filters []gwapiv1.HTTPRouteFilter, headers []gwapiv1.HTTPHeader) []gwapiv1.HTTPRouteFilter {
+	for i := range filters {
+		if filters[i].Type == gwapiv1.HTTPRouteFilterResponseHeaderModifier && filters[i].ResponseHeaderModifier != nil {
+			filters[i].ResponseHeaderModifier.Set = append(filters[i].ResponseHeaderModifier.Set, headers...)
+			return filters
+		}
+	}
+	return append(filters, gwapiv1.HTTPRouteFilter{
+		Type: gwapiv1.HTTPRouteFilterResponseHeaderModifier,
+		ResponseHeaderModifier: &gwapiv1.HTTPHeaderFilter{
+			Set: headers,
+		},
+	})
+}
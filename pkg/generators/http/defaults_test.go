@@ -25,6 +25,7 @@ import (
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
 
+	"k8s.io/utils/ptr"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
@@ -49,14 +50,14 @@ func TestGenerateHTTPRoute(t *testing.T) {
 		Authentication: &[]types.AuthConfiguration{
 			{
 				AuthType: "APIKey",
-				Enabled:  true,
+				Enabled:  ptr.To(true),
 			},
 		},
 		Operations: &[]types.Operation{
-			{Target: "/employees", Verb: "GET", Secured: true, Scopes: []string{}},
-			{Target: "/employee", Verb: "POST", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: true, Scopes: []string{}},
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee", Verb: "POST", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: ptr.To(true), Scopes: []string{}},
 		},
 	}
 
@@ -69,15 +70,15 @@ func TestGenerateHTTPRoute(t *testing.T) {
 		Hostname:     "wso2-apim",
 	}
 	operations := *apkConf.Operations
-	endpoints := utils.GetEndpoints(apkConf)
+	uniqueId := "test-id"
+	endpoints := utils.GetEndpoints(apkConf, uniqueId)
 	endpoint := endpoints[constants.PRODUCTION_TYPE]
 	endpointType := "test-endpoint"
-	uniqueId := "test-id"
 	count := 1
 
-	httpRoute, err := g.GenerateHTTPRoute(apkConf, organization, gatewayConfiguration, operations, &endpoint, endpointType, uniqueId, count)
-	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
+	httpRoute, diags := g.GenerateHTTPRoute(apkConf, organization, gatewayConfiguration, operations, &endpoint, endpointType, uniqueId, count)
+	if diags.HasErrors() {
+		t.Fatalf("Expected no error, got %v", diags)
 	}
 
 	if httpRoute == nil {
@@ -111,24 +112,25 @@ func TestGenerateHTTPRouteRules(t *testing.T) {
 		Authentication: &[]types.AuthConfiguration{
 			{
 				AuthType: "APIKey",
-				Enabled:  true,
+				Enabled:  ptr.To(true),
 			},
 		},
 		Operations: &[]types.Operation{
-			{Target: "/employees", Verb: "GET", Secured: true, Scopes: []string{}},
-			{Target: "/employee", Verb: "POST", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: true, Scopes: []string{}},
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee", Verb: "POST", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: ptr.To(true), Scopes: []string{}},
 		},
 	}
 	operations := *apkConf.Operations
-	endpoints := utils.GetEndpoints(apkConf)
+	uniqueId := "unique-id"
+	endpoints := utils.GetEndpoints(apkConf, uniqueId)
 	endpoint := endpoints[constants.PRODUCTION_TYPE]
 	endpointType := "test-endpoint"
 
-	httpRouteRules, err := g.GenerateHTTPRouteRules(apkConf, operations, &endpoint, endpointType)
-	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
+	httpRouteRules, diags := g.GenerateHTTPRouteRules(apkConf, operations, &endpoint, endpointType, uniqueId)
+	if diags.HasErrors() {
+		t.Fatalf("Expected no error, got %v", diags)
 	}
 
 	if httpRouteRules == nil {
@@ -157,24 +159,25 @@ func TestGenerateHTTPRouteRule(t *testing.T) {
 		Authentication: &[]types.AuthConfiguration{
 			{
 				AuthType: "APIKey",
-				Enabled:  true,
+				Enabled:  ptr.To(true),
 			},
 		},
 		Operations: &[]types.Operation{
-			{Target: "/employees", Verb: "GET", Secured: true, Scopes: []string{}},
-			{Target: "/employee", Verb: "POST", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: true, Scopes: []string{}},
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee", Verb: "POST", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: ptr.To(true), Scopes: []string{}},
 		},
 	}
 	operation := (*apkConf.Operations)[0]
-	endpoints := utils.GetEndpoints(apkConf)
+	uniqueId := "unique-id"
+	endpoints := utils.GetEndpoints(apkConf, uniqueId)
 	endpoint := endpoints[constants.PRODUCTION_TYPE]
 	endpointType := "test-endpoint"
 
-	httpRouteRule, err := g.GenerateHTTPRouteRule(apkConf, operation, &endpoint, endpointType)
-	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
+	httpRouteRule, diags := g.GenerateHTTPRouteRule(apkConf, operation, &endpoint, endpointType, uniqueId)
+	if diags.HasErrors() {
+		t.Fatalf("Expected no error, got %v", diags)
 	}
 
 	if httpRouteRule == nil {
@@ -239,21 +242,24 @@ func TestGenerateHTTPRouteFilters(t *testing.T) {
 		Authentication: &[]types.AuthConfiguration{
 			{
 				AuthType: "APIKey",
-				Enabled:  true,
+				Enabled:  ptr.To(true),
 			},
 		},
 		Operations: &[]types.Operation{
-			{Target: "/employees", Verb: "GET", Secured: true, Scopes: []string{}},
-			{Target: "/employee", Verb: "POST", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: true, Scopes: []string{}},
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee", Verb: "POST", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: ptr.To(true), Scopes: []string{}},
 		},
 	}
 	endpointToUse := types.EndpointDetails{}
 	operation := (*apkConf.Operations)[0]
 	endpointType := "test-endpoint"
 
-	filters, hasRedirectPolicy := g.GenerateHTTPRouteFilters(apkConf, endpointToUse, operation, endpointType)
+	filters, hasRedirectPolicy, diags := g.GenerateHTTPRouteFilters(apkConf, endpointToUse, operation, endpointType, "unique-id")
+	if diags.HasErrors() {
+		t.Fatalf("Expected no error, got %v", diags)
+	}
 	if filters == nil {
 		t.Fatalf("Expected HTTPRouteFilters, got nil")
 	}
@@ -284,14 +290,14 @@ func TestRetrieveHTTPMatches(t *testing.T) {
 		Authentication: &[]types.AuthConfiguration{
 			{
 				AuthType: "APIKey",
-				Enabled:  true,
+				Enabled:  ptr.To(true),
 			},
 		},
 		Operations: &[]types.Operation{
-			{Target: "/employees", Verb: "GET", Secured: true, Scopes: []string{}},
-			{Target: "/employee", Verb: "POST", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: true, Scopes: []string{}},
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee", Verb: "POST", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: ptr.To(true), Scopes: []string{}},
 		},
 	}
 	operation := (*apkConf.Operations)[0]
@@ -305,3 +311,228 @@ func TestRetrieveHTTPMatches(t *testing.T) {
 		t.Fatalf("Expected HTTPRouteMatches, got nil")
 	}
 }
+
+func TestRetrieveHTTPMatchesDefaultVersion(t *testing.T) {
+	g := Generator()
+	apkConf := types.APKConf{
+		Name:           "EmployeeServiceAPI",
+		Version:        "3.14",
+		BasePath:       "/employees-info",
+		Type:           "REST",
+		DefaultVersion: true,
+	}
+	operation := types.Operation{Target: "/employee/{employeeId}", Verb: "GET"}
+
+	httpRouteMatches, err := g.RetrieveHTTPMatches(apkConf, operation)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expectedPaths := []string{"/employees-info/3\\.14/employee/(.*)", "/employees-info/employee/(.*)"}
+	if len(httpRouteMatches) != len(expectedPaths) {
+		t.Fatalf("Expected %d HTTPRouteMatches, got %d", len(expectedPaths), len(httpRouteMatches))
+	}
+	for i, expectedPath := range expectedPaths {
+		if *httpRouteMatches[i].Path.Value != expectedPath {
+			t.Errorf("Expected path %s, got %s", expectedPath, *httpRouteMatches[i].Path.Value)
+		}
+	}
+}
+
+func TestGenerateHTTPRouteRulesGatewayCORS(t *testing.T) {
+	g := Generator()
+	g.EnableGatewayCORS = true
+	apkConf := types.APKConf{
+		Name:     "EmployeeServiceAPI",
+		Version:  "3.14",
+		BasePath: "/employees-info",
+		Type:     "REST",
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-service:8080"),
+			},
+		},
+		CorsConfig: &types.CORSConfiguration{
+			CORSConfigurationEnabled:      true,
+			AccessControlAllowOrigins:     []string{"https://example.com"},
+			AccessControlAllowCredentials: true,
+			AccessControlAllowHeaders:     []string{"authorization", "content-type"},
+			AccessControlAllowMethods:     []string{"GET", "OPTIONS"},
+		},
+		Operations: &[]types.Operation{
+			{
+				Target: "/employees",
+				Verb:   "GET",
+				OperationPolicies: &types.OperationPolicies{
+					Response: []types.OperationPolicy{
+						{PolicyName: "SetHeader", Parameters: types.Header{HeaderName: "x-response", HeaderValue: "value"}},
+					},
+				},
+			},
+		},
+	}
+	uniqueId := "unique-id"
+	endpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.PRODUCTION_TYPE]
+
+	httpRouteRules, diags := g.GenerateHTTPRouteRules(apkConf, *apkConf.Operations, &endpoint, constants.PRODUCTION_TYPE, uniqueId)
+	if diags.HasErrors() {
+		t.Fatalf("Expected no error, got %v", diags)
+	}
+	if len(httpRouteRules) != 2 {
+		t.Fatalf("Expected 2 HTTPRouteRules, got %d", len(httpRouteRules))
+	}
+
+	for _, httpRouteRule := range httpRouteRules {
+		responseHeaderModifiers := 0
+		for _, filter := range httpRouteRule.Filters {
+			if filter.Type == gwapiv1.HTTPRouteFilterResponseHeaderModifier {
+				responseHeaderModifiers++
+				if filter.ResponseHeaderModifier.Set[len(filter.ResponseHeaderModifier.Set)-1].Name != "Access-Control-Allow-Methods" {
+					t.Errorf("Expected the CORS headers to be set, got %v", filter.ResponseHeaderModifier.Set)
+				}
+			}
+		}
+		if responseHeaderModifiers != 1 {
+			t.Errorf("Expected a single ResponseHeaderModifier filter, got %d", responseHeaderModifiers)
+		}
+	}
+
+	preflightRule := httpRouteRules[1]
+	if *preflightRule.Matches[0].Method != "OPTIONS" {
+		t.Errorf("Expected the preflight rule to match OPTIONS, got %s", *preflightRule.Matches[0].Method)
+	}
+	if len(preflightRule.BackendRefs) != 1 {
+		t.Errorf("Expected the preflight rule to have a backend, got %d", len(preflightRule.BackendRefs))
+	}
+
+	apkConf.CorsConfig.AccessControlAllowOrigins = []string{"https://example.com", "https://example.org"}
+	if _, diags := g.GenerateHTTPRouteRules(apkConf, *apkConf.Operations, &endpoint, constants.PRODUCTION_TYPE, uniqueId); !diags.HasErrors() {
+		t.Errorf("Expected an error for multiple allowed origins, got nil")
+	}
+}
+
+func TestExtractHTTPRouteFilter(t *testing.T) {
+	g := Generator()
+	apkConf := types.APKConf{
+		Name:     "EmployeeServiceAPI",
+		Version:  "3.14",
+		BasePath: "/employees-info",
+		Type:     "REST",
+	}
+	endpoint := utils.CreateEndpointDetails("unique-id", types.EndpointConfiguration{Endpoint: types.EndpointURL("http://employee-service:8080")})
+	operation := types.Operation{Target: "/employees", Verb: "GET"}
+	mirrorPolicies := []types.OperationPolicy{
+		{PolicyName: "RequestMirror", Parameters: types.URLList{URLs: []string{"http://mirror-service:9090/api", "https://mirror-service-2"}}},
+	}
+
+	filters, hasRedirectPolicy, diags := g.ExtractHTTPRouteFilter(&apkConf, endpoint, operation, mirrorPolicies, true, "unique-id")
+	if diags.HasErrors() {
+		t.Fatalf("Expected no error, got %v", diags)
+	}
+	if hasRedirectPolicy {
+		t.Errorf("Expected no redirect policy, got one")
+	}
+	if len(filters) != 2 {
+		t.Fatalf("Expected 2 HTTPRouteFilters, got %d", len(filters))
+	}
+	expectedMirrors := []struct {
+		name string
+		port gwapiv1.PortNumber
+	}{
+		{utils.GetBackendName("unique-id", types.EndpointConfiguration{Endpoint: types.EndpointURL("http://mirror-service:9090/api")}), 9090},
+		{utils.GetBackendName("unique-id", types.EndpointConfiguration{Endpoint: types.EndpointURL("https://mirror-service-2")}), 443},
+	}
+	for i, expected := range expectedMirrors {
+		backendRef := filters[i].RequestMirror.BackendRef
+		if string(backendRef.Name) != expected.name {
+			t.Errorf("Expected mirror backend %s, got %s", expected.name, backendRef.Name)
+		}
+		if backendRef.Name == gwapiv1.ObjectName(endpoint.Name) {
+			t.Errorf("Expected the mirror not to refer the primary backend")
+		}
+		if backendRef.Port == nil || *backendRef.Port != expected.port {
+			t.Errorf("Expected mirror port %d, got %v", expected.port, backendRef.Port)
+		}
+		if backendRef.Kind == nil || *backendRef.Kind != constants.BACKEND_KIND {
+			t.Errorf("Expected mirror kind %s, got %v", constants.BACKEND_KIND, backendRef.Kind)
+		}
+	}
+
+	// Mirror and redirect policies are not allowed in the response flow
+	if _, _, diags := g.ExtractHTTPRouteFilter(&apkConf, endpoint, operation, mirrorPolicies, false, "unique-id"); !diags.HasErrors() || diags[0].Path != "response[0]" {
+		t.Errorf("Expected an error on response[0] for a response mirror policy, got %v", diags)
+	}
+	redirectPolicies := []types.OperationPolicy{
+		{PolicyName: "RequestRedirect", Parameters: types.RedirectPolicy{URL: "https://example.com/redirect", StatusCode: 301}},
+	}
+	if _, _, diags := g.ExtractHTTPRouteFilter(&apkConf, endpoint, operation, redirectPolicies, false, "unique-id"); !diags.HasErrors() {
+		t.Errorf("Expected an error for a response redirect policy, got nil")
+	}
+
+	invalidMirrorPolicies := []types.OperationPolicy{
+		{PolicyName: "RequestMirror", Parameters: types.URLList{URLs: []string{"mirror-service"}}},
+	}
+	if _, _, diags := g.ExtractHTTPRouteFilter(&apkConf, endpoint, operation, invalidMirrorPolicies, true, "unique-id"); !diags.HasErrors() || diags[0].Path != "request[0].parameters.urls[0]" {
+		t.Errorf("Expected an error on request[0].parameters.urls[0] for an invalid mirror url, got %v", diags)
+	}
+}
+
+func TestGenerateHTTPRouteRuleDiagnostics(t *testing.T) {
+	g := Generator()
+	apkConf := types.APKConf{
+		Name:     "EmployeeServiceAPI",
+		Version:  "3.14",
+		BasePath: "/employees-info",
+		Type:     "REST",
+		APIPolicies: &types.OperationPolicies{
+			Request: []types.OperationPolicy{
+				{PolicyName: "AddHeader", Parameters: types.Header{HeaderName: "x-request", HeaderValue: "value"}},
+			},
+			Response: []types.OperationPolicy{
+				{PolicyName: "RequestMirror", Parameters: types.URLList{URLs: []string{"http://mirror-service:9090"}}},
+				{PolicyName: "BackendJwt", Parameters: types.BackendJWT{SigningAlgorithm: "SHA256withRSA"}},
+			},
+		},
+		Operations: &[]types.Operation{
+			{Target: "/employees", Verb: "GET"},
+			{
+				Target: "/employee",
+				Verb:   "POST",
+				OperationPolicies: &types.OperationPolicies{
+					Request: []types.OperationPolicy{
+						{PolicyName: "RemoveHeader", Parameters: types.Header{HeaderName: "x-remove"}},
+					},
+				},
+			},
+		},
+	}
+	endpoint := utils.CreateEndpointDetails("unique-id", types.EndpointConfiguration{Endpoint: types.EndpointURL("http://employee-service:8080")})
+
+	uniqueId := "unique-id"
+	httpRouteRule, diags := g.GenerateHTTPRouteRule(apkConf, (*apkConf.Operations)[1], &endpoint, constants.PRODUCTION_TYPE, uniqueId)
+	if httpRouteRule != nil {
+		t.Errorf("Expected no HTTPRouteRule, got %v", httpRouteRule)
+	}
+	expectedPaths := []string{"operations[1].operationPolicies", "apiPolicies.response[0]", "apiPolicies.response[1]"}
+	if len(diags) != len(expectedPaths) {
+		t.Fatalf("Expected %d diagnostics, got %v", len(expectedPaths), diags)
+	}
+	for i, expectedPath := range expectedPaths {
+		if diags[i].Path != expectedPath {
+			t.Errorf("Expected path %s, got %s", expectedPath, diags[i].Path)
+		}
+	}
+
+	// Warnings are returned along with the rule
+	apkConf.APIPolicies.Response = nil
+	httpRouteRule, diags = g.GenerateHTTPRouteRule(apkConf, (*apkConf.Operations)[1], &endpoint, constants.PRODUCTION_TYPE, uniqueId)
+	if httpRouteRule == nil || diags.HasErrors() || len(diags.Warnings()) != 1 {
+		t.Errorf("Expected a HTTPRouteRule with a warning, got %v", diags)
+	}
+
+	// Missing endpoint
+	_, diags = g.GenerateHTTPRouteRule(apkConf, (*apkConf.Operations)[0], nil, constants.PRODUCTION_TYPE, uniqueId)
+	if !diags.HasErrors() || diags[0].Path != "operations[0]" {
+		t.Errorf("Expected an error on operations[0], got %v", diags)
+	}
+}
@@ -20,7 +20,9 @@ package http_generator
 import (
 	"strconv"
 
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,15 +31,21 @@ import (
 
 // HttpRouteGenerator is the interface for the HTTP route generator.
 type httpRouteGenerator struct {
-	GenerateHTTPRouteRules        func(apkConf types.APKConf, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string) ([]gwapiv1.HTTPRouteRule, error)
-	GenerateHTTPRouteRule         func(apkConf types.APKConf, operation types.Operation, endpoint *types.EndpointDetails, endpointType string) (*gwapiv1.HTTPRouteRule, error)
+	GenerateHTTPRouteRules        func(apkConf types.APKConf, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) ([]gwapiv1.HTTPRouteRule, diagnostics.Diagnostics)
+	GenerateHTTPRouteRule         func(apkConf types.APKConf, operation types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) (*gwapiv1.HTTPRouteRule, diagnostics.Diagnostics)
 	GenerateAndRetrieveParentRefs func(gatewayConfig types.GatewayConfigurations, uniqueId string) []gwapiv1.ParentReference
-	GenerateHTTPRouteFilters      func(apkConf types.APKConf, endpointToUse types.EndpointDetails, operation types.Operation, endpointType string) ([]gwapiv1.HTTPRouteFilter, bool)
-	ExtractHTTPRouteFilter        func(apkConf *types.APKConf, endpoint types.EndpointDetails, operation types.Operation, operationPolicies []types.OperationPolicy, isRequest bool) ([]gwapiv1.HTTPRouteFilter, bool)
-	GetHostNames                  func(apkConf types.APKConf, endpointType string, organization types.Organization) []gwapiv1.Hostname
+	GenerateHTTPRouteFilters      func(apkConf types.APKConf, endpointToUse types.EndpointDetails, operation types.Operation, endpointType string, uniqueId string) ([]gwapiv1.HTTPRouteFilter, bool, diagnostics.Diagnostics)
+	ExtractHTTPRouteFilter        func(apkConf *types.APKConf, endpoint types.EndpointDetails, operation types.Operation, operationPolicies []types.OperationPolicy, isRequest bool, uniqueId string) ([]gwapiv1.HTTPRouteFilter, bool, diagnostics.Diagnostics)
+	GetHostNames                  func(apkConf types.APKConf, endpointType string, organization types.Organization, gatewayConfig types.GatewayConfigurations) []gwapiv1.Hostname
 	RetrieveHTTPMatches           func(apkConf types.APKConf, operation types.Operation) ([]gwapiv1.HTTPRouteMatch, error)
-	RetrieveHTTPMatch             func(apkConf types.APKConf, operation types.Operation) (gwapiv1.HTTPRouteMatch, error)
+	RetrieveHTTPMatch             func(apkConf types.APKConf, operation types.Operation, basePath string) (gwapiv1.HTTPRouteMatch, error)
 	GenerateHTTPBackEndRef        func(endpoint types.EndpointDetails, operation types.Operation, endpointType string) []gwapiv1.HTTPBackendRef
+	GenerateCORSHeaders           func(corsConfig types.CORSConfiguration) ([]gwapiv1.HTTPHeader, error)
+	GenerateCORSPreflightRule     func(apkConf types.APKConf, endpoint *types.EndpointDetails, endpointType string, corsHeaders []gwapiv1.HTTPHeader) (*gwapiv1.HTTPRouteRule, error)
+
+	// EnableGatewayCORS applies the CORS configuration with Gateway API filters and a preflight rule,
+	// for gateways without the APK APIPolicy extension.
+	EnableGatewayCORS bool
 }
 
 // Generator creates a new HTTP route generator.
@@ -52,19 +60,48 @@ func Generator() *httpRouteGenerator {
 	gen.RetrieveHTTPMatches = gen.retrieveHTTPMatches
 	gen.RetrieveHTTPMatch = gen.retrieveHTTPMatch
 	gen.GenerateHTTPBackEndRef = gen.generateHTTPBackEndRef
+	gen.GenerateCORSHeaders = gen.generateCORSHeaders
+	gen.GenerateCORSPreflightRule = gen.generateCORSPreflightRule
 	return gen
 }
 
 // GenerateHTTPRoute generates a HTTPRoute based on the provided configurations.
-func (g *httpRouteGenerator) GenerateHTTPRoute(apkConf types.APKConf, organization types.Organization, gatewayConfiguration types.GatewayConfigurations, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string, count int) (*gwapiv1.HTTPRoute, error) {
-	httpRouteRules, err := g.GenerateHTTPRouteRules(apkConf, operations, endpoint, endpointType)
-	if err != nil {
-		return nil, err
+// The HTTPRoute is nil when the diagnostics contain errors, while warnings are returned along with the HTTPRoute.
+func (g *httpRouteGenerator) GenerateHTTPRoute(apkConf types.APKConf, organization types.Organization, gatewayConfiguration types.GatewayConfigurations, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string, count int) (*gwapiv1.HTTPRoute, diagnostics.Diagnostics) {
+	httpRouteRules, diags := g.GenerateHTTPRouteRules(apkConf, operations, endpoint, endpointType, uniqueId)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return g.newHTTPRoute(apkConf, organization, gatewayConfiguration, httpRouteRules, endpointType, uniqueId, count), diags
+}
+
+// GenerateHTTPRoutes generates the HTTPRoutes of the operations, splitting the rules into as many HTTPRoutes as needed
+// to stay within the Gateway API limit of rules per route. Rules of the same backend are kept in the same HTTPRoute
+// when they fit, and the HTTPRoutes are named <uniqueId>-<endpointType>-httproute-<n>.
+func (g *httpRouteGenerator) GenerateHTTPRoutes(apkConf types.APKConf, organization types.Organization, gatewayConfiguration types.GatewayConfigurations, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) ([]*gwapiv1.HTTPRoute, diagnostics.Diagnostics) {
+	httpRouteRules, diags := g.GenerateHTTPRouteRules(apkConf, operations, endpoint, endpointType, uniqueId)
+	if diags.HasErrors() {
+		return nil, diags
 	}
-	httpRoute := gwapiv1.HTTPRoute{
+	var httpRoutes []*gwapiv1.HTTPRoute
+	ruleGroups := utils.SplitRules(httpRouteRules, func(rule gwapiv1.HTTPRouteRule) string {
+		if len(rule.BackendRefs) == 0 {
+			return ""
+		}
+		return string(rule.BackendRefs[0].Name)
+	})
+	for i, rules := range ruleGroups {
+		httpRoutes = append(httpRoutes, g.newHTTPRoute(apkConf, organization, gatewayConfiguration, rules, endpointType, uniqueId, i+1))
+	}
+	return httpRoutes, diags
+}
+
+// newHTTPRoute creates the HTTPRoute with the given rules.
+func (g *httpRouteGenerator) newHTTPRoute(apkConf types.APKConf, organization types.Organization, gatewayConfiguration types.GatewayConfigurations, httpRouteRules []gwapiv1.HTTPRouteRule, endpointType string, uniqueId string, count int) *gwapiv1.HTTPRoute {
+	return &gwapiv1.HTTPRoute{
 		TypeMeta: v1.TypeMeta{
 			Kind:       "HTTPRoute",
-			APIVersion: "gateway.sigs.k8s.io/v1",
+			APIVersion: constants.GATEWAY_V1,
 		},
 		ObjectMeta: v1.ObjectMeta{
 			Name: uniqueId + "-" + endpointType + "-httproute-" + strconv.Itoa(count),
@@ -74,8 +111,7 @@ func (g *httpRouteGenerator) GenerateHTTPRoute(apkConf types.APKConf, organizati
 				ParentRefs: g.GenerateAndRetrieveParentRefs(gatewayConfiguration, uniqueId),
 			},
 			Rules:     httpRouteRules,
-			Hostnames: g.GetHostNames(apkConf, endpointType, organization),
+			Hostnames: g.GetHostNames(apkConf, endpointType, organization, gatewayConfiguration),
 		},
 	}
-	return &httpRoute, nil
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
@@ -50,14 +51,14 @@ func TestGenerator(t *testing.T) {
 		Authentication: &[]types.AuthConfiguration{
 			{
 				AuthType: "APIKey",
-				Enabled:  true,
+				Enabled:  ptr.To(true),
 			},
 		},
 		Operations: &[]types.Operation{
-			{Target: "/employees", Verb: "GET", Secured: true, Scopes: []string{}},
-			{Target: "/employee", Verb: "POST", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: true, Scopes: []string{}},
-			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: true, Scopes: []string{}},
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee", Verb: "POST", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: ptr.To(true), Scopes: []string{}},
+			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: ptr.To(true), Scopes: []string{}},
 		},
 	}
 	organization := types.Organization{
@@ -72,15 +73,63 @@ func TestGenerator(t *testing.T) {
 	gen := Generator()
 
 	// Get the endpoint to use
-	endpoints := utils.GetEndpoints(apkConf)
+	uniqueId := "unique-id"
+	endpoints := utils.GetEndpoints(apkConf, uniqueId)
 	// If endpoints has production type
 	if endpoint, ok := endpoints[constants.PRODUCTION_TYPE]; ok {
-		httpRoute, err := gen.GenerateHTTPRoute(apkConf, organization, gatewayConfig, *apkConf.Operations, &endpoint, constants.PRODUCTION_TYPE, "unique-id", 1)
-		if err != nil {
-			fmt.Println(err)
+		httpRoute, diags := gen.GenerateHTTPRoute(apkConf, organization, gatewayConfig, *apkConf.Operations, &endpoint, constants.PRODUCTION_TYPE, uniqueId, 1)
+		if diags.HasErrors() {
+			fmt.Println(diags)
 		}
 
-		assert.Nil(t, err)
+		assert.False(t, diags.HasErrors())
 		assert.IsType(t, &gwapiv1.HTTPRoute{}, httpRoute)
 	}
 }
+
+func TestGenerateHTTPRoutes(t *testing.T) {
+	apkConf := types.APKConf{
+		Name:     "EmployeeServiceAPI",
+		Version:  "3.14",
+		BasePath: "/employees-info",
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-service:8080"),
+			},
+		},
+	}
+	var operations []types.Operation
+	for i := 0; i < 20; i++ {
+		operations = append(operations, types.Operation{Target: fmt.Sprintf("/employees/%d", i), Verb: "GET"})
+	}
+	operationEndpoint := &types.EndpointConfigurations{
+		Production: &types.EndpointConfiguration{
+			Endpoint: types.EndpointURL("http://employee-search:8080"),
+		},
+	}
+	operations = append(operations,
+		types.Operation{Target: "/search", Verb: "GET", EndpointConfigurations: operationEndpoint},
+		types.Operation{Target: "/search", Verb: "POST", EndpointConfigurations: operationEndpoint},
+	)
+	apkConf.Operations = &operations
+	uniqueId := "unique-id"
+	endpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.PRODUCTION_TYPE]
+
+	httpRoutes, diags := Generator().GenerateHTTPRoutes(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, operations, &endpoint, constants.PRODUCTION_TYPE, "unique-id")
+	assert.False(t, diags.HasErrors())
+	assert.Len(t, httpRoutes, 2)
+	assert.Equal(t, []string{"unique-id-production-httproute-1", "unique-id-production-httproute-2"}, utils.GetHTTPRouteNames(httpRoutes))
+	assert.Len(t, httpRoutes[0].Spec.Rules, 16)
+	// The operations of the same endpoint are kept in the same route
+	assert.Len(t, httpRoutes[1].Spec.Rules, 6)
+	searchRules := httpRoutes[1].Spec.Rules[4:]
+	assert.Equal(t, searchRules[0].BackendRefs[0].Name, searchRules[1].BackendRefs[0].Name)
+	assert.NotEqual(t, httpRoutes[0].Spec.Rules[0].BackendRefs[0].Name, searchRules[0].BackendRefs[0].Name)
+
+	// The errors of any rule are reported without generating routes
+	operations[21].Target = ""
+	operations[21].EndpointConfigurations = nil
+	operations = append(operations, types.Operation{Target: "/missing", Verb: "GET", EndpointConfigurations: &types.EndpointConfigurations{}})
+	_, diags = Generator().GenerateHTTPRoutes(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, operations, nil, constants.PRODUCTION_TYPE, "unique-id")
+	assert.True(t, diags.HasErrors())
+}
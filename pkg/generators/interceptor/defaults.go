@@ -0,0 +1,74 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package interceptor_generator
+
+import (
+	"fmt"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// generateInterceptorService generates the InterceptorService referring to the Backend of the interceptor URL.
+func (g *interceptorGenerator) generateInterceptorService(interceptor types.InterceptorService, uniqueId string, name string, isRequest bool) (*crds.InterceptorService, error) {
+	if utils.GetHost(types.EndpointURL(interceptor.BackendURL)) == "" {
+		return nil, fmt.Errorf("invalid interceptor backend url: %q", interceptor.BackendURL)
+	}
+	interceptorService := crds.InterceptorService{
+		TypeMeta: v1.TypeMeta{
+			Kind:       constants.INTERCEPTOR_SERVICE_KIND,
+			APIVersion: constants.DP_V1ALPHA1,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: name,
+		},
+		Spec: crds.InterceptorServiceSpec{
+			BackendRef: crds.BackendReference{
+				Name: utils.GetBackendName(uniqueId, utils.GetInterceptorEndpointConfiguration(interceptor)),
+			},
+			Includes: g.GenerateIncludes(interceptor, isRequest),
+		},
+	}
+	return &interceptorService, nil
+}
+
+// generateIncludes generates the parts of the request or response flow sent to the interceptor.
+func (g *interceptorGenerator) generateIncludes(interceptor types.InterceptorService, isRequest bool) []string {
+	flow := "response"
+	if isRequest {
+		flow = "request"
+	}
+	var includes []string
+	if interceptor.HeadersEnabled {
+		includes = append(includes, flow+"_headers")
+	}
+	if interceptor.BodyEnabled {
+		includes = append(includes, flow+"_body")
+	}
+	if interceptor.TrailersEnabled {
+		includes = append(includes, flow+"_trailers")
+	}
+	if interceptor.ContextEnabled {
+		includes = append(includes, "invocation_context")
+	}
+	return includes
+}
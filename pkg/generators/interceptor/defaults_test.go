@@ -0,0 +1,77 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package interceptor_generator
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+)
+
+func TestGenerateInterceptorService(t *testing.T) {
+	g := Generator()
+	interceptor := types.InterceptorService{BackendURL: "http://interceptor-service:8080", TrailersEnabled: true}
+
+	interceptorService, err := g.GenerateInterceptorService(interceptor, "unique-id", "interceptor", true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if interceptorService.Name != "interceptor" {
+		t.Errorf("Expected name interceptor, got %s", interceptorService.Name)
+	}
+	if interceptorService.Spec.BackendRef.Name != utils.GetBackendName("unique-id", utils.GetInterceptorEndpointConfiguration(interceptor)) {
+		t.Errorf("Expected backend %s, got %s", utils.GetBackendName("unique-id", utils.GetInterceptorEndpointConfiguration(interceptor)), interceptorService.Spec.BackendRef.Name)
+	}
+
+	if _, err := g.GenerateInterceptorService(types.InterceptorService{BackendURL: "interceptor-service"}, "unique-id", "interceptor", true); err == nil {
+		t.Errorf("Expected an error for an invalid backend url, got nil")
+	}
+}
+
+func TestGenerateIncludes(t *testing.T) {
+	g := Generator()
+	interceptor := types.InterceptorService{HeadersEnabled: true, BodyEnabled: true, TrailersEnabled: true, ContextEnabled: true}
+
+	tests := []struct {
+		name      string
+		isRequest bool
+		expected  []string
+	}{
+		{"Request flow", true, []string{"request_headers", "request_body", "request_trailers", "invocation_context"}},
+		{"Response flow", false, []string{"response_headers", "response_body", "response_trailers", "invocation_context"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			includes := g.GenerateIncludes(interceptor, tt.isRequest)
+			if len(includes) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, includes)
+			}
+			for i := range includes {
+				if includes[i] != tt.expected[i] {
+					t.Errorf("Expected %s, got %s", tt.expected[i], includes[i])
+				}
+			}
+		})
+	}
+
+	if includes := g.GenerateIncludes(types.InterceptorService{}, true); len(includes) != 0 {
+		t.Errorf("Expected no includes, got %v", includes)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package interceptor_generator
+
+import (
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+)
+
+// interceptorGenerator is the interface for the InterceptorService generator.
+type interceptorGenerator struct {
+	GenerateInterceptorService func(interceptor types.InterceptorService, uniqueId string, name string, isRequest bool) (*crds.InterceptorService, error)
+	GenerateIncludes           func(interceptor types.InterceptorService, isRequest bool) []string
+}
+
+// Generator creates a new InterceptorService generator.
+func Generator() *interceptorGenerator {
+	gen := &interceptorGenerator{}
+	gen.GenerateInterceptorService = gen.generateInterceptorService
+	gen.GenerateIncludes = gen.generateIncludes
+	return gen
+}
+
+// GenerateInterceptorServices generates an InterceptorService for each interceptor policy of the API and its operations,
+// leaving out the operation policies when API level policies are configured.
+// The Backends of the interceptors are generated by the Backend generator.
+func (g *interceptorGenerator) GenerateInterceptorServices(apkConf types.APKConf, uniqueId string) ([]*crds.InterceptorService, error) {
+	var interceptorServices []*crds.InterceptorService
+	generatedInterceptors := make(map[string]bool)
+	for _, policies := range utils.GetAppliedPolicies(apkConf) {
+		if policies == nil {
+			continue
+		}
+		for _, isRequest := range []bool{true, false} {
+			flowPolicies := policies.Response
+			if isRequest {
+				flowPolicies = policies.Request
+			}
+			for _, interceptor := range utils.GetInterceptors(flowPolicies) {
+				name := utils.GetInterceptorServiceName(uniqueId, interceptor, isRequest)
+				if generatedInterceptors[name] {
+					continue
+				}
+				interceptorService, err := g.GenerateInterceptorService(interceptor, uniqueId, name, isRequest)
+				if err != nil {
+					return nil, err
+				}
+				generatedInterceptors[name] = true
+				interceptorServices = append(interceptorServices, interceptorService)
+			}
+		}
+	}
+	return interceptorServices, nil
+}
@@ -0,0 +1,85 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package interceptor_generator
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+// TestGenerator test for Generator
+func TestGenerator(t *testing.T) {
+	requestInterceptor := types.InterceptorService{BackendURL: "https://interceptor-service:8443", HeadersEnabled: true, BodyEnabled: true}
+	responseInterceptor := types.InterceptorService{BackendURL: "https://interceptor-service:8443", HeadersEnabled: true, ContextEnabled: true}
+	apkConf := types.APKConf{
+		Name:     "EmployeeServiceAPI",
+		Version:  "3.14",
+		BasePath: "/employees-info",
+		Type:     "REST",
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-service:8080"),
+			},
+		},
+		APIPolicies: &types.OperationPolicies{
+			Request: []types.OperationPolicy{
+				{PolicyName: "Interceptor", Parameters: requestInterceptor},
+			},
+		},
+		Operations: &[]types.Operation{
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true)},
+			{
+				Target:  "/employee",
+				Verb:    "POST",
+				Secured: ptr.To(true),
+				OperationPolicies: &types.OperationPolicies{
+					Request: []types.OperationPolicy{
+						{PolicyName: "Interceptor", Parameters: requestInterceptor},
+					},
+					Response: []types.OperationPolicy{
+						{PolicyName: "Interceptor", Parameters: responseInterceptor},
+					},
+				},
+			},
+		},
+	}
+	uniqueId := "unique-id"
+
+	gen := Generator()
+
+	// Operation policies are ignored as API level policies are configured
+	interceptorServices, err := gen.GenerateInterceptorServices(apkConf, uniqueId)
+	assert.Nil(t, err)
+	assert.Len(t, interceptorServices, 1)
+	assert.Equal(t, utils.GetInterceptorServiceName(uniqueId, requestInterceptor, true), interceptorServices[0].Name)
+	assert.Equal(t, []string{"request_headers", "request_body"}, interceptorServices[0].Spec.Includes)
+
+	apkConf.APIPolicies = nil
+	interceptorServices, err = gen.GenerateInterceptorServices(apkConf, uniqueId)
+	assert.Nil(t, err)
+	assert.Len(t, interceptorServices, 2)
+	assert.Equal(t, utils.GetInterceptorServiceName(uniqueId, requestInterceptor, true), interceptorServices[0].Name)
+	assert.Equal(t, utils.GetInterceptorServiceName(uniqueId, responseInterceptor, false), interceptorServices[1].Name)
+	assert.Equal(t, []string{"response_headers", "invocation_context"}, interceptorServices[1].Spec.Includes)
+	assert.Equal(t, utils.GetBackendName(uniqueId, utils.GetInterceptorEndpointConfiguration(responseInterceptor)), interceptorServices[1].Spec.BackendRef.Name)
+}
@@ -0,0 +1,97 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package ratelimit_generator
+
+import (
+	"fmt"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// generateAPIRateLimitPolicy generates the RateLimitPolicy targeting the API based on the provided configurations.
+func (g *rateLimitGenerator) generateAPIRateLimitPolicy(apkConf types.APKConf, uniqueId string) (*crds.RateLimitPolicy, error) {
+	if apkConf.RateLimit == nil {
+		return nil, nil
+	}
+	rateLimitData, err := g.GenerateRateLimitData(*apkConf.RateLimit)
+	if err != nil {
+		return nil, err
+	}
+	rateLimitPolicy := crds.RateLimitPolicy{
+		TypeMeta: v1.TypeMeta{
+			Kind:       constants.RATELIMIT_POLICY_KIND,
+			APIVersion: constants.DP_V1ALPHA3,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: uniqueId + "-ratelimit",
+		},
+		Spec: crds.RateLimitPolicySpec{
+			Default:   rateLimitData,
+			TargetRef: utils.GetAPITargetRef(uniqueId),
+		},
+	}
+	return &rateLimitPolicy, nil
+}
+
+// generateOperationRateLimitPolicy generates the resource level RateLimitPolicy of the operation,
+// applied to the route rules of the operation referring it through ExtensionRef filters.
+func (g *rateLimitGenerator) generateOperationRateLimitPolicy(operation types.Operation, uniqueId string) (*crds.RateLimitPolicy, error) {
+	if operation.RateLimit == nil {
+		return nil, fmt.Errorf("no rate limit specified for operation %s %s", operation.Verb, operation.Target)
+	}
+	rateLimitData, err := g.GenerateRateLimitData(*operation.RateLimit)
+	if err != nil {
+		return nil, err
+	}
+	rateLimitPolicy := crds.RateLimitPolicy{
+		TypeMeta: v1.TypeMeta{
+			Kind:       constants.RATELIMIT_POLICY_KIND,
+			APIVersion: constants.DP_V1ALPHA3,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: utils.GetOperationPolicyName(uniqueId, operation, "ratelimit"),
+		},
+		Spec: crds.RateLimitPolicySpec{
+			Default:   rateLimitData,
+			TargetRef: utils.GetResourceTargetRef(uniqueId),
+		},
+	}
+	return &rateLimitPolicy, nil
+}
+
+// generateRateLimitData generates the rate limit configuration after validating the requests count and time unit.
+func (g *rateLimitGenerator) generateRateLimitData(rateLimit types.RateLimit) (*crds.RateLimitAPIPolicy, error) {
+	if rateLimit.RequestsPerUnit <= 0 {
+		return nil, fmt.Errorf("invalid rate limit requests per unit: %d", rateLimit.RequestsPerUnit)
+	}
+	unit, ok := utils.GetRateLimitUnit(rateLimit.Unit)
+	if !ok {
+		return nil, fmt.Errorf("invalid rate limit unit: %q", rateLimit.Unit)
+	}
+	return &crds.RateLimitAPIPolicy{
+		API: &crds.APIRateLimitPolicy{
+			RequestsPerUnit: uint32(rateLimit.RequestsPerUnit),
+			Unit:            unit,
+		},
+	}, nil
+}
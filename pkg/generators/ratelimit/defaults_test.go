@@ -0,0 +1,78 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package ratelimit_generator
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateAPIRateLimitPolicy(t *testing.T) {
+	g := Generator()
+
+	rateLimitPolicy, err := g.GenerateAPIRateLimitPolicy(types.APKConf{}, "unique-id")
+	assert.Nil(t, err)
+	assert.Nil(t, rateLimitPolicy)
+
+	apkConf := types.APKConf{RateLimit: &types.RateLimit{RequestsPerUnit: 5, Unit: "Minute"}}
+	rateLimitPolicy, err = g.GenerateAPIRateLimitPolicy(apkConf, "unique-id")
+	assert.Nil(t, err)
+	assert.Equal(t, "unique-id-ratelimit", rateLimitPolicy.Name)
+	assert.Equal(t, utils.GetAPITargetRef("unique-id"), rateLimitPolicy.Spec.TargetRef)
+}
+
+func TestGenerateOperationRateLimitPolicy(t *testing.T) {
+	g := Generator()
+	operation := types.Operation{Target: "/employees", Verb: "GET", RateLimit: &types.RateLimit{RequestsPerUnit: 5, Unit: "Day"}}
+	rateLimitPolicy, err := g.GenerateOperationRateLimitPolicy(operation, "unique-id")
+	assert.Nil(t, err)
+	assert.Equal(t, "unique-id-"+utils.GetRuleName(operation)+"-ratelimit", rateLimitPolicy.Name)
+	assert.Equal(t, utils.GetResourceTargetRef("unique-id"), rateLimitPolicy.Spec.TargetRef)
+	assert.Equal(t, "Day", rateLimitPolicy.Spec.Default.API.Unit)
+}
+
+func TestGenerateRateLimitData(t *testing.T) {
+	tests := []struct {
+		name      string
+		rateLimit types.RateLimit
+		unit      string
+		hasError  bool
+	}{
+		{"Minute unit", types.RateLimit{RequestsPerUnit: 5, Unit: "Minute"}, "Minute", false},
+		{"Lower case unit", types.RateLimit{RequestsPerUnit: 5, Unit: "hour"}, "Hour", false},
+		{"Unsupported unit", types.RateLimit{RequestsPerUnit: 5, Unit: "Week"}, "", true},
+		{"Missing requests", types.RateLimit{Unit: "Day"}, "", true},
+	}
+
+	g := Generator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rateLimitData, err := g.GenerateRateLimitData(tt.rateLimit)
+			if tt.hasError {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.unit, rateLimitData.API.Unit)
+		})
+	}
+}
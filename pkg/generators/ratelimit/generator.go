@@ -0,0 +1,77 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package ratelimit_generator
+
+import (
+	"errors"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+)
+
+// rateLimitGenerator is the interface for the RateLimitPolicy generator.
+type rateLimitGenerator struct {
+	GenerateAPIRateLimitPolicy       func(apkConf types.APKConf, uniqueId string) (*crds.RateLimitPolicy, error)
+	GenerateOperationRateLimitPolicy func(operation types.Operation, uniqueId string) (*crds.RateLimitPolicy, error)
+	GenerateRateLimitData            func(rateLimit types.RateLimit) (*crds.RateLimitAPIPolicy, error)
+}
+
+// Generator creates a new RateLimitPolicy generator.
+func Generator() *rateLimitGenerator {
+	gen := &rateLimitGenerator{}
+	gen.GenerateAPIRateLimitPolicy = gen.generateAPIRateLimitPolicy
+	gen.GenerateOperationRateLimitPolicy = gen.generateOperationRateLimitPolicy
+	gen.GenerateRateLimitData = gen.generateRateLimitData
+	return gen
+}
+
+// GenerateRateLimitPolicies generates the API level RateLimitPolicy, or a RateLimitPolicy for each operation
+// with its own rate limit that is served by any of the route rules. API and operation level rate limits cannot be used together.
+func (g *rateLimitGenerator) GenerateRateLimitPolicies(apkConf types.APKConf, uniqueId string, routes []types.RouteReference) ([]*crds.RateLimitPolicy, error) {
+	var rateLimitPolicies []*crds.RateLimitPolicy
+	if apkConf.RateLimit != nil && apkConf.Operations != nil {
+		for _, operation := range *apkConf.Operations {
+			if operation.RateLimit != nil {
+				return nil, errors.New("api level and operation level rate limits cannot be used together")
+			}
+		}
+	}
+
+	apiRateLimitPolicy, err := g.GenerateAPIRateLimitPolicy(apkConf, uniqueId)
+	if err != nil {
+		return nil, err
+	}
+	if apiRateLimitPolicy != nil {
+		rateLimitPolicies = append(rateLimitPolicies, apiRateLimitPolicy)
+	}
+
+	if apkConf.Operations != nil {
+		for _, operation := range *apkConf.Operations {
+			if operation.RateLimit == nil || !utils.HasRouteRule(routes, operation) {
+				continue
+			}
+			rateLimitPolicy, err := g.GenerateOperationRateLimitPolicy(operation, uniqueId)
+			if err != nil {
+				return nil, err
+			}
+			rateLimitPolicies = append(rateLimitPolicies, rateLimitPolicy)
+		}
+	}
+	return rateLimitPolicies, nil
+}
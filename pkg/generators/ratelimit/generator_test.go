@@ -0,0 +1,87 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package ratelimit_generator
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	http_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/http"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+// TestGenerator test for Generator
+func TestGenerator(t *testing.T) {
+	apkConf := types.APKConf{
+		Name:     "EmployeeServiceAPI",
+		Version:  "3.14",
+		BasePath: "/employees-info",
+		Type:     "REST",
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-service:8080"),
+			},
+		},
+		Operations: &[]types.Operation{
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true)},
+			{Target: "/employee", Verb: "POST", Secured: ptr.To(true), RateLimit: &types.RateLimit{RequestsPerUnit: 10, Unit: "Hour"}},
+		},
+	}
+	organization := types.Organization{
+		Name: "wso2",
+	}
+	gatewayConfig := types.GatewayConfigurations{
+		Name:         "wso2-apim",
+		ListenerName: "wso2-apim-gateway",
+		Hostname:     "wso2-apim",
+	}
+	uniqueId := "unique-id"
+
+	endpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.PRODUCTION_TYPE]
+	httpRoute, diags := http_generator.Generator().GenerateHTTPRoute(apkConf, organization, gatewayConfig, *apkConf.Operations, &endpoint, constants.PRODUCTION_TYPE, uniqueId, 1)
+	assert.False(t, diags.HasErrors())
+	routes := []types.RouteReference{utils.GetHTTPRouteReference(httpRoute)}
+
+	gen := Generator()
+
+	rateLimitPolicies, err := gen.GenerateRateLimitPolicies(apkConf, uniqueId, routes)
+	assert.Nil(t, err)
+	assert.Len(t, rateLimitPolicies, 1)
+	assert.Equal(t, utils.GetResourceTargetRef(uniqueId), rateLimitPolicies[0].Spec.TargetRef)
+	assert.Equal(t, uint32(10), rateLimitPolicies[0].Spec.Default.API.RequestsPerUnit)
+	extensionRef := httpRoute.Spec.Rules[1].Filters[len(httpRoute.Spec.Rules[1].Filters)-1].ExtensionRef
+	assert.Equal(t, "RateLimitPolicy", string(extensionRef.Kind))
+	assert.Equal(t, rateLimitPolicies[0].Name, string(extensionRef.Name))
+
+	// API and operation level rate limits cannot be mixed
+	apkConf.RateLimit = &types.RateLimit{RequestsPerUnit: 5, Unit: "Minute"}
+	_, err = gen.GenerateRateLimitPolicies(apkConf, uniqueId, routes)
+	assert.NotNil(t, err)
+
+	// API level rate limit
+	(*apkConf.Operations)[1].RateLimit = nil
+	rateLimitPolicies, err = gen.GenerateRateLimitPolicies(apkConf, uniqueId, routes)
+	assert.Nil(t, err)
+	assert.Len(t, rateLimitPolicies, 1)
+	assert.Equal(t, utils.GetAPITargetRef(uniqueId), rateLimitPolicies[0].Spec.TargetRef)
+}
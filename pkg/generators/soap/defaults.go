@@ -0,0 +1,211 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package soap_generator
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// generateSOAPRouteRules generates a list of HTTPRouteRules of the SOAP operations based on the provided configurations.
+func (g *soapRouteGenerator) generateSOAPRouteRules(apkConf types.APKConf, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) ([]gwapiv1.HTTPRouteRule, diagnostics.Diagnostics) {
+	var httpRouteRules []gwapiv1.HTTPRouteRule
+	var diags diagnostics.Diagnostics
+	for _, operation := range operations {
+		httpRouteRule, ruleDiags := g.GenerateSOAPRouteRule(apkConf, operation, endpoint, endpointType, uniqueId)
+		diags = append(diags, ruleDiags...)
+		if httpRouteRule != nil {
+			httpRouteRules = append(httpRouteRules, *httpRouteRule)
+		}
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return httpRouteRules, diags
+}
+
+// generateSOAPRouteRule generates the HTTP route rule of the SOAP operation. In the pass-through mode the SOAP requests
+// to the base path are matched by their SOAPAction and forwarded as they are, while in the SOAP-to-REST mode the REST
+// requests are forwarded to the SOAP endpoint with the SOAPAction of the operation set.
+func (g *soapRouteGenerator) generateSOAPRouteRule(apkConf types.APKConf, operation types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) (*gwapiv1.HTTPRouteRule, diagnostics.Diagnostics) {
+	var diags diagnostics.Diagnostics
+	operationPath := utils.GetOperationPath(apkConf, operation)
+	soapAction, err := g.GetSOAPAction(operation)
+	if err != nil {
+		diags = append(diags, diagnostics.FromError(diagnostics.JoinPath(operationPath, "target"), err)...)
+		return nil, diags
+	}
+	if !g.EnableSOAPToREST && operation.Verb != "POST" {
+		diags.Warnf(diagnostics.JoinPath(operationPath, "verb"), "SOAP requests are matched as POST requests, the %s verb is ignored", operation.Verb)
+	}
+	httpRouteRule, ruleDiags := g.GenerateHTTPRouteRule(apkConf, operation, endpoint, endpointType, uniqueId)
+	diags = append(diags, ruleDiags...)
+	if httpRouteRule == nil {
+		return nil, diags
+	}
+	// All operations are served at the endpoint URL of the SOAP service.
+	rewritePath := "\\1"
+	if g.EnableSOAPToREST {
+		rewritePath = "/"
+	}
+	for _, filter := range httpRouteRule.Filters {
+		if filter.URLRewrite != nil && filter.URLRewrite.Path != nil {
+			filter.URLRewrite.Path.ReplaceFullPath = &rewritePath
+		}
+	}
+	if g.EnableSOAPToREST && soapAction != "" {
+		httpRouteRule.Filters = setSOAPAction(httpRouteRule.Filters, soapAction)
+	}
+	return httpRouteRule, diags
+}
+
+// retrieveSOAPMatches retrieves the HTTPRouteMatches of the requests of the operation based on the provided configurations.
+// In the pass-through mode, SOAP 1.2 requests carrying the action in the Content-Type header are matched along with the
+// SOAP 1.1 requests carrying the SOAPAction header.
+func (g *soapRouteGenerator) retrieveSOAPMatches(apkConf types.APKConf, operation types.Operation) []gwapiv1.HTTPRouteMatch {
+	var httpRouteMatches []gwapiv1.HTTPRouteMatch
+	basePaths := []string{utils.RetrieveFullBasePath(apkConf.BasePath, apkConf.Version)}
+	if apkConf.DefaultVersion {
+		defaultBasePath := utils.RetrieveBasePathWithoutVersion(apkConf.BasePath, apkConf.Version)
+		if defaultBasePath != basePaths[0] {
+			basePaths = append(basePaths, defaultBasePath)
+		}
+	}
+	// The errors of the SOAPAction are reported when generating the rule.
+	soapAction, _ := g.GetSOAPAction(operation)
+	for _, basePath := range basePaths {
+		httpRouteMatch := g.RetrieveSOAPMatch(apkConf, operation, basePath)
+		httpRouteMatches = append(httpRouteMatches, httpRouteMatch)
+		if g.EnableSOAPToREST || soapAction == "" {
+			continue
+		}
+		// The header regex matches the whole value, with the action as any of the media type parameters.
+		headerType := gwapiv1.HeaderMatchRegularExpression
+		soap12Match := httpRouteMatch
+		soap12Match.Headers = []gwapiv1.HTTPHeaderMatch{
+			{
+				Type:  &headerType,
+				Name:  "Content-Type",
+				Value: `^[^;]*(;[^;]*)*;\s*action="?` + regexp.QuoteMeta(soapAction) + `"?\s*(;.*)?$`,
+			},
+		}
+		httpRouteMatches = append(httpRouteMatches, soap12Match)
+	}
+	return httpRouteMatches
+}
+
+// retrieveSOAPMatch retrieves the HTTPRouteMatch of the requests of the operation for the given base path.
+func (g *soapRouteGenerator) retrieveSOAPMatch(apkConf types.APKConf, operation types.Operation, basePath string) gwapiv1.HTTPRouteMatch {
+	pathType := gwapiv1.PathMatchRegularExpression
+	if g.EnableSOAPToREST {
+		method := gwapiv1.HTTPMethod(operation.Verb)
+		operationTarget := "/*"
+		if operation.Target != "" {
+			operationTarget = operation.Target
+		}
+		pathValue := utils.RetrievePathPrefix(operationTarget, basePath)
+		return gwapiv1.HTTPRouteMatch{
+			Method: &method,
+			Path: &gwapiv1.HTTPPathMatch{
+				Type:  &pathType,
+				Value: &pathValue,
+			},
+		}
+	}
+	method := gwapiv1.HTTPMethodPost
+	pathValue := utils.RetrievePathPrefix("/*", basePath)
+	httpRouteMatch := gwapiv1.HTTPRouteMatch{
+		Method: &method,
+		Path: &gwapiv1.HTTPPathMatch{
+			Type:  &pathType,
+			Value: &pathValue,
+		},
+	}
+	// The errors of the SOAPAction are reported when generating the rule.
+	if soapAction, _ := g.GetSOAPAction(operation); soapAction != "" {
+		// SOAP 1.1 clients send the SOAPAction as a quoted string.
+		headerType := gwapiv1.HeaderMatchRegularExpression
+		httpRouteMatch.Headers = []gwapiv1.HTTPHeaderMatch{
+			{
+				Type:  &headerType,
+				Name:  "SOAPAction",
+				Value: `^"?` + regexp.QuoteMeta(soapAction) + `"?$`,
+			},
+		}
+	}
+	return httpRouteMatch
+}
+
+// getSOAPAction returns the SOAPAction of the WSDL operation named by the last segment of the operation target,
+// defaulting to the operation name when no WSDL operations are given. The SOAPAction is empty for the /* target
+// matching all the SOAP requests.
+func (g *soapRouteGenerator) getSOAPAction(operation types.Operation) (string, error) {
+	name := path.Base(operation.Target)
+	if operation.Target == "" || name == "*" || name == "/" {
+		return "", nil
+	}
+	if len(g.WSDLOperations) == 0 {
+		return name, nil
+	}
+	for _, wsdlOperation := range g.WSDLOperations {
+		if wsdlOperation.Name == name {
+			if wsdlOperation.SOAPAction == "" {
+				return name, nil
+			}
+			return wsdlOperation.SOAPAction, nil
+		}
+	}
+	return "", fmt.Errorf("operation %q is not defined in the WSDL", name)
+}
+
+// setSOAPAction sets the SOAPAction header on the request header modifier of the filters,
+// adding the request header modifier when the filters do not have one.
+func setSOAPAction(filters []gwapiv1.HTTPRouteFilter, soapAction string) []gwapiv1.HTTPRouteFilter {
+	soapActionHeader := gwapiv1.HTTPHeader{Name: "SOAPAction", Value: `"` + soapAction + `"`}
+	for _, filter := range filters {
+		if filter.RequestHeaderModifier != nil {
+			filter.RequestHeaderModifier.Set = append(filter.RequestHeaderModifier.Set, soapActionHeader)
+			return filters
+		}
+	}
+	return append([]gwapiv1.HTTPRouteFilter{
+		{
+			Type: gwapiv1.HTTPRouteFilterRequestHeaderModifier,
+			RequestHeaderModifier: &gwapiv1.HTTPHeaderFilter{
+				Set: []gwapiv1.HTTPHeader{soapActionHeader},
+			},
+		},
+	}, filters...)
+}
+
+// addInterceptor returns a copy of the policies with the interceptor added to the request and response flows.
+func addInterceptor(policies types.OperationPolicies, interceptor types.InterceptorService) *types.OperationPolicies {
+	interceptorPolicy := types.OperationPolicy{PolicyName: constants.POLICY_INTERCEPTOR, Parameters: interceptor}
+	policies.Request = append(slices.Clone(policies.Request), interceptorPolicy)
+	policies.Response = append(slices.Clone(policies.Response), interceptorPolicy)
+	return &policies
+}
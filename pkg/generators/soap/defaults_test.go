@@ -0,0 +1,80 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package soap_generator
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSOAPAction(t *testing.T) {
+	g := Generator()
+	tests := []struct {
+		name       string
+		target     string
+		soapAction string
+	}{
+		{"All SOAP requests", "/*", ""},
+		{"Operation name", "/GetEmployee", "GetEmployee"},
+		{"Nested operation name", "/employees/GetEmployee", "GetEmployee"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			soapAction, err := g.GetSOAPAction(types.Operation{Target: tt.target, Verb: "POST"})
+			assert.Nil(t, err)
+			assert.Equal(t, tt.soapAction, soapAction)
+		})
+	}
+
+	// The SOAPAction is looked up in the WSDL operations
+	g.WSDLOperations = []WSDLOperation{{Name: "GetEmployee", SOAPAction: "urn:GetEmployee"}, {Name: "AddEmployee"}}
+	soapAction, err := g.GetSOAPAction(types.Operation{Target: "/GetEmployee", Verb: "POST"})
+	assert.Nil(t, err)
+	assert.Equal(t, "urn:GetEmployee", soapAction)
+	soapAction, err = g.GetSOAPAction(types.Operation{Target: "/AddEmployee", Verb: "POST"})
+	assert.Nil(t, err)
+	assert.Equal(t, "AddEmployee", soapAction)
+	_, err = g.GetSOAPAction(types.Operation{Target: "/RemoveEmployee", Verb: "POST"})
+	assert.EqualError(t, err, `operation "RemoveEmployee" is not defined in the WSDL`)
+}
+
+func TestRetrieveSOAPMatch(t *testing.T) {
+	g := Generator()
+	operation := types.Operation{Target: "/*", Verb: "POST"}
+	apkConf := types.APKConf{
+		Name:     "EmployeeSOAPAPI",
+		Version:  "1.0",
+		BasePath: "/employees-soap",
+		Type:     constants.API_TYPE_SOAP,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-service:8080/EmployeeService"),
+			},
+		},
+		Operations: &[]types.Operation{operation},
+	}
+
+	// The /* target matches all the SOAP requests regardless of their SOAPAction
+	match := g.RetrieveSOAPMatch(apkConf, operation, "/employees-soap")
+	assert.Equal(t, "/employees-soap(.*)", *match.Path.Value)
+	assert.Empty(t, match.Headers)
+}
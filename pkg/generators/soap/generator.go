@@ -0,0 +1,148 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package soap_generator
+
+import (
+	"strconv"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	http_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/http"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// soapRouteGenerator is the interface for the SOAP route generator.
+type soapRouteGenerator struct {
+	GenerateSOAPRouteRules        func(apkConf types.APKConf, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) ([]gwapiv1.HTTPRouteRule, diagnostics.Diagnostics)
+	GenerateSOAPRouteRule         func(apkConf types.APKConf, operation types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) (*gwapiv1.HTTPRouteRule, diagnostics.Diagnostics)
+	GenerateHTTPRouteRule         func(apkConf types.APKConf, operation types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) (*gwapiv1.HTTPRouteRule, diagnostics.Diagnostics)
+	GenerateAndRetrieveParentRefs func(gatewayConfig types.GatewayConfigurations, uniqueId string) []gwapiv1.ParentReference
+	GetHostNames                  func(apkConf types.APKConf, endpointType string, organization types.Organization, gatewayConfig types.GatewayConfigurations) []gwapiv1.Hostname
+	RetrieveSOAPMatches           func(apkConf types.APKConf, operation types.Operation) []gwapiv1.HTTPRouteMatch
+	RetrieveSOAPMatch             func(apkConf types.APKConf, operation types.Operation, basePath string) gwapiv1.HTTPRouteMatch
+	GetSOAPAction                 func(operation types.Operation) (string, error)
+
+	// WSDLOperations holds the operations of the WSDL of the API, e.g. parsed with ParseWSDL,
+	// to look up the SOAPAction of the operations by their name.
+	WSDLOperations []WSDLOperation
+	// EnableSOAPToREST exposes the operations as REST resources, setting the SOAPAction of the requests forwarded
+	// to the SOAP endpoint. The payloads are converted to and from SOAP envelopes by the interceptor added to
+	// the API with AddSOAPToRESTInterceptor.
+	EnableSOAPToREST bool
+}
+
+// Generator creates a new SOAP route generator. The rules are generated by the HTTP route generator,
+// with the requests matched by RetrieveSOAPMatches.
+func Generator() *soapRouteGenerator {
+	gen := &soapRouteGenerator{}
+	httpGen := http_generator.Generator()
+	httpGen.RetrieveHTTPMatches = func(apkConf types.APKConf, operation types.Operation) ([]gwapiv1.HTTPRouteMatch, error) {
+		return gen.RetrieveSOAPMatches(apkConf, operation), nil
+	}
+	gen.GenerateSOAPRouteRules = gen.generateSOAPRouteRules
+	gen.GenerateSOAPRouteRule = gen.generateSOAPRouteRule
+	gen.GenerateHTTPRouteRule = httpGen.GenerateHTTPRouteRule
+	gen.GenerateAndRetrieveParentRefs = httpGen.GenerateAndRetrieveParentRefs
+	gen.GetHostNames = utils.GetHostNames
+	gen.RetrieveSOAPMatches = gen.retrieveSOAPMatches
+	gen.RetrieveSOAPMatch = gen.retrieveSOAPMatch
+	gen.GetSOAPAction = gen.getSOAPAction
+	return gen
+}
+
+// GenerateHTTPRoute generates a HTTPRoute routing the SOAP requests of the operations to their endpoints.
+// The HTTPRoute is nil when the diagnostics contain errors, while warnings are returned along with the HTTPRoute.
+func (g *soapRouteGenerator) GenerateHTTPRoute(apkConf types.APKConf, organization types.Organization, gatewayConfiguration types.GatewayConfigurations, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string, count int) (*gwapiv1.HTTPRoute, diagnostics.Diagnostics) {
+	httpRouteRules, diags := g.GenerateSOAPRouteRules(apkConf, operations, endpoint, endpointType, uniqueId)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return g.newHTTPRoute(apkConf, organization, gatewayConfiguration, httpRouteRules, endpointType, uniqueId, count), diags
+}
+
+// GenerateHTTPRoutes generates the HTTPRoutes of the SOAP operations, splitting the rules into as many HTTPRoutes
+// as needed to stay within the Gateway API limit of rules per route. Rules of the same backend are kept in the same
+// HTTPRoute when they fit, and the HTTPRoutes are named <uniqueId>-<endpointType>-httproute-<n>.
+func (g *soapRouteGenerator) GenerateHTTPRoutes(apkConf types.APKConf, organization types.Organization, gatewayConfiguration types.GatewayConfigurations, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) ([]*gwapiv1.HTTPRoute, diagnostics.Diagnostics) {
+	httpRouteRules, diags := g.GenerateSOAPRouteRules(apkConf, operations, endpoint, endpointType, uniqueId)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	var httpRoutes []*gwapiv1.HTTPRoute
+	ruleGroups := utils.SplitRules(httpRouteRules, func(rule gwapiv1.HTTPRouteRule) string {
+		if len(rule.BackendRefs) == 0 {
+			return ""
+		}
+		return string(rule.BackendRefs[0].Name)
+	})
+	for i, rules := range ruleGroups {
+		httpRoutes = append(httpRoutes, g.newHTTPRoute(apkConf, organization, gatewayConfiguration, rules, endpointType, uniqueId, i+1))
+	}
+	return httpRoutes, diags
+}
+
+// AddSOAPToRESTInterceptor returns a copy of the APK configuration with the given interceptor converting the payloads
+// of the requests and responses added to the API policies when configured, otherwise to the policies of each operation.
+// The InterceptorService, its Backend and the APIPolicies referring it are then generated by their generators.
+func (g *soapRouteGenerator) AddSOAPToRESTInterceptor(apkConf types.APKConf, interceptor types.InterceptorService) types.APKConf {
+	// The interceptor converts the payloads from the body, with the headers holding the content type.
+	interceptor.BodyEnabled = true
+	interceptor.HeadersEnabled = true
+	if apkConf.APIPolicies != nil {
+		apkConf.APIPolicies = addInterceptor(*apkConf.APIPolicies, interceptor)
+		return apkConf
+	}
+	if apkConf.Operations != nil {
+		operations := make([]types.Operation, len(*apkConf.Operations))
+		for i, operation := range *apkConf.Operations {
+			var policies types.OperationPolicies
+			if operation.OperationPolicies != nil {
+				policies = *operation.OperationPolicies
+			}
+			operation.OperationPolicies = addInterceptor(policies, interceptor)
+			operations[i] = operation
+		}
+		apkConf.Operations = &operations
+	}
+	return apkConf
+}
+
+// newHTTPRoute creates the HTTPRoute with the given rules.
+func (g *soapRouteGenerator) newHTTPRoute(apkConf types.APKConf, organization types.Organization, gatewayConfiguration types.GatewayConfigurations, httpRouteRules []gwapiv1.HTTPRouteRule, endpointType string, uniqueId string, count int) *gwapiv1.HTTPRoute {
+	return &gwapiv1.HTTPRoute{
+		TypeMeta: v1.TypeMeta{
+			Kind:       constants.HTTPROUTE_KIND,
+			APIVersion: constants.GATEWAY_V1,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: uniqueId + "-" + endpointType + "-httproute-" + strconv.Itoa(count),
+		},
+		Spec: gwapiv1.HTTPRouteSpec{
+			CommonRouteSpec: gwapiv1.CommonRouteSpec{
+				ParentRefs: g.GenerateAndRetrieveParentRefs(gatewayConfiguration, uniqueId),
+			},
+			Rules:     httpRouteRules,
+			Hostnames: g.GetHostNames(apkConf, endpointType, organization, gatewayConfiguration),
+		},
+	}
+}
@@ -0,0 +1,166 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package soap_generator
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// TestGenerator test for Generator
+func TestGenerator(t *testing.T) {
+	wsdlOperations, err := ParseWSDL([]byte(testWSDL))
+	assert.Nil(t, err)
+	operations := GetOperations(wsdlOperations)
+	apkConf := types.APKConf{
+		Name:     "EmployeeSOAPAPI",
+		Version:  "1.0",
+		BasePath: "/employees-soap",
+		Type:     constants.API_TYPE_SOAP,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-service:8080/EmployeeService"),
+			},
+		},
+		Operations: &operations,
+	}
+	organization := types.Organization{
+		Name: "wso2",
+	}
+	gatewayConfig := types.GatewayConfigurations{
+		Name:         "wso2-apim",
+		ListenerName: "wso2-apim-gateway",
+		Hostname:     "wso2-apim",
+	}
+
+	gen := Generator()
+	gen.WSDLOperations = wsdlOperations
+
+	uniqueId := "unique-id"
+	endpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.PRODUCTION_TYPE]
+	httpRoute, diags := gen.GenerateHTTPRoute(apkConf, organization, gatewayConfig, *apkConf.Operations, &endpoint, constants.PRODUCTION_TYPE, uniqueId, 1)
+	assert.False(t, diags.HasErrors())
+	assert.Empty(t, diags.Warnings())
+	assert.Equal(t, "unique-id-production-httproute-1", httpRoute.Name)
+	assert.Len(t, httpRoute.Spec.Rules, 2)
+	// The SOAP requests to the base path are routed by their SOAPAction
+	rule := httpRoute.Spec.Rules[1]
+	assert.Equal(t, utils.GetRuleName((*apkConf.Operations)[1]), string(*rule.Name))
+	assert.Equal(t, gwapiv1.HTTPMethodPost, *rule.Matches[0].Method)
+	assert.Equal(t, `/employees-soap/1\.0(.*)`, *rule.Matches[0].Path.Value)
+	assert.Equal(t, gwapiv1.HTTPHeaderName("SOAPAction"), rule.Matches[0].Headers[0].Name)
+	assert.Equal(t, `^"?http://employees\.example\.com/AddEmployee"?$`, rule.Matches[0].Headers[0].Value)
+	// SOAP 1.2 requests carry the action in the Content-Type header
+	assert.Len(t, rule.Matches, 2)
+	assert.Equal(t, *rule.Matches[0].Path.Value, *rule.Matches[1].Path.Value)
+	assert.Equal(t, gwapiv1.HTTPHeaderName("Content-Type"), rule.Matches[1].Headers[0].Name)
+	// The gateway matches the header regex against the whole value
+	soap12Match := regexp.MustCompile(`^(?:` + rule.Matches[1].Headers[0].Value + `)$`)
+	assert.True(t, soap12Match.MatchString(`application/soap+xml;charset=UTF-8;action="http://employees.example.com/AddEmployee"`))
+	assert.True(t, soap12Match.MatchString(`application/soap+xml; charset=utf-8; action="http://employees.example.com/AddEmployee"`))
+	assert.True(t, soap12Match.MatchString(`application/soap+xml; action=http://employees.example.com/AddEmployee; charset=UTF-8`))
+	assert.False(t, soap12Match.MatchString(`application/soap+xml;action="http://employees.example.com/AddEmployees"`))
+	assert.False(t, soap12Match.MatchString(`application/soap+xml; xaction="http://employees.example.com/AddEmployee"`))
+	assert.Equal(t, "\\1", *rule.Filters[0].URLRewrite.Path.ReplaceFullPath)
+}
+
+func TestGenerateSOAPToREST(t *testing.T) {
+	operations := []types.Operation{
+		{Target: "/GetEmployee", Verb: "GET", OperationPolicies: &types.OperationPolicies{
+			Request: []types.OperationPolicy{{PolicyName: "AddHeader", Parameters: types.Header{HeaderName: "X-Client", HeaderValue: "apk"}}},
+		}},
+		{Target: "/AddEmployee", Verb: "POST"},
+	}
+	gen := Generator()
+	gen.WSDLOperations = []WSDLOperation{{Name: "GetEmployee", SOAPAction: "urn:GetEmployee"}, {Name: "AddEmployee", SOAPAction: "urn:AddEmployee"}}
+	gen.EnableSOAPToREST = true
+	interceptor := types.InterceptorService{BackendURL: "http://soap-to-rest:8080"}
+	apkConf := types.APKConf{
+		Name:     "EmployeeSOAPAPI",
+		Version:  "1.0",
+		BasePath: "/employees-soap",
+		Type:     constants.API_TYPE_SOAP,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-service:8080/EmployeeService"),
+			},
+		},
+		Operations: &operations,
+	}
+	apkConf = gen.AddSOAPToRESTInterceptor(apkConf, interceptor)
+	// The operations of the given configuration are not modified
+	assert.Len(t, operations[0].OperationPolicies.Request, 1)
+	assert.Nil(t, operations[1].OperationPolicies)
+	assert.Len(t, (*apkConf.Operations)[0].OperationPolicies.Request, 2)
+	assert.Equal(t, []types.InterceptorService{{BackendURL: "http://soap-to-rest:8080", HeadersEnabled: true, BodyEnabled: true}}, utils.GetInterceptors((*apkConf.Operations)[1].OperationPolicies.Response))
+
+	uniqueId := "unique-id"
+	endpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.PRODUCTION_TYPE]
+	httpRoute, diags := gen.GenerateHTTPRoute(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, *apkConf.Operations, &endpoint, constants.PRODUCTION_TYPE, uniqueId, 1)
+	assert.False(t, diags.HasErrors())
+	// The REST resources are forwarded to the SOAP endpoint with the SOAPAction of the operation
+	rule := httpRoute.Spec.Rules[0]
+	assert.Equal(t, gwapiv1.HTTPMethodGet, *rule.Matches[0].Method)
+	assert.Equal(t, `/employees-soap/1\.0/GetEmployee`, *rule.Matches[0].Path.Value)
+	assert.Empty(t, rule.Matches[0].Headers)
+	assert.Equal(t, []gwapiv1.HTTPHeader{{Name: "X-Client", Value: "apk"}}, rule.Filters[0].RequestHeaderModifier.Add)
+	assert.Equal(t, []gwapiv1.HTTPHeader{{Name: "SOAPAction", Value: `"urn:GetEmployee"`}}, rule.Filters[0].RequestHeaderModifier.Set)
+	assert.Equal(t, "/", *rule.Filters[1].URLRewrite.Path.ReplaceFullPath)
+	assert.Equal(t, gwapiv1.HTTPRouteFilterRequestHeaderModifier, httpRoute.Spec.Rules[1].Filters[0].Type)
+}
+
+func TestGenerateHTTPRouteErrors(t *testing.T) {
+	operations := []types.Operation{
+		{Target: "/GetEmployee", Verb: "GET"},
+		{Target: "/RemoveEmployee", Verb: "POST"},
+	}
+	apkConf := types.APKConf{
+		Name:     "EmployeeSOAPAPI",
+		Version:  "1.0",
+		BasePath: "/employees-soap",
+		Type:     constants.API_TYPE_SOAP,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-service:8080/EmployeeService"),
+			},
+		},
+		Operations: &operations,
+	}
+	uniqueId := "unique-id"
+	endpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.PRODUCTION_TYPE]
+	gen := Generator()
+	gen.WSDLOperations = []WSDLOperation{{Name: "GetEmployee"}}
+
+	httpRoute, diags := gen.GenerateHTTPRoute(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, operations, &endpoint, constants.PRODUCTION_TYPE, uniqueId, 1)
+	assert.Nil(t, httpRoute)
+	var messages []string
+	for _, diagnostic := range diags {
+		messages = append(messages, diagnostic.Error())
+	}
+	assert.Equal(t, []string{
+		"warning: operations[0].verb: SOAP requests are matched as POST requests, the GET verb is ignored",
+		`error: operations[1].target: operation "RemoveEmployee" is not defined in the WSDL`,
+	}, messages)
+}
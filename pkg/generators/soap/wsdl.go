@@ -0,0 +1,85 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package soap_generator
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+)
+
+// WSDLOperation is an operation of the SOAP bindings of a WSDL.
+type WSDLOperation struct {
+	Name       string
+	SOAPAction string
+}
+
+// wsdlDefinitions holds the bindings of a WSDL 1.1 document.
+type wsdlDefinitions struct {
+	XMLName  xml.Name      `xml:"definitions"`
+	Bindings []wsdlBinding `xml:"binding"`
+}
+
+// wsdlBinding holds the operations of a WSDL binding.
+type wsdlBinding struct {
+	Operations []struct {
+		Name string `xml:"name,attr"`
+		// Operation is the soap:operation or soap12:operation of the binding operation.
+		Operation struct {
+			SOAPAction string `xml:"soapAction,attr"`
+		} `xml:"operation"`
+	} `xml:"operation"`
+}
+
+// ParseWSDL parses the operations of the SOAP 1.1 and 1.2 bindings of the given WSDL 1.1 document.
+// The operations of multiple bindings are merged by name, keeping the first SOAPAction specified.
+func ParseWSDL(definition []byte) ([]WSDLOperation, error) {
+	var definitions wsdlDefinitions
+	if err := xml.Unmarshal(definition, &definitions); err != nil {
+		return nil, fmt.Errorf("failed to parse the WSDL: %w", err)
+	}
+	var wsdlOperations []WSDLOperation
+	indexes := make(map[string]int)
+	for _, binding := range definitions.Bindings {
+		for _, operation := range binding.Operations {
+			if i, ok := indexes[operation.Name]; ok {
+				if wsdlOperations[i].SOAPAction == "" {
+					wsdlOperations[i].SOAPAction = operation.Operation.SOAPAction
+				}
+				continue
+			}
+			indexes[operation.Name] = len(wsdlOperations)
+			wsdlOperations = append(wsdlOperations, WSDLOperation{Name: operation.Name, SOAPAction: operation.Operation.SOAPAction})
+		}
+	}
+	if len(wsdlOperations) == 0 {
+		return nil, fmt.Errorf("no binding operations found in the WSDL")
+	}
+	return wsdlOperations, nil
+}
+
+// GetOperations returns the operations of the APK configuration of the WSDL operations, each a secured POST
+// request to /<operation name>.
+func GetOperations(wsdlOperations []WSDLOperation) []types.Operation {
+	var operations []types.Operation
+	for _, wsdlOperation := range wsdlOperations {
+		operations = append(operations, types.Operation{Target: "/" + wsdlOperation.Name, Verb: "POST"})
+	}
+	return operations
+}
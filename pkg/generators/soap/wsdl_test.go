@@ -0,0 +1,69 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package soap_generator
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testWSDL is a WSDL with SOAP 1.1 and SOAP 1.2 bindings of the employee service.
+const testWSDL = `<?xml version="1.0" encoding="UTF-8"?>
+<wsdl:definitions xmlns:wsdl="http://schemas.xmlsoap.org/wsdl/" xmlns:soap="http://schemas.xmlsoap.org/wsdl/soap/" xmlns:soap12="http://schemas.xmlsoap.org/wsdl/soap12/" targetNamespace="http://employees.example.com/">
+  <wsdl:binding name="EmployeeServiceSoap12" type="tns:EmployeeService">
+    <soap12:binding transport="http://schemas.xmlsoap.org/soap/http"/>
+    <wsdl:operation name="GetEmployee">
+      <soap12:operation soapAction="" style="document"/>
+    </wsdl:operation>
+    <wsdl:operation name="AddEmployee">
+      <soap12:operation soapAction="http://employees.example.com/AddEmployee" style="document"/>
+    </wsdl:operation>
+  </wsdl:binding>
+  <wsdl:binding name="EmployeeServiceSoap" type="tns:EmployeeService">
+    <soap:binding transport="http://schemas.xmlsoap.org/soap/http"/>
+    <wsdl:operation name="GetEmployee">
+      <soap:operation soapAction="http://employees.example.com/GetEmployee" style="document"/>
+    </wsdl:operation>
+  </wsdl:binding>
+</wsdl:definitions>`
+
+func TestParseWSDL(t *testing.T) {
+	wsdlOperations, err := ParseWSDL([]byte(testWSDL))
+	assert.Nil(t, err)
+	// The operations of the bindings are merged, keeping the first SOAPAction specified
+	assert.Equal(t, []WSDLOperation{
+		{Name: "GetEmployee", SOAPAction: "http://employees.example.com/GetEmployee"},
+		{Name: "AddEmployee", SOAPAction: "http://employees.example.com/AddEmployee"},
+	}, wsdlOperations)
+
+	_, err = ParseWSDL([]byte(`<description xmlns="http://www.w3.org/ns/wsdl"/>`))
+	assert.ErrorContains(t, err, "failed to parse the WSDL")
+	_, err = ParseWSDL([]byte(`<definitions/>`))
+	assert.EqualError(t, err, "no binding operations found in the WSDL")
+}
+
+func TestGetOperations(t *testing.T) {
+	operations := GetOperations([]WSDLOperation{{Name: "GetEmployee"}})
+	assert.Len(t, operations, 1)
+	assert.Equal(t, "/GetEmployee", operations[0].Target)
+	assert.Equal(t, "POST", operations[0].Verb)
+	assert.True(t, utils.IsOperationSecured(operations[0]))
+}
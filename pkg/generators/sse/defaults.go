@@ -0,0 +1,84 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package sse_generator
+
+import (
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// generateSSERouteRules generates a list of HTTPRouteRules of the event streams based on the provided configurations.
+func (g *sseRouteGenerator) generateSSERouteRules(apkConf types.APKConf, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) ([]gwapiv1.HTTPRouteRule, diagnostics.Diagnostics) {
+	var httpRouteRules []gwapiv1.HTTPRouteRule
+	var diags diagnostics.Diagnostics
+	for _, operation := range operations {
+		httpRouteRule, ruleDiags := g.GenerateSSERouteRule(apkConf, operation, endpoint, endpointType, uniqueId)
+		diags = append(diags, ruleDiags...)
+		if httpRouteRule != nil {
+			httpRouteRules = append(httpRouteRules, *httpRouteRule)
+		}
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return httpRouteRules, diags
+}
+
+// generateSSERouteRule generates the HTTP route rule of the operation event stream, without timeouts
+// as the connections are held open while the events are sent.
+func (g *sseRouteGenerator) generateSSERouteRule(apkConf types.APKConf, operation types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) (*gwapiv1.HTTPRouteRule, diagnostics.Diagnostics) {
+	httpRouteRule, diags := g.GenerateHTTPRouteRule(apkConf, operation, endpoint, endpointType, uniqueId)
+	if httpRouteRule == nil {
+		return nil, diags
+	}
+	httpRouteRule.Timeouts = g.GenerateSSETimeouts()
+	return httpRouteRule, diags
+}
+
+// retrieveSSEMatch retrieves the HTTPRouteMatch of the event stream requests of the operation for the given base path.
+func (g *sseRouteGenerator) retrieveSSEMatch(apkConf types.APKConf, operation types.Operation, basePath string) gwapiv1.HTTPRouteMatch {
+	// The events are subscribed with GET requests to the operation target.
+	method := gwapiv1.HTTPMethodGet
+	pathType := gwapiv1.PathMatchRegularExpression
+	operationTarget := "/*"
+	if operation.Target != "" {
+		operationTarget = operation.Target
+	}
+	pathValue := utils.RetrievePathPrefix(operationTarget, basePath)
+	return gwapiv1.HTTPRouteMatch{
+		Method: &method,
+		Path: &gwapiv1.HTTPPathMatch{
+			Type:  &pathType,
+			Value: &pathValue,
+		},
+	}
+}
+
+// generateSSETimeouts generates the timeouts of the event stream rules, disabling the request timeouts of the gateway.
+func (g *sseRouteGenerator) generateSSETimeouts() *gwapiv1.HTTPRouteTimeouts {
+	// The zero duration disables the timeouts.
+	timeout := gwapiv1.Duration("0s")
+	backendTimeout := gwapiv1.Duration("0s")
+	return &gwapiv1.HTTPRouteTimeouts{
+		Request:        &timeout,
+		BackendRequest: &backendTimeout,
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package sse_generator
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+
+	"github.com/stretchr/testify/assert"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestRetrieveSSEMatch(t *testing.T) {
+	g := Generator()
+	operation := types.Operation{Target: "/events", Verb: "SUBSCRIBE"}
+	apkConf := types.APKConf{
+		Name:     "EmployeeEventsAPI",
+		Version:  "1.0",
+		BasePath: "/employees-events",
+		Type:     constants.API_TYPE_SSE,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-events:8080"),
+			},
+		},
+		Operations: &[]types.Operation{operation},
+	}
+
+	match := g.RetrieveSSEMatch(apkConf, operation, "/employees-events")
+	assert.Equal(t, gwapiv1.HTTPMethodGet, *match.Method)
+	assert.Equal(t, gwapiv1.PathMatchRegularExpression, *match.Path.Type)
+	assert.Equal(t, "/employees-events/events", *match.Path.Value)
+}
+
+func TestGenerateSSERouteRule(t *testing.T) {
+	g := Generator()
+	// The timeouts can be overridden for gateways limiting the connection duration
+	timeout := gwapiv1.Duration("1h")
+	g.GenerateSSETimeouts = func() *gwapiv1.HTTPRouteTimeouts {
+		return &gwapiv1.HTTPRouteTimeouts{Request: &timeout}
+	}
+	operation := types.Operation{Target: "/events", Verb: "SUBSCRIBE"}
+	apkConf := types.APKConf{
+		Name:     "EmployeeEventsAPI",
+		Version:  "1.0",
+		BasePath: "/employees-events",
+		Type:     constants.API_TYPE_SSE,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-events:8080"),
+			},
+		},
+		Operations: &[]types.Operation{operation},
+	}
+	apkConf.DefaultVersion = true
+	endpoint := types.EndpointDetails{Name: "backend", URL: "http://employee-events:8080"}
+
+	uniqueId := "unique-id"
+	httpRouteRule, diags := g.GenerateSSERouteRule(apkConf, operation, &endpoint, "production", uniqueId)
+	assert.False(t, diags.HasErrors())
+	assert.Len(t, httpRouteRule.Matches, 2)
+	assert.Equal(t, "/employees-events/events", *httpRouteRule.Matches[1].Path.Value)
+	assert.Equal(t, timeout, *httpRouteRule.Timeouts.Request)
+	assert.Nil(t, httpRouteRule.Timeouts.BackendRequest)
+}
@@ -0,0 +1,113 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package sse_generator
+
+import (
+	"strconv"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	http_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/http"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// sseRouteGenerator is the interface for the Server-Sent Events route generator.
+type sseRouteGenerator struct {
+	GenerateSSERouteRules         func(apkConf types.APKConf, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) ([]gwapiv1.HTTPRouteRule, diagnostics.Diagnostics)
+	GenerateSSERouteRule          func(apkConf types.APKConf, operation types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) (*gwapiv1.HTTPRouteRule, diagnostics.Diagnostics)
+	GenerateHTTPRouteRule         func(apkConf types.APKConf, operation types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) (*gwapiv1.HTTPRouteRule, diagnostics.Diagnostics)
+	GenerateAndRetrieveParentRefs func(gatewayConfig types.GatewayConfigurations, uniqueId string) []gwapiv1.ParentReference
+	GetHostNames                  func(apkConf types.APKConf, endpointType string, organization types.Organization, gatewayConfig types.GatewayConfigurations) []gwapiv1.Hostname
+	RetrieveSSEMatch              func(apkConf types.APKConf, operation types.Operation, basePath string) gwapiv1.HTTPRouteMatch
+	GenerateSSETimeouts           func() *gwapiv1.HTTPRouteTimeouts
+}
+
+// Generator creates a new Server-Sent Events route generator. The rules are generated by the HTTP route generator,
+// with the event stream requests matched by RetrieveSSEMatch.
+func Generator() *sseRouteGenerator {
+	gen := &sseRouteGenerator{}
+	httpGen := http_generator.Generator()
+	httpGen.RetrieveHTTPMatch = func(apkConf types.APKConf, operation types.Operation, basePath string) (gwapiv1.HTTPRouteMatch, error) {
+		return gen.RetrieveSSEMatch(apkConf, operation, basePath), nil
+	}
+	gen.GenerateSSERouteRules = gen.generateSSERouteRules
+	gen.GenerateSSERouteRule = gen.generateSSERouteRule
+	gen.GenerateHTTPRouteRule = httpGen.GenerateHTTPRouteRule
+	gen.GenerateAndRetrieveParentRefs = httpGen.GenerateAndRetrieveParentRefs
+	gen.GetHostNames = utils.GetHostNames
+	gen.RetrieveSSEMatch = gen.retrieveSSEMatch
+	gen.GenerateSSETimeouts = gen.generateSSETimeouts
+	return gen
+}
+
+// GenerateHTTPRoute generates a HTTPRoute routing the event stream requests of the operations to their endpoints,
+// with the timeouts of the rules set for the long-lived connections.
+// The HTTPRoute is nil when the diagnostics contain errors, while warnings are returned along with the HTTPRoute.
+func (g *sseRouteGenerator) GenerateHTTPRoute(apkConf types.APKConf, organization types.Organization, gatewayConfiguration types.GatewayConfigurations, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string, count int) (*gwapiv1.HTTPRoute, diagnostics.Diagnostics) {
+	httpRouteRules, diags := g.GenerateSSERouteRules(apkConf, operations, endpoint, endpointType, uniqueId)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return g.newHTTPRoute(apkConf, organization, gatewayConfiguration, httpRouteRules, endpointType, uniqueId, count), diags
+}
+
+// GenerateHTTPRoutes generates the HTTPRoutes of the event streams, splitting the rules into as many HTTPRoutes
+// as needed to stay within the Gateway API limit of rules per route. Rules of the same backend are kept in the same
+// HTTPRoute when they fit, and the HTTPRoutes are named <uniqueId>-<endpointType>-httproute-<n>.
+func (g *sseRouteGenerator) GenerateHTTPRoutes(apkConf types.APKConf, organization types.Organization, gatewayConfiguration types.GatewayConfigurations, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) ([]*gwapiv1.HTTPRoute, diagnostics.Diagnostics) {
+	httpRouteRules, diags := g.GenerateSSERouteRules(apkConf, operations, endpoint, endpointType, uniqueId)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	var httpRoutes []*gwapiv1.HTTPRoute
+	ruleGroups := utils.SplitRules(httpRouteRules, func(rule gwapiv1.HTTPRouteRule) string {
+		if len(rule.BackendRefs) == 0 {
+			return ""
+		}
+		return string(rule.BackendRefs[0].Name)
+	})
+	for i, rules := range ruleGroups {
+		httpRoutes = append(httpRoutes, g.newHTTPRoute(apkConf, organization, gatewayConfiguration, rules, endpointType, uniqueId, i+1))
+	}
+	return httpRoutes, diags
+}
+
+// newHTTPRoute creates the HTTPRoute with the given rules.
+func (g *sseRouteGenerator) newHTTPRoute(apkConf types.APKConf, organization types.Organization, gatewayConfiguration types.GatewayConfigurations, httpRouteRules []gwapiv1.HTTPRouteRule, endpointType string, uniqueId string, count int) *gwapiv1.HTTPRoute {
+	return &gwapiv1.HTTPRoute{
+		TypeMeta: v1.TypeMeta{
+			Kind:       constants.HTTPROUTE_KIND,
+			APIVersion: constants.GATEWAY_V1,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: uniqueId + "-" + endpointType + "-httproute-" + strconv.Itoa(count),
+		},
+		Spec: gwapiv1.HTTPRouteSpec{
+			CommonRouteSpec: gwapiv1.CommonRouteSpec{
+				ParentRefs: g.GenerateAndRetrieveParentRefs(gatewayConfiguration, uniqueId),
+			},
+			Rules:     httpRouteRules,
+			Hostnames: g.GetHostNames(apkConf, endpointType, organization, gatewayConfiguration),
+		},
+	}
+}
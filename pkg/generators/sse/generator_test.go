@@ -0,0 +1,124 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package sse_generator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// TestGenerator test for Generator
+func TestGenerator(t *testing.T) {
+	apkConf := types.APKConf{
+		Name:     "EmployeeEventsAPI",
+		Version:  "1.0",
+		BasePath: "/employees-events",
+		Type:     constants.API_TYPE_SSE,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-events:8080"),
+			},
+		},
+		Operations: &[]types.Operation{
+			{Target: "/events", Verb: "SUBSCRIBE", Secured: ptr.To(true)},
+			{Target: "/events/{department}", Verb: "SUBSCRIBE", Secured: ptr.To(true)},
+		},
+	}
+	organization := types.Organization{
+		Name: "wso2",
+	}
+	gatewayConfig := types.GatewayConfigurations{
+		Name:         "wso2-apim",
+		ListenerName: "wso2-apim-gateway",
+		Hostname:     "wso2-apim",
+	}
+
+	gen := Generator()
+
+	uniqueId := "unique-id"
+	endpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.PRODUCTION_TYPE]
+	httpRoute, diags := gen.GenerateHTTPRoute(apkConf, organization, gatewayConfig, *apkConf.Operations, &endpoint, constants.PRODUCTION_TYPE, uniqueId, 1)
+	assert.False(t, diags.HasErrors())
+	assert.Equal(t, constants.HTTPROUTE_KIND, httpRoute.Kind)
+	assert.Equal(t, "unique-id-production-httproute-1", httpRoute.Name)
+	assert.Len(t, httpRoute.Spec.Rules, 2)
+	rule := httpRoute.Spec.Rules[1]
+	assert.Equal(t, utils.GetRuleName((*apkConf.Operations)[1]), string(*rule.Name))
+	// The event streams are subscribed with GET requests
+	assert.Equal(t, gwapiv1.HTTPMethodGet, *rule.Matches[0].Method)
+	assert.Equal(t, `/employees-events/1\.0/events/(.*)`, *rule.Matches[0].Path.Value)
+	assert.Equal(t, "/events/\\1", *rule.Filters[0].URLRewrite.Path.ReplaceFullPath)
+	assert.Equal(t, gwapiv1.Duration("0s"), *rule.Timeouts.Request)
+	assert.Equal(t, gwapiv1.Duration("0s"), *rule.Timeouts.BackendRequest)
+	assert.Equal(t, endpoint.Name, string(rule.BackendRefs[0].Name))
+}
+
+func TestGenerateHTTPRoutes(t *testing.T) {
+	var operations []types.Operation
+	for i := 0; i < 20; i++ {
+		operations = append(operations, types.Operation{Target: fmt.Sprintf("/events/%d", i), Verb: "SUBSCRIBE"})
+	}
+	apkConf := types.APKConf{
+		Name:     "EmployeeEventsAPI",
+		Version:  "1.0",
+		BasePath: "/employees-events",
+		Type:     constants.API_TYPE_SSE,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-events:8080"),
+			},
+		},
+		Operations: &operations,
+	}
+	uniqueId := "unique-id"
+	endpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.PRODUCTION_TYPE]
+
+	httpRoutes, diags := Generator().GenerateHTTPRoutes(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, operations, &endpoint, constants.PRODUCTION_TYPE, "unique-id")
+	assert.False(t, diags.HasErrors())
+	assert.Equal(t, []string{"unique-id-production-httproute-1", "unique-id-production-httproute-2"}, utils.GetHTTPRouteNames(httpRoutes))
+	assert.Len(t, httpRoutes[0].Spec.Rules, 16)
+	assert.Len(t, httpRoutes[1].Spec.Rules, 4)
+}
+
+func TestGenerateHTTPRouteErrors(t *testing.T) {
+	operations := []types.Operation{{Target: "/events", Verb: "SUBSCRIBE"}}
+	apkConf := types.APKConf{
+		Name:     "EmployeeEventsAPI",
+		Version:  "1.0",
+		BasePath: "/employees-events",
+		Type:     constants.API_TYPE_SSE,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://employee-events:8080"),
+			},
+		},
+		Operations: &operations,
+	}
+
+	httpRoute, diags := Generator().GenerateHTTPRoute(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, operations, nil, constants.PRODUCTION_TYPE, "unique-id", 1)
+	assert.Nil(t, httpRoute)
+	assert.EqualError(t, diags.Err(), "error: operations[0]: no production endpoint specified for the operation or the API")
+}
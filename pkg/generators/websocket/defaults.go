@@ -0,0 +1,228 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package websocket_generator
+
+import (
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// generateWSRouteRules generates a route rule for each channel of the operations. The operations of the same
+// channel, e.g. PUBLISH and SUBSCRIBE, share the upgrade request and hence the route rule of the first operation.
+func (g *wsRouteGenerator) generateWSRouteRules(apkConf types.APKConf, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) ([]gwapiv1.HTTPRouteRule, diagnostics.Diagnostics) {
+	var httpRouteRules []gwapiv1.HTTPRouteRule
+	var diags diagnostics.Diagnostics
+	channelOperations := make(map[string]types.Operation)
+	for _, operation := range operations {
+		channel := getChannel(operation)
+		if channelOperation, ok := channelOperations[channel]; ok {
+			if operation.EndpointConfigurations != nil || operation.OperationPolicies != nil || operation.RateLimit != nil || utils.IsOperationSecured(operation) != utils.IsOperationSecured(channelOperation) {
+				diags.Warnf(utils.GetOperationPath(apkConf, operation), "operation shares the route rule of the %s operation of channel %s, its own configurations are ignored", channelOperation.Verb, channel)
+			}
+			continue
+		}
+		channelOperations[channel] = operation
+		httpRouteRule, ruleDiags := g.GenerateWSRouteRule(apkConf, operation, endpoint, endpointType, uniqueId)
+		diags = append(diags, ruleDiags...)
+		if httpRouteRule != nil {
+			httpRouteRules = append(httpRouteRules, *httpRouteRule)
+		}
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return httpRouteRules, diags
+}
+
+// generateWSRouteRule generates the route rule of the upgrade requests of the operation channel.
+func (g *wsRouteGenerator) generateWSRouteRule(apkConf types.APKConf, operation types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) (*gwapiv1.HTTPRouteRule, diagnostics.Diagnostics) {
+	var diags diagnostics.Diagnostics
+	var endpointToUse *types.EndpointDetails = utils.GetEndpointToUse(operation.EndpointConfigurations, endpointType, uniqueId)
+	if endpointToUse == nil && endpoint != nil {
+		endpointToUse = endpoint
+	}
+	if endpointToUse == nil {
+		diags.Errorf(utils.GetOperationPath(apkConf, operation), "no %s endpoint specified for the operation or the API", endpointType)
+		return nil, diags
+	}
+	filters, filterDiags := g.GenerateWSRouteFilters(apkConf, *endpointToUse, operation)
+	diags = append(diags, filterDiags...)
+	if filterDiags.HasErrors() {
+		return nil, diags
+	}
+	filters = append(filters, utils.GetOperationExtensionRefFilters(apkConf, operation, uniqueId)...)
+	ruleName := gwapiv1.SectionName(utils.GetRuleName(operation))
+	return &gwapiv1.HTTPRouteRule{
+		Name:        &ruleName,
+		Matches:     g.RetrieveWSMatches(apkConf, operation),
+		Filters:     filters,
+		BackendRefs: g.GenerateWSBackEndRef(*endpointToUse),
+	}, diags
+}
+
+// generateWSRouteFilters generates the header modifiers of the policies applied to the upgrade requests and responses,
+// followed by the rewrite of the channel path to the endpoint path. Request mirroring and redirection are not supported
+// as a WebSocket connection is held with a single endpoint.
+func (g *wsRouteGenerator) generateWSRouteFilters(apkConf types.APKConf, endpointToUse types.EndpointDetails, operation types.Operation) ([]gwapiv1.HTTPRouteFilter, diagnostics.Diagnostics) {
+	routeFilters := make([]gwapiv1.HTTPRouteFilter, 0)
+	var diags diagnostics.Diagnostics
+	operationPoliciesPath := diagnostics.JoinPath(utils.GetOperationPath(apkConf, operation), "operationPolicies")
+	operationPoliciesToUse := operation.OperationPolicies
+	policiesPath := operationPoliciesPath
+	if utils.HasAPIPolicies(apkConf) {
+		if operation.OperationPolicies != nil {
+			diags.Warnf(operationPoliciesPath, "operation policies are ignored as API level policies are configured")
+		}
+		operationPoliciesToUse = apkConf.APIPolicies
+		policiesPath = "apiPolicies"
+	}
+
+	if operationPoliciesToUse != nil {
+		requestFilter, requestDiags := extractHeaderFilter(operationPoliciesToUse.Request, true)
+		diags = append(diags, requestDiags.WithPathPrefix(policiesPath)...)
+		responseFilter, responseDiags := extractHeaderFilter(operationPoliciesToUse.Response, false)
+		diags = append(diags, responseDiags.WithPathPrefix(policiesPath)...)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		if requestFilter != nil {
+			routeFilters = append(routeFilters, *requestFilter)
+		}
+		if responseFilter != nil {
+			routeFilters = append(routeFilters, *responseFilter)
+		}
+	}
+	generatedPath := utils.GeneratePrefixMatch(endpointToUse, operation)
+	routeFilters = append(routeFilters, gwapiv1.HTTPRouteFilter{
+		Type: gwapiv1.HTTPRouteFilterURLRewrite,
+		URLRewrite: &gwapiv1.HTTPURLRewriteFilter{
+			Path: &gwapiv1.HTTPPathModifier{
+				Type:            gwapiv1.FullPathHTTPPathModifier,
+				ReplaceFullPath: &generatedPath,
+			},
+		},
+	})
+	return routeFilters, diags
+}
+
+// extractHeaderFilter extracts the header modifier filter of the header policies, or nil when there are none.
+// The paths of the returned diagnostics are relative to the policies, e.g. response[0].
+func extractHeaderFilter(policies []types.OperationPolicy, isRequest bool) (*gwapiv1.HTTPRouteFilter, diagnostics.Diagnostics) {
+	var diags diagnostics.Diagnostics
+	var headerModifier gwapiv1.HTTPHeaderFilter
+	flow := "response"
+	if isRequest {
+		flow = "request"
+	}
+	for i, policy := range policies {
+		switch policy.PolicyName {
+		case constants.POLICY_REQUEST_MIRROR, constants.POLICY_REQUEST_REDIRECT:
+			diags.Errorf(diagnostics.Index(flow, i), "%s policy is not supported for WebSocket APIs", policy.PolicyName)
+			continue
+		case constants.POLICY_BACKEND_JWT:
+			if !isRequest {
+				diags.Errorf(diagnostics.Index(flow, i), "%s policy cannot be used as a response policy", policy.PolicyName)
+			}
+			continue
+		}
+		// Interceptor and BackendJwt request policies are applied through the APIPolicy of the route rule.
+		header, ok := policy.Parameters.(types.Header)
+		if !ok {
+			continue
+		}
+		switch policy.PolicyName {
+		case constants.POLICY_ADD_HEADER:
+			headerModifier.Add = append(headerModifier.Add, gwapiv1.HTTPHeader{Name: gwapiv1.HTTPHeaderName(header.HeaderName), Value: header.HeaderValue})
+		case constants.POLICY_SET_HEADER:
+			headerModifier.Set = append(headerModifier.Set, gwapiv1.HTTPHeader{Name: gwapiv1.HTTPHeaderName(header.HeaderName), Value: header.HeaderValue})
+		case constants.POLICY_REMOVE_HEADER:
+			headerModifier.Remove = append(headerModifier.Remove, header.HeaderName)
+		}
+	}
+	if diags.HasErrors() || (len(headerModifier.Add) == 0 && len(headerModifier.Set) == 0 && len(headerModifier.Remove) == 0) {
+		return nil, diags
+	}
+	if isRequest {
+		return &gwapiv1.HTTPRouteFilter{
+			Type:                  gwapiv1.HTTPRouteFilterRequestHeaderModifier,
+			RequestHeaderModifier: &headerModifier,
+		}, diags
+	}
+	return &gwapiv1.HTTPRouteFilter{
+		Type:                   gwapiv1.HTTPRouteFilterResponseHeaderModifier,
+		ResponseHeaderModifier: &headerModifier,
+	}, diags
+}
+
+// retrieveWSMatches retrieves the HTTPRouteMatches of the upgrade requests based on the provided configurations.
+func (g *wsRouteGenerator) retrieveWSMatches(apkConf types.APKConf, operation types.Operation) []gwapiv1.HTTPRouteMatch {
+	basePath := utils.RetrieveFullBasePath(apkConf.BasePath, apkConf.Version)
+	httpRouteMatches := []gwapiv1.HTTPRouteMatch{g.RetrieveWSMatch(apkConf, operation, basePath)}
+	if apkConf.DefaultVersion {
+		defaultBasePath := utils.RetrieveBasePathWithoutVersion(apkConf.BasePath, apkConf.Version)
+		if defaultBasePath != basePath {
+			httpRouteMatches = append(httpRouteMatches, g.RetrieveWSMatch(apkConf, operation, defaultBasePath))
+		}
+	}
+	return httpRouteMatches
+}
+
+// retrieveWSMatch retrieves the HTTPRouteMatch of the upgrade requests of the operation channel for the given base path.
+func (g *wsRouteGenerator) retrieveWSMatch(apkConf types.APKConf, operation types.Operation, basePath string) gwapiv1.HTTPRouteMatch {
+	method := gwapiv1.HTTPMethodGet
+	pathType := gwapiv1.PathMatchRegularExpression
+	pathValue := utils.RetrievePathPrefix(getChannel(operation), basePath)
+	// The Upgrade header value is case-insensitive
+	headerType := gwapiv1.HeaderMatchRegularExpression
+	return gwapiv1.HTTPRouteMatch{
+		Method: &method,
+		Path: &gwapiv1.HTTPPathMatch{
+			Type:  &pathType,
+			Value: &pathValue,
+		},
+		Headers: []gwapiv1.HTTPHeaderMatch{
+			{
+				Type:  &headerType,
+				Name:  "Upgrade",
+				Value: "(?i)^websocket$",
+			},
+		},
+	}
+}
+
+// generateWSBackEndRef generates the backend references of the route rule to the given endpoint.
+func (g *wsRouteGenerator) generateWSBackEndRef(endpoint types.EndpointDetails) []gwapiv1.HTTPBackendRef {
+	httpBackEndRef := gwapiv1.HTTPBackendRef{
+		BackendRef: gwapiv1.BackendRef{
+			BackendObjectReference: utils.GenerateBackendObjectReference(endpoint),
+		},
+	}
+	return []gwapiv1.HTTPBackendRef{httpBackEndRef}
+}
+
+// getChannel returns the channel of the operation, matching all the paths when no target is specified.
+func getChannel(operation types.Operation) string {
+	if operation.Target == "" {
+		return "/*"
+	}
+	return operation.Target
+}
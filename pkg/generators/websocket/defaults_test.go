@@ -0,0 +1,99 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package websocket_generator
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestRetrieveWSMatches(t *testing.T) {
+	g := Generator()
+	operation := types.Operation{Target: "/notifications", Verb: "SUBSCRIBE"}
+	apkConf := types.APKConf{
+		Name:     "EmployeeNotificationsAPI",
+		Version:  "1.0",
+		BasePath: "/employees-ws",
+		Type:     constants.API_TYPE_WS,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("ws://employee-notifications:8080"),
+			},
+		},
+		Operations: &[]types.Operation{operation},
+	}
+	apkConf.DefaultVersion = true
+
+	matches := g.RetrieveWSMatches(apkConf, operation)
+	assert.Len(t, matches, 2)
+	// The upgrade requests are matched on the channel path
+	assert.Equal(t, gwapiv1.HTTPMethodGet, *matches[0].Method)
+	assert.Equal(t, `/employees-ws/1\.0/notifications`, *matches[0].Path.Value)
+	assert.Equal(t, "/employees-ws/notifications", *matches[1].Path.Value)
+	assert.Equal(t, gwapiv1.HTTPHeaderName("Upgrade"), matches[0].Headers[0].Name)
+	assert.Equal(t, gwapiv1.HeaderMatchRegularExpression, *matches[0].Headers[0].Type)
+	// The Upgrade header value is matched regardless of its case
+	upgradeMatch := regexp.MustCompile(matches[0].Headers[0].Value)
+	assert.True(t, upgradeMatch.MatchString("websocket"))
+	assert.True(t, upgradeMatch.MatchString("WebSocket"))
+	assert.False(t, upgradeMatch.MatchString("h2c"))
+}
+
+func TestGenerateWSRouteFilters(t *testing.T) {
+	g := Generator()
+	operation := types.Operation{Target: "/notifications", Verb: "SUBSCRIBE", OperationPolicies: &types.OperationPolicies{
+		Request: []types.OperationPolicy{
+			{PolicyName: "AddHeader", Parameters: types.Header{HeaderName: "X-Channel", HeaderValue: "notifications"}},
+			{PolicyName: "RemoveHeader", Parameters: types.Header{HeaderName: "X-Internal"}},
+		},
+	}}
+	apkConf := types.APKConf{
+		Name:     "EmployeeNotificationsAPI",
+		Version:  "1.0",
+		BasePath: "/employees-ws",
+		Type:     constants.API_TYPE_WS,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("ws://employee-notifications:8080"),
+			},
+		},
+		Operations: &[]types.Operation{operation},
+	}
+	endpoint := utils.CreateEndpointDetails("unique-id", types.EndpointConfiguration{Endpoint: types.EndpointURL("ws://employee-notifications:8080/ws")})
+
+	filters, diags := g.GenerateWSRouteFilters(apkConf, endpoint, operation)
+	assert.False(t, diags.HasErrors())
+	assert.Len(t, filters, 2)
+	assert.Equal(t, gwapiv1.HTTPRouteFilterRequestHeaderModifier, filters[0].Type)
+	assert.Equal(t, []string{"X-Internal"}, filters[0].RequestHeaderModifier.Remove)
+	assert.Equal(t, gwapiv1.HTTPRouteFilterURLRewrite, filters[1].Type)
+	assert.Equal(t, "/notifications", *filters[1].URLRewrite.Path.ReplaceFullPath)
+
+	// Redirecting the upgrade requests is not supported
+	operation.OperationPolicies.Request = []types.OperationPolicy{{PolicyName: "RequestRedirect", Parameters: types.RedirectPolicy{URL: "https://example.com"}}}
+	filters, diags = g.GenerateWSRouteFilters(apkConf, endpoint, operation)
+	assert.Nil(t, filters)
+	assert.True(t, diags.HasErrors())
+}
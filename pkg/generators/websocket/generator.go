@@ -0,0 +1,110 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package websocket_generator
+
+import (
+	"strconv"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
+	http_generator "github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/generators/http"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// wsRouteGenerator is the interface for the WebSocket route generator.
+type wsRouteGenerator struct {
+	GenerateWSRouteRules          func(apkConf types.APKConf, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) ([]gwapiv1.HTTPRouteRule, diagnostics.Diagnostics)
+	GenerateWSRouteRule           func(apkConf types.APKConf, operation types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) (*gwapiv1.HTTPRouteRule, diagnostics.Diagnostics)
+	GenerateAndRetrieveParentRefs func(gatewayConfig types.GatewayConfigurations, uniqueId string) []gwapiv1.ParentReference
+	GenerateWSRouteFilters        func(apkConf types.APKConf, endpointToUse types.EndpointDetails, operation types.Operation) ([]gwapiv1.HTTPRouteFilter, diagnostics.Diagnostics)
+	GetHostNames                  func(apkConf types.APKConf, endpointType string, organization types.Organization, gatewayConfig types.GatewayConfigurations) []gwapiv1.Hostname
+	RetrieveWSMatches             func(apkConf types.APKConf, operation types.Operation) []gwapiv1.HTTPRouteMatch
+	RetrieveWSMatch               func(apkConf types.APKConf, operation types.Operation, basePath string) gwapiv1.HTTPRouteMatch
+	GenerateWSBackEndRef          func(endpoint types.EndpointDetails) []gwapiv1.HTTPBackendRef
+}
+
+// Generator creates a new WebSocket route generator.
+func Generator() *wsRouteGenerator {
+	gen := &wsRouteGenerator{}
+	httpGen := http_generator.Generator()
+	gen.GenerateWSRouteRules = gen.generateWSRouteRules
+	gen.GenerateWSRouteRule = gen.generateWSRouteRule
+	gen.GenerateAndRetrieveParentRefs = httpGen.GenerateAndRetrieveParentRefs
+	gen.GenerateWSRouteFilters = gen.generateWSRouteFilters
+	gen.GetHostNames = utils.GetHostNames
+	gen.RetrieveWSMatches = gen.retrieveWSMatches
+	gen.RetrieveWSMatch = gen.retrieveWSMatch
+	gen.GenerateWSBackEndRef = gen.generateWSBackEndRef
+	return gen
+}
+
+// GenerateHTTPRoute generates a HTTPRoute routing the WebSocket upgrade requests of the channels to their endpoints.
+// APK serves WebSocket APIs through HTTPRoutes, as the connections start with an HTTP upgrade request.
+// The HTTPRoute is nil when the diagnostics contain errors, while warnings are returned along with the HTTPRoute.
+func (g *wsRouteGenerator) GenerateHTTPRoute(apkConf types.APKConf, organization types.Organization, gatewayConfiguration types.GatewayConfigurations, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string, count int) (*gwapiv1.HTTPRoute, diagnostics.Diagnostics) {
+	httpRouteRules, diags := g.GenerateWSRouteRules(apkConf, operations, endpoint, endpointType, uniqueId)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return g.newHTTPRoute(apkConf, organization, gatewayConfiguration, httpRouteRules, endpointType, uniqueId, count), diags
+}
+
+// GenerateHTTPRoutes generates the HTTPRoutes of the WebSocket channels, splitting the rules into as many HTTPRoutes
+// as needed to stay within the Gateway API limit of rules per route. Rules of the same backend are kept in the same
+// HTTPRoute when they fit, and the HTTPRoutes are named <uniqueId>-<endpointType>-httproute-<n>.
+func (g *wsRouteGenerator) GenerateHTTPRoutes(apkConf types.APKConf, organization types.Organization, gatewayConfiguration types.GatewayConfigurations, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) ([]*gwapiv1.HTTPRoute, diagnostics.Diagnostics) {
+	httpRouteRules, diags := g.GenerateWSRouteRules(apkConf, operations, endpoint, endpointType, uniqueId)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	var httpRoutes []*gwapiv1.HTTPRoute
+	ruleGroups := utils.SplitRules(httpRouteRules, func(rule gwapiv1.HTTPRouteRule) string {
+		if len(rule.BackendRefs) == 0 {
+			return ""
+		}
+		return string(rule.BackendRefs[0].Name)
+	})
+	for i, rules := range ruleGroups {
+		httpRoutes = append(httpRoutes, g.newHTTPRoute(apkConf, organization, gatewayConfiguration, rules, endpointType, uniqueId, i+1))
+	}
+	return httpRoutes, diags
+}
+
+// newHTTPRoute creates the HTTPRoute with the given rules.
+func (g *wsRouteGenerator) newHTTPRoute(apkConf types.APKConf, organization types.Organization, gatewayConfiguration types.GatewayConfigurations, httpRouteRules []gwapiv1.HTTPRouteRule, endpointType string, uniqueId string, count int) *gwapiv1.HTTPRoute {
+	return &gwapiv1.HTTPRoute{
+		TypeMeta: v1.TypeMeta{
+			Kind:       constants.HTTPROUTE_KIND,
+			APIVersion: constants.GATEWAY_V1,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: uniqueId + "-" + endpointType + "-httproute-" + strconv.Itoa(count),
+		},
+		Spec: gwapiv1.HTTPRouteSpec{
+			CommonRouteSpec: gwapiv1.CommonRouteSpec{
+				ParentRefs: g.GenerateAndRetrieveParentRefs(gatewayConfiguration, uniqueId),
+			},
+			Rules:     httpRouteRules,
+			Hostnames: g.GetHostNames(apkConf, endpointType, organization, gatewayConfiguration),
+		},
+	}
+}
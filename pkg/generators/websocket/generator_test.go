@@ -0,0 +1,139 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package websocket_generator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+// TestGenerator test for Generator
+func TestGenerator(t *testing.T) {
+	apkConf := types.APKConf{
+		Name:     "EmployeeNotificationsAPI",
+		Version:  "1.0",
+		BasePath: "/employees-ws",
+		Type:     constants.API_TYPE_WS,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("ws://employee-notifications:8080"),
+			},
+		},
+		Operations: &[]types.Operation{
+			{Target: "/notifications", Verb: "SUBSCRIBE", Secured: ptr.To(true)},
+			{Target: "/notifications", Verb: "PUBLISH", Secured: ptr.To(true)},
+			{Target: "/rooms/{roomId}", Verb: "SUBSCRIBE", Secured: ptr.To(true)},
+		},
+	}
+	organization := types.Organization{
+		Name: "wso2",
+	}
+	gatewayConfig := types.GatewayConfigurations{
+		Name:         "wso2-apim",
+		ListenerName: "wso2-apim-gateway",
+		Hostname:     "wso2-apim",
+	}
+
+	gen := Generator()
+
+	uniqueId := "unique-id"
+	endpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.PRODUCTION_TYPE]
+	httpRoute, diags := gen.GenerateHTTPRoute(apkConf, organization, gatewayConfig, *apkConf.Operations, &endpoint, constants.PRODUCTION_TYPE, uniqueId, 1)
+	assert.False(t, diags.HasErrors())
+	assert.Empty(t, diags.Warnings())
+	assert.Equal(t, constants.HTTPROUTE_KIND, httpRoute.Kind)
+	assert.Equal(t, constants.GATEWAY_V1, httpRoute.APIVersion)
+	assert.Equal(t, "unique-id-production-httproute-1", httpRoute.Name)
+	// The operations of a channel share its route rule
+	assert.Len(t, httpRoute.Spec.Rules, 2)
+	assert.Equal(t, utils.GetRuleName((*apkConf.Operations)[0]), string(*httpRoute.Spec.Rules[0].Name))
+	assert.Equal(t, `/employees-ws/1\.0/rooms/(.*)`, *httpRoute.Spec.Rules[1].Matches[0].Path.Value)
+	assert.Equal(t, "/rooms/\\1", *httpRoute.Spec.Rules[1].Filters[0].URLRewrite.Path.ReplaceFullPath)
+	assert.Equal(t, int32(8080), int32(*httpRoute.Spec.Rules[0].BackendRefs[0].Port))
+}
+
+func TestGenerateHTTPRoutes(t *testing.T) {
+	var operations []types.Operation
+	for i := 0; i < 20; i++ {
+		operations = append(operations, types.Operation{Target: fmt.Sprintf("/rooms/%d", i), Verb: "SUBSCRIBE"})
+	}
+	apkConf := types.APKConf{
+		Name:     "EmployeeNotificationsAPI",
+		Version:  "1.0",
+		BasePath: "/employees-ws",
+		Type:     constants.API_TYPE_WS,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("ws://employee-notifications:8080"),
+			},
+		},
+		Operations: &operations,
+	}
+	uniqueId := "unique-id"
+	endpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.PRODUCTION_TYPE]
+
+	httpRoutes, diags := Generator().GenerateHTTPRoutes(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, operations, &endpoint, constants.PRODUCTION_TYPE, "unique-id")
+	assert.False(t, diags.HasErrors())
+	assert.Equal(t, []string{"unique-id-production-httproute-1", "unique-id-production-httproute-2"}, utils.GetHTTPRouteNames(httpRoutes))
+	assert.Len(t, httpRoutes[0].Spec.Rules, 16)
+	assert.Len(t, httpRoutes[1].Spec.Rules, 4)
+}
+
+func TestGenerateHTTPRouteDiagnostics(t *testing.T) {
+	operations := []types.Operation{
+		{Target: "/notifications", Verb: "SUBSCRIBE"},
+		{Target: "/notifications", Verb: "PUBLISH", RateLimit: &types.RateLimit{RequestsPerUnit: 10, Unit: "Minute"}},
+		{Target: "/rooms", Verb: "PUBLISH", OperationPolicies: &types.OperationPolicies{
+			Request:  []types.OperationPolicy{{PolicyName: "RequestMirror", Parameters: types.URLList{URLs: []string{"http://mirror:8080"}}}},
+			Response: []types.OperationPolicy{{PolicyName: "BackendJwt", Parameters: types.BackendJWT{SigningAlgorithm: "SHA256withRSA"}}},
+		}},
+	}
+	apkConf := types.APKConf{
+		Name:     "EmployeeNotificationsAPI",
+		Version:  "1.0",
+		BasePath: "/employees-ws",
+		Type:     constants.API_TYPE_WS,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("ws://employee-notifications:8080"),
+			},
+		},
+		Operations: &operations,
+	}
+	uniqueId := "unique-id"
+	endpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.PRODUCTION_TYPE]
+
+	httpRoute, diags := Generator().GenerateHTTPRoute(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, operations, &endpoint, constants.PRODUCTION_TYPE, uniqueId, 1)
+	assert.Nil(t, httpRoute)
+	var messages []string
+	for _, diagnostic := range diags {
+		messages = append(messages, diagnostic.Error())
+	}
+	assert.Equal(t, []string{
+		"warning: operations[1]: operation shares the route rule of the SUBSCRIBE operation of channel /notifications, its own configurations are ignored",
+		"error: operations[2].operationPolicies.request[0]: RequestMirror policy is not supported for WebSocket APIs",
+		"error: operations[2].operationPolicies.response[0]: BackendJwt policy cannot be used as a response policy",
+	}, messages)
+}
@@ -0,0 +1,183 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package websub_generator
+
+import (
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// callbackPath is the path at which the events published to the topics are received, relative to the base path.
+const callbackPath = "/webhooks_events_receiver_resource"
+
+// subscriptionModes holds the hub.mode values of the subscription requests.
+var subscriptionModes = []string{"subscribe", "unsubscribe"}
+
+// generateWebSubRouteRules generates the subscription and callback rules of the topic of each operation. The operations
+// of the same topic share the subscription requests and the event callbacks and hence the rules of the first operation.
+func (g *webSubRouteGenerator) generateWebSubRouteRules(apkConf types.APKConf, operations []types.Operation, endpoint *types.EndpointDetails, endpointType string, uniqueId string) ([]gwapiv1.HTTPRouteRule, diagnostics.Diagnostics) {
+	var httpRouteRules []gwapiv1.HTTPRouteRule
+	var diags diagnostics.Diagnostics
+	if apkConf.APIPolicies != nil {
+		diags = append(diags, checkPolicies(*apkConf.APIPolicies).WithPathPrefix("apiPolicies")...)
+	}
+	topicOperations := make(map[string]types.Operation)
+	for _, operation := range operations {
+		operationPath := utils.GetOperationPath(apkConf, operation)
+		if topicOperation, ok := topicOperations[operation.Target]; ok {
+			diags.Warnf(operationPath, "operation shares the route rules of the %s operation of topic %s, it is ignored", topicOperation.Verb, operation.Target)
+			continue
+		}
+		topicOperations[operation.Target] = operation
+		if operation.OperationPolicies != nil {
+			diags = append(diags, checkPolicies(*operation.OperationPolicies).WithPathPrefix(diagnostics.JoinPath(operationPath, "operationPolicies"))...)
+		}
+		var endpointToUse *types.EndpointDetails = utils.GetEndpointToUse(operation.EndpointConfigurations, endpointType, uniqueId)
+		if endpointToUse == nil && endpoint != nil {
+			endpointToUse = endpoint
+		}
+		if endpointToUse == nil {
+			diags.Errorf(operationPath, "no %s endpoint specified for the operation or the API", endpointType)
+			continue
+		}
+		subscriptionRule := g.GenerateSubscriptionRule(apkConf, operation, *endpointToUse)
+		subscriptionRule.Filters = append(subscriptionRule.Filters, utils.GetOperationExtensionRefFilters(apkConf, operation, uniqueId)...)
+		httpRouteRules = append(httpRouteRules, subscriptionRule, g.GenerateCallbackRule(apkConf, operation, *endpointToUse))
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return httpRouteRules, diags
+}
+
+// generateSubscriptionRule generates the rule of the requests subscribing to and unsubscribing from the topic
+// of the operation, sent to the base path of the API with the hub.mode and hub.topic query parameters.
+// The rule is named after the operation, so that the operation policies target the subscriptions.
+func (g *webSubRouteGenerator) generateSubscriptionRule(apkConf types.APKConf, operation types.Operation, endpoint types.EndpointDetails) gwapiv1.HTTPRouteRule {
+	var matches []gwapiv1.HTTPRouteMatch
+	for _, mode := range subscriptionModes {
+		matches = append(matches, g.RetrieveWebSubMatches(apkConf, "", []gwapiv1.HTTPQueryParamMatch{
+			getQueryParamMatch("hub.mode", mode),
+			getQueryParamMatch("hub.topic", operation.Target),
+		})...)
+	}
+	ruleName := gwapiv1.SectionName(utils.GetRuleName(operation))
+	return gwapiv1.HTTPRouteRule{
+		Name:        &ruleName,
+		Matches:     matches,
+		Filters:     []gwapiv1.HTTPRouteFilter{getURLRewriteFilter("/")},
+		BackendRefs: g.GenerateWebSubBackEndRef(endpoint),
+	}
+}
+
+// generateCallbackRule generates the rule of the events published to the topic of the operation,
+// sent to the callback path with the topic query parameter.
+func (g *webSubRouteGenerator) generateCallbackRule(apkConf types.APKConf, operation types.Operation, endpoint types.EndpointDetails) gwapiv1.HTTPRouteRule {
+	ruleName := gwapiv1.SectionName(utils.GetRuleName(types.Operation{Target: operation.Target, Verb: "CALLBACK"}))
+	return gwapiv1.HTTPRouteRule{
+		Name: &ruleName,
+		Matches: g.RetrieveWebSubMatches(apkConf, callbackPath, []gwapiv1.HTTPQueryParamMatch{
+			getQueryParamMatch("topic", operation.Target),
+		}),
+		Filters:     []gwapiv1.HTTPRouteFilter{getURLRewriteFilter(callbackPath)},
+		BackendRefs: g.GenerateWebSubBackEndRef(endpoint),
+	}
+}
+
+// retrieveWebSubMatches retrieves the HTTPRouteMatches of the POST requests to the given path relative to the base paths
+// of the API, with the given query parameters.
+func (g *webSubRouteGenerator) retrieveWebSubMatches(apkConf types.APKConf, path string, queryParams []gwapiv1.HTTPQueryParamMatch) []gwapiv1.HTTPRouteMatch {
+	basePaths := []string{utils.RetrieveFullBasePath(apkConf.BasePath, apkConf.Version)}
+	if apkConf.DefaultVersion {
+		if defaultBasePath := utils.RetrieveBasePathWithoutVersion(apkConf.BasePath, apkConf.Version); defaultBasePath != basePaths[0] {
+			basePaths = append(basePaths, defaultBasePath)
+		}
+	}
+	var httpRouteMatches []gwapiv1.HTTPRouteMatch
+	for _, basePath := range basePaths {
+		method := gwapiv1.HTTPMethodPost
+		pathType := gwapiv1.PathMatchExact
+		pathValue := basePath + path
+		httpRouteMatches = append(httpRouteMatches, gwapiv1.HTTPRouteMatch{
+			Method: &method,
+			Path: &gwapiv1.HTTPPathMatch{
+				Type:  &pathType,
+				Value: &pathValue,
+			},
+			QueryParams: queryParams,
+		})
+	}
+	return httpRouteMatches
+}
+
+// generateWebSubBackEndRef generates the backend references of the route rule to the hub endpoint.
+func (g *webSubRouteGenerator) generateWebSubBackEndRef(endpoint types.EndpointDetails) []gwapiv1.HTTPBackendRef {
+	httpBackEndRef := gwapiv1.HTTPBackendRef{
+		BackendRef: gwapiv1.BackendRef{
+			BackendObjectReference: utils.GenerateBackendObjectReference(endpoint),
+		},
+	}
+	return []gwapiv1.HTTPBackendRef{httpBackEndRef}
+}
+
+// checkPolicies reports the policies that cannot be applied to the WebSub routes. The Interceptor and BackendJwt
+// request policies are applied through the APIPolicy of the subscription rules.
+// The paths of the returned diagnostics are relative to the policies, e.g. response[0].
+func checkPolicies(policies types.OperationPolicies) diagnostics.Diagnostics {
+	var diags diagnostics.Diagnostics
+	check := func(flow string, flowPolicies []types.OperationPolicy) {
+		for i, policy := range flowPolicies {
+			switch {
+			case policy.PolicyName == constants.POLICY_BACKEND_JWT && flow == "response":
+				diags.Errorf(diagnostics.Index(flow, i), "%s policy cannot be used as a response policy", policy.PolicyName)
+			case policy.PolicyName != constants.POLICY_INTERCEPTOR && policy.PolicyName != constants.POLICY_BACKEND_JWT:
+				diags.Errorf(diagnostics.Index(flow, i), "%s policy is not supported for WebSub APIs", policy.PolicyName)
+			}
+		}
+	}
+	check("request", policies.Request)
+	check("response", policies.Response)
+	return diags
+}
+
+// getQueryParamMatch returns the exact match of the given query parameter.
+func getQueryParamMatch(name string, value string) gwapiv1.HTTPQueryParamMatch {
+	matchType := gwapiv1.QueryParamMatchExact
+	return gwapiv1.HTTPQueryParamMatch{
+		Type:  &matchType,
+		Name:  gwapiv1.HTTPHeaderName(name),
+		Value: value,
+	}
+}
+
+// getURLRewriteFilter returns the filter replacing the full path of the requests with the given path.
+func getURLRewriteFilter(path string) gwapiv1.HTTPRouteFilter {
+	return gwapiv1.HTTPRouteFilter{
+		Type: gwapiv1.HTTPRouteFilterURLRewrite,
+		URLRewrite: &gwapiv1.HTTPURLRewriteFilter{
+			Path: &gwapiv1.HTTPPathModifier{
+				Type:            gwapiv1.FullPathHTTPPathModifier,
+				ReplaceFullPath: &path,
+			},
+		},
+	}
+}
@@ -0,0 +1,74 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package websub_generator
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+
+	"github.com/stretchr/testify/assert"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestRetrieveWebSubMatches(t *testing.T) {
+	g := Generator()
+	apkConf := types.APKConf{
+		Name:     "RepoWatcherAPI",
+		Version:  "1.0",
+		BasePath: "/repo-watcher",
+		Type:     constants.API_TYPE_WEBSUB,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://websub-hub:8080"),
+			},
+		},
+	}
+	apkConf.DefaultVersion = true
+
+	matches := g.RetrieveWebSubMatches(apkConf, "/webhooks_events_receiver_resource", nil)
+	assert.Len(t, matches, 2)
+	assert.Equal(t, gwapiv1.PathMatchExact, *matches[0].Path.Type)
+	assert.Equal(t, "/repo-watcher/1.0/webhooks_events_receiver_resource", *matches[0].Path.Value)
+	assert.Equal(t, "/repo-watcher/webhooks_events_receiver_resource", *matches[1].Path.Value)
+}
+
+func TestGenerateCallbackRule(t *testing.T) {
+	g := Generator()
+	operation := types.Operation{Target: "_issues", Verb: "SUBSCRIBE"}
+	apkConf := types.APKConf{
+		Name:     "RepoWatcherAPI",
+		Version:  "1.0",
+		BasePath: "/repo-watcher",
+		Type:     constants.API_TYPE_WEBSUB,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://websub-hub:8080"),
+			},
+		},
+		Operations: &[]types.Operation{operation},
+	}
+	endpoint := types.EndpointDetails{Name: "backend", URL: "http://websub-hub:8080"}
+
+	callbackRule := g.GenerateCallbackRule(apkConf, operation, endpoint)
+	// The callback rule is not named after the operation, so that the operation policies only target the subscriptions
+	assert.NotEqual(t, *g.GenerateSubscriptionRule(apkConf, operation, endpoint).Name, *callbackRule.Name)
+	assert.Equal(t, gwapiv1.HTTPMethodPost, *callbackRule.Matches[0].Method)
+	assert.Equal(t, gwapiv1.ObjectName("backend"), callbackRule.BackendRefs[0].Name)
+}
@@ -0,0 +1,187 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package websub_generator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// TestGenerator test for Generator
+func TestGenerator(t *testing.T) {
+	apkConf := types.APKConf{
+		Name:     "RepoWatcherAPI",
+		Version:  "1.0",
+		BasePath: "/repo-watcher",
+		Type:     constants.API_TYPE_WEBSUB,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://websub-hub:8080"),
+			},
+		},
+		Operations: &[]types.Operation{
+			{Target: "_issues", Verb: "SUBSCRIBE", Secured: ptr.To(true)},
+			{Target: "_pull_requests", Verb: "SUBSCRIBE", Secured: ptr.To(true)},
+		},
+	}
+	organization := types.Organization{
+		Name: "wso2",
+	}
+	gatewayConfig := types.GatewayConfigurations{
+		Name:         "wso2-apim",
+		ListenerName: "wso2-apim-gateway",
+		Hostname:     "wso2-apim",
+	}
+
+	gen := Generator()
+
+	uniqueId := "unique-id"
+	endpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.PRODUCTION_TYPE]
+	httpRoute, diags := gen.GenerateHTTPRoute(apkConf, organization, gatewayConfig, *apkConf.Operations, &endpoint, constants.PRODUCTION_TYPE, uniqueId, 1)
+	assert.False(t, diags.HasErrors())
+	assert.Equal(t, constants.HTTPROUTE_KIND, httpRoute.Kind)
+	assert.Equal(t, "unique-id-production-httproute-1", httpRoute.Name)
+	// Each topic has a subscription and a callback rule
+	assert.Len(t, httpRoute.Spec.Rules, 4)
+
+	subscriptionRule := httpRoute.Spec.Rules[2]
+	assert.Equal(t, utils.GetRuleName((*apkConf.Operations)[1]), string(*subscriptionRule.Name))
+	assert.Len(t, subscriptionRule.Matches, 2)
+	assert.Equal(t, gwapiv1.HTTPMethodPost, *subscriptionRule.Matches[0].Method)
+	assert.Equal(t, "/repo-watcher/1.0", *subscriptionRule.Matches[0].Path.Value)
+	assert.Equal(t, []string{"hub.mode=subscribe", "hub.topic=_pull_requests"}, getQueryParams(subscriptionRule.Matches[0]))
+	assert.Equal(t, []string{"hub.mode=unsubscribe", "hub.topic=_pull_requests"}, getQueryParams(subscriptionRule.Matches[1]))
+	assert.Equal(t, endpoint.Name, string(subscriptionRule.BackendRefs[0].Name))
+
+	callbackRule := httpRoute.Spec.Rules[3]
+	assert.Equal(t, "/repo-watcher/1.0/webhooks_events_receiver_resource", *callbackRule.Matches[0].Path.Value)
+	assert.Equal(t, []string{"topic=_pull_requests"}, getQueryParams(callbackRule.Matches[0]))
+	assert.Equal(t, "/webhooks_events_receiver_resource", *callbackRule.Filters[0].URLRewrite.Path.ReplaceFullPath)
+}
+
+func TestGenerateHTTPRoutes(t *testing.T) {
+	var operations []types.Operation
+	for i := 0; i < 10; i++ {
+		operations = append(operations, types.Operation{Target: fmt.Sprintf("topic-%d", i), Verb: "SUBSCRIBE"})
+	}
+	apkConf := types.APKConf{
+		Name:     "RepoWatcherAPI",
+		Version:  "1.0",
+		BasePath: "/repo-watcher",
+		Type:     constants.API_TYPE_WEBSUB,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://websub-hub:8080"),
+			},
+		},
+		Operations: &operations,
+	}
+	uniqueId := "unique-id"
+	endpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.PRODUCTION_TYPE]
+
+	httpRoutes, diags := Generator().GenerateHTTPRoutes(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, operations, &endpoint, constants.PRODUCTION_TYPE, "unique-id")
+	assert.False(t, diags.HasErrors())
+	assert.Equal(t, []string{"unique-id-production-httproute-1", "unique-id-production-httproute-2"}, utils.GetHTTPRouteNames(httpRoutes))
+	assert.Len(t, httpRoutes[0].Spec.Rules, 16)
+	assert.Len(t, httpRoutes[1].Spec.Rules, 4)
+}
+
+func TestGenerateHTTPRouteErrors(t *testing.T) {
+	operations := []types.Operation{
+		{Target: "_issues", Verb: "SUBSCRIBE", OperationPolicies: &types.OperationPolicies{
+			Request: []types.OperationPolicy{
+				{PolicyName: "Interceptor", Parameters: types.InterceptorService{BackendURL: "http://interceptor:8080"}},
+				{PolicyName: "AddHeader", Parameters: types.Header{HeaderName: "X-Topic", HeaderValue: "issues"}},
+			},
+			Response: []types.OperationPolicy{
+				{PolicyName: "BackendJwt", Parameters: types.BackendJWT{SigningAlgorithm: "SHA256withRSA"}},
+			},
+		}},
+	}
+	apkConf := types.APKConf{
+		Name:     "RepoWatcherAPI",
+		Version:  "1.0",
+		BasePath: "/repo-watcher",
+		Type:     constants.API_TYPE_WEBSUB,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://websub-hub:8080"),
+			},
+		},
+		Operations: &operations,
+	}
+
+	httpRoute, diags := Generator().GenerateHTTPRoute(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, operations, nil, constants.PRODUCTION_TYPE, "unique-id", 1)
+	assert.Nil(t, httpRoute)
+	var messages []string
+	for _, diagnostic := range diags {
+		messages = append(messages, diagnostic.Error())
+	}
+	assert.Equal(t, []string{
+		"error: operations[0].operationPolicies.request[1]: AddHeader policy is not supported for WebSub APIs",
+		"error: operations[0].operationPolicies.response[0]: BackendJwt policy cannot be used as a response policy",
+		"error: operations[0]: no production endpoint specified for the operation or the API",
+	}, messages)
+}
+
+func TestGenerateHTTPRouteSharedTopic(t *testing.T) {
+	operations := []types.Operation{
+		{Target: "_issues", Verb: "SUBSCRIBE"},
+		{Target: "_pull_requests", Verb: "SUBSCRIBE"},
+		{Target: "_issues", Verb: "subscribe", RateLimit: &types.RateLimit{RequestsPerUnit: 10, Unit: "Minute"}},
+	}
+	apkConf := types.APKConf{
+		Name:     "RepoWatcherAPI",
+		Version:  "1.0",
+		BasePath: "/repo-watcher",
+		Type:     constants.API_TYPE_WEBSUB,
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("http://websub-hub:8080"),
+			},
+		},
+		Operations: &operations,
+	}
+	uniqueId := "unique-id"
+	endpoint := utils.GetEndpoints(apkConf, uniqueId)[constants.PRODUCTION_TYPE]
+
+	httpRoute, diags := Generator().GenerateHTTPRoute(apkConf, types.Organization{Name: "wso2"}, types.GatewayConfigurations{Name: "wso2-apim"}, operations, &endpoint, constants.PRODUCTION_TYPE, uniqueId, 1)
+	assert.False(t, diags.HasErrors())
+	// The later operation of the topic is ignored, leaving a single callback rule per topic
+	assert.Len(t, httpRoute.Spec.Rules, 4)
+	assert.Equal(t, gwapiv1.SectionName(utils.GetRuleName(operations[0])), *httpRoute.Spec.Rules[0].Name)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "warning: operations[2]: operation shares the route rules of the SUBSCRIBE operation of topic _issues, it is ignored", diags[0].Error())
+}
+
+// getQueryParams returns the query parameters of the match as name=value pairs.
+func getQueryParams(match gwapiv1.HTTPRouteMatch) []string {
+	var queryParams []string
+	for _, queryParam := range match.QueryParams {
+		queryParams = append(queryParams, string(queryParam.Name)+"="+queryParam.Value)
+	}
+	return queryParams
+}
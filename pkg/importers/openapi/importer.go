@@ -0,0 +1,372 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package openapi_importer
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/utils/ptr"
+)
+
+// defaultDefinitionPath is the path the API definition is exposed on, unless configured otherwise.
+const defaultDefinitionPath = "/api-definition"
+
+// Options configures the import of an OpenAPI definition.
+type Options struct {
+	// DefinitionPath is the path the API definition is exposed on, defaulting to /api-definition.
+	DefinitionPath string
+}
+
+// Import generates the APK configuration of a REST API from the given OpenAPI 2.0 or 3.x definition, in JSON or YAML.
+//
+// The API name and version are taken from the info of the definition and the base path from the x-wso2-basePath
+// extension, defaulting to the name of the API. The production and sandbox endpoints are taken from the
+// x-wso2-production-endpoints and x-wso2-sandbox-endpoints extensions of the definition and of the operations,
+// with the production endpoint defaulting to the servers, or the host, of the definition.
+func Import(definition []byte, opts Options) (*types.APKConf, error) {
+	var doc document
+	if err := yaml.Unmarshal(definition, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse the OpenAPI definition: %w", err)
+	}
+	if doc.Swagger == "" && doc.OpenAPI == "" {
+		return nil, fmt.Errorf("not an OpenAPI definition, no swagger or openapi version specified")
+	}
+	if doc.Swagger != "" && doc.Swagger != "2.0" {
+		return nil, fmt.Errorf("unsupported swagger version: %q", doc.Swagger)
+	}
+	if doc.OpenAPI != "" && !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported openapi version: %q", doc.OpenAPI)
+	}
+	if doc.Info.Title == "" {
+		return nil, fmt.Errorf("no title specified in the info of the OpenAPI definition")
+	}
+
+	definitionPath := opts.DefinitionPath
+	if definitionPath == "" {
+		definitionPath = defaultDefinitionPath
+	}
+	basePath, err := getBasePath(doc)
+	if err != nil {
+		return nil, err
+	}
+	apkConf := types.APKConf{
+		Name:           doc.Info.Title,
+		Version:        doc.Info.Version,
+		BasePath:       basePath,
+		Type:           constants.API_TYPE_REST,
+		DefinitionPath: definitionPath,
+	}
+
+	endpointConfigs, err := getEndpointConfigurations(doc.Production, doc.Sandbox, "")
+	if err != nil {
+		return nil, err
+	}
+	if endpointConfigs == nil || endpointConfigs.Production == nil {
+		if url := getServerURL(doc); url != "" {
+			if endpointConfigs == nil {
+				endpointConfigs = &types.EndpointConfigurations{}
+			}
+			endpointConfigs.Production = &types.EndpointConfiguration{Endpoint: types.EndpointURL(url)}
+		}
+	}
+	apkConf.EndpointConfigurations = endpointConfigs
+
+	authConfigs, err := getAuthConfigurations(doc)
+	if err != nil {
+		return nil, err
+	}
+	apkConf.Authentication = authConfigs
+
+	operations, err := getOperations(doc)
+	if err != nil {
+		return nil, err
+	}
+	apkConf.Operations = &operations
+	return &apkConf, nil
+}
+
+// document holds the parts of an OpenAPI 2.0 or 3.x definition mapped to the APK configuration.
+type document struct {
+	Swagger string `yaml:"swagger"`
+	OpenAPI string `yaml:"openapi"`
+	Info    struct {
+		Title   string `yaml:"title"`
+		Version string `yaml:"version"`
+	} `yaml:"info"`
+	// Host, BasePath and Schemes locate the backend of an OpenAPI 2.0 definition.
+	Host     string   `yaml:"host"`
+	BasePath string   `yaml:"basePath"`
+	Schemes  []string `yaml:"schemes"`
+	// Servers locate the backend of an OpenAPI 3.x definition.
+	Servers  []server               `yaml:"servers"`
+	Paths    yaml.Node              `yaml:"paths"`
+	Security *[]securityRequirement `yaml:"security"`
+
+	WSO2BasePath        string               `yaml:"x-wso2-basePath"`
+	Production          *endpoints           `yaml:"x-wso2-production-endpoints"`
+	Sandbox             *endpoints           `yaml:"x-wso2-sandbox-endpoints"`
+	DisableSecurity     bool                 `yaml:"x-wso2-disable-security"`
+	AuthHeader          string               `yaml:"x-wso2-auth-header"`
+	ApplicationSecurity *applicationSecurity `yaml:"x-wso2-application-security"`
+}
+
+// server is a server of an OpenAPI 3.x definition.
+type server struct {
+	URL       string `yaml:"url"`
+	Variables map[string]struct {
+		Default string `yaml:"default"`
+	} `yaml:"variables"`
+}
+
+// pathItem holds the operations of a path of an OpenAPI definition.
+type pathItem struct {
+	Get     *operation `yaml:"get"`
+	Put     *operation `yaml:"put"`
+	Post    *operation `yaml:"post"`
+	Delete  *operation `yaml:"delete"`
+	Options *operation `yaml:"options"`
+	Head    *operation `yaml:"head"`
+	Patch   *operation `yaml:"patch"`
+}
+
+// operation holds the security and the x-wso2 extensions of an operation of an OpenAPI definition.
+type operation struct {
+	Security        *[]securityRequirement `yaml:"security"`
+	Scope           string                 `yaml:"x-scope"`
+	AuthType        string                 `yaml:"x-auth-type"`
+	DisableSecurity bool                   `yaml:"x-wso2-disable-security"`
+	Production      *endpoints             `yaml:"x-wso2-production-endpoints"`
+	Sandbox         *endpoints             `yaml:"x-wso2-sandbox-endpoints"`
+}
+
+// securityRequirement maps the security schemes of a security requirement to their scopes.
+type securityRequirement map[string][]string
+
+// endpoints is the value of the x-wso2-production-endpoints and x-wso2-sandbox-endpoints extensions.
+type endpoints struct {
+	URLs []string `yaml:"urls"`
+}
+
+// applicationSecurity is the value of the x-wso2-application-security extension.
+type applicationSecurity struct {
+	SecurityTypes []string `yaml:"security-types"`
+	Optional      bool     `yaml:"optional"`
+}
+
+// getBasePath returns the base path of the x-wso2-basePath extension without the version, defaulting to the name
+// of the API.
+func getBasePath(doc document) (string, error) {
+	if doc.WSO2BasePath != "" {
+		basePath := strings.TrimSuffix(doc.WSO2BasePath, "/{version}")
+		basePath = utils.RetrieveBasePathWithoutVersion(basePath, doc.Info.Version)
+		if !strings.HasPrefix(basePath, "/") {
+			basePath = "/" + basePath
+		}
+		return basePath, nil
+	}
+	name := strings.Trim(regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(strings.ToLower(doc.Info.Title), "-"), "-")
+	if name == "" {
+		return "", fmt.Errorf("failed to derive the base path from the title %q, specify the x-wso2-basePath extension", doc.Info.Title)
+	}
+	return "/" + name, nil
+}
+
+// getServerURL returns the first absolute HTTP URL of the servers of an OpenAPI 3.x definition, or the URL of the
+// host of an OpenAPI 2.0 definition, preferring HTTPS.
+func getServerURL(doc document) string {
+	for _, server := range doc.Servers {
+		url := server.URL
+		for name, variable := range server.Variables {
+			url = strings.ReplaceAll(url, "{"+name+"}", variable.Default)
+		}
+		if strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
+			return strings.TrimSuffix(url, "/")
+		}
+	}
+	if doc.Host == "" {
+		return ""
+	}
+	scheme := "http"
+	if slices.Contains(doc.Schemes, "https") {
+		scheme = "https"
+	}
+	return scheme + "://" + doc.Host + strings.TrimSuffix(doc.BasePath, "/")
+}
+
+// getEndpointConfigurations returns the endpoint configurations of the x-wso2-production-endpoints and
+// x-wso2-sandbox-endpoints extensions, or nil when neither is specified.
+func getEndpointConfigurations(production *endpoints, sandbox *endpoints, path string) (*types.EndpointConfigurations, error) {
+	if production == nil && sandbox == nil {
+		return nil, nil
+	}
+	var endpointConfigs types.EndpointConfigurations
+	var err error
+	if production != nil {
+		if endpointConfigs.Production, err = getEndpointConfiguration(*production, path, "x-wso2-production-endpoints"); err != nil {
+			return nil, err
+		}
+	}
+	if sandbox != nil {
+		if endpointConfigs.Sandbox, err = getEndpointConfiguration(*sandbox, path, "x-wso2-sandbox-endpoints"); err != nil {
+			return nil, err
+		}
+	}
+	return &endpointConfigs, nil
+}
+
+// getEndpointConfiguration returns the endpoint configuration of the URL of the endpoints extension. A single URL is
+// supported, as an APK endpoint configuration refers to a single endpoint.
+func getEndpointConfiguration(endpoints endpoints, path string, extension string) (*types.EndpointConfiguration, error) {
+	if path != "" {
+		extension = fmt.Sprintf("%s extension of the operation %s", extension, path)
+	} else {
+		extension += " extension"
+	}
+	if len(endpoints.URLs) == 0 || endpoints.URLs[0] == "" {
+		return nil, fmt.Errorf("no urls specified in the %s", extension)
+	}
+	if len(endpoints.URLs) > 1 {
+		return nil, fmt.Errorf("multiple urls specified in the %s, only a single url is supported", extension)
+	}
+	return &types.EndpointConfiguration{Endpoint: types.EndpointURL(endpoints.URLs[0])}, nil
+}
+
+// getAuthConfigurations returns the authentication configurations of the x-wso2-application-security and
+// x-wso2-auth-header extensions, or nil when neither is specified.
+func getAuthConfigurations(doc document) (*[]types.AuthConfiguration, error) {
+	if doc.ApplicationSecurity == nil {
+		if doc.AuthHeader == "" {
+			return nil, nil
+		}
+		return &[]types.AuthConfiguration{{AuthType: constants.AUTH_TYPE_OAUTH2, HeaderName: doc.AuthHeader}}, nil
+	}
+	required := "mandatory"
+	if doc.ApplicationSecurity.Optional {
+		required = "optional"
+	}
+	// OAuth2 is enabled by default, so it is disabled explicitly unless listed
+	oauth2 := types.AuthConfiguration{AuthType: constants.AUTH_TYPE_OAUTH2, HeaderName: doc.AuthHeader, Enabled: ptr.To(false)}
+	var authConfigs []types.AuthConfiguration
+	for _, securityType := range doc.ApplicationSecurity.SecurityTypes {
+		switch securityType {
+		case "oauth2":
+			oauth2.Enabled = nil
+			oauth2.Required = required
+		case "api_key":
+			authConfigs = append(authConfigs, types.AuthConfiguration{AuthType: constants.AUTH_TYPE_API_KEY, Required: required, HeaderEnabled: true})
+		default:
+			return nil, fmt.Errorf("unsupported security type in the x-wso2-application-security extension: %q", securityType)
+		}
+	}
+	authConfigs = append([]types.AuthConfiguration{oauth2}, authConfigs...)
+	return &authConfigs, nil
+}
+
+// getOperations returns the operations of the paths of the definition, in the order of the paths.
+func getOperations(doc document) ([]types.Operation, error) {
+	operations := []types.Operation{}
+	if doc.Paths.Kind == 0 {
+		return operations, nil
+	}
+	if doc.Paths.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("the paths of the OpenAPI definition must be a mapping")
+	}
+	for i := 0; i+1 < len(doc.Paths.Content); i += 2 {
+		target := doc.Paths.Content[i].Value
+		var item pathItem
+		if err := doc.Paths.Content[i+1].Decode(&item); err != nil {
+			return nil, fmt.Errorf("failed to parse the path %s: %w", target, err)
+		}
+		methods := []struct {
+			verb      string
+			operation *operation
+		}{
+			{"GET", item.Get}, {"PUT", item.Put}, {"POST", item.Post}, {"DELETE", item.Delete},
+			{"OPTIONS", item.Options}, {"HEAD", item.Head}, {"PATCH", item.Patch},
+		}
+		for _, method := range methods {
+			if method.operation == nil {
+				continue
+			}
+			operation, err := getOperation(doc, target, method.verb, *method.operation)
+			if err != nil {
+				return nil, err
+			}
+			operations = append(operations, operation)
+		}
+	}
+	return operations, nil
+}
+
+// getOperation returns the operation of the APK configuration of an operation of the definition. The operation is
+// secured unless its security, or the security of the definition, is empty or the security is disabled with the
+// x-auth-type or x-wso2-disable-security extensions.
+func getOperation(doc document, target string, verb string, op operation) (types.Operation, error) {
+	security := doc.Security
+	if op.Security != nil {
+		security = op.Security
+	}
+	secured := !doc.DisableSecurity && !op.DisableSecurity && !strings.EqualFold(op.AuthType, "None")
+	if security != nil && len(*security) == 0 {
+		secured = false
+	}
+
+	var scopes []string
+	addScope := func(scope string) {
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	if security != nil {
+		for _, requirement := range *security {
+			for _, scheme := range slices.Sorted(maps.Keys(requirement)) {
+				for _, scope := range requirement[scheme] {
+					addScope(scope)
+				}
+			}
+		}
+	}
+	if op.Scope != "" {
+		addScope(op.Scope)
+	}
+
+	endpointConfigs, err := getEndpointConfigurations(op.Production, op.Sandbox, verb+" "+target)
+	if err != nil {
+		return types.Operation{}, err
+	}
+	operation := types.Operation{
+		Target:                 target,
+		Verb:                   verb,
+		Scopes:                 scopes,
+		EndpointConfigurations: endpointConfigs,
+	}
+	// Operations are secured by default, so only the unsecured operations specify it
+	if !secured {
+		operation.Secured = ptr.To(false)
+	}
+	return operation, nil
+}
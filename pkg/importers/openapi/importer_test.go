@@ -0,0 +1,160 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package openapi_importer
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/validation"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+// testOpenAPI is an OpenAPI 3.0 definition of the employee service with x-wso2 extensions.
+const testOpenAPI = `openapi: 3.0.1
+info:
+  title: Employee Service
+  version: "1.0"
+servers:
+  - url: /relative
+  - url: https://{host}/api
+    variables:
+      host:
+        default: employees.example.com
+security:
+  - default: [read]
+x-wso2-basePath: /employees/1.0
+x-wso2-sandbox-endpoints:
+  urls:
+    - https://sandbox.example.com/api
+x-wso2-auth-header: X-Authorization
+paths:
+  /employees:
+    post:
+      security:
+        - default: [write]
+          apiKey: [admin]
+      x-wso2-production-endpoints:
+        urls:
+          - https://write.example.com/api
+    get:
+      x-scope: list
+  /employees/{id}:
+    parameters:
+      - name: id
+        in: path
+    delete:
+      security: []
+    put:
+      x-auth-type: None
+    patch:
+      x-wso2-disable-security: true
+`
+
+// testSwagger is a Swagger 2.0 definition of the employee service in JSON.
+const testSwagger = `{
+  "swagger": "2.0",
+  "info": {"title": "Employees", "version": "2.0.0"},
+  "host": "employees.example.com:8080",
+  "basePath": "/api",
+  "schemes": ["http", "https"],
+  "security": [],
+  "x-wso2-application-security": {"security-types": ["api_key"], "optional": true},
+  "paths": {
+    "/employees": {"get": {}, "head": {"security": [{"oauth": ["read"]}]}}
+  }
+}`
+
+// TestImport test for Import
+func TestImport(t *testing.T) {
+	apkConf, err := Import([]byte(testOpenAPI), Options{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Employee Service", apkConf.Name)
+	assert.Equal(t, "1.0", apkConf.Version)
+	assert.Equal(t, "/employees", apkConf.BasePath)
+	assert.Equal(t, constants.API_TYPE_REST, apkConf.Type)
+	assert.Equal(t, "/api-definition", apkConf.DefinitionPath)
+	// The first absolute server URL is used as the production endpoint
+	assert.Equal(t, &types.EndpointConfigurations{
+		Production: &types.EndpointConfiguration{Endpoint: types.EndpointURL("https://employees.example.com/api")},
+		Sandbox:    &types.EndpointConfiguration{Endpoint: types.EndpointURL("https://sandbox.example.com/api")},
+	}, apkConf.EndpointConfigurations)
+	assert.Equal(t, &[]types.AuthConfiguration{
+		{AuthType: constants.AUTH_TYPE_OAUTH2, HeaderName: "X-Authorization"},
+	}, apkConf.Authentication)
+	assert.Equal(t, &[]types.Operation{
+		{Target: "/employees", Verb: "GET", Scopes: []string{"read", "list"}},
+		{
+			Target: "/employees",
+			Verb:   "POST",
+			Scopes: []string{"admin", "write"},
+			EndpointConfigurations: &types.EndpointConfigurations{
+				Production: &types.EndpointConfiguration{Endpoint: types.EndpointURL("https://write.example.com/api")},
+			},
+		},
+		{Target: "/employees/{id}", Verb: "PUT", Scopes: []string{"read"}, Secured: ptr.To(false)},
+		{Target: "/employees/{id}", Verb: "DELETE", Secured: ptr.To(false)},
+		{Target: "/employees/{id}", Verb: "PATCH", Scopes: []string{"read"}, Secured: ptr.To(false)},
+	}, apkConf.Operations)
+	assert.False(t, validation.Validator().Validate(*apkConf).HasErrors())
+
+	apkConf, err = Import([]byte(testSwagger), Options{DefinitionPath: "/swagger.json"})
+	assert.Nil(t, err)
+	assert.Equal(t, "/employees", apkConf.BasePath)
+	assert.Equal(t, "/swagger.json", apkConf.DefinitionPath)
+	assert.Equal(t, &types.EndpointConfigurations{
+		Production: &types.EndpointConfiguration{Endpoint: types.EndpointURL("https://employees.example.com:8080/api")},
+	}, apkConf.EndpointConfigurations)
+	// OAuth2 is disabled when not listed in the security types
+	assert.Equal(t, &[]types.AuthConfiguration{
+		{AuthType: constants.AUTH_TYPE_OAUTH2, Enabled: ptr.To(false)},
+		{AuthType: constants.AUTH_TYPE_API_KEY, Required: "optional", HeaderEnabled: true},
+	}, apkConf.Authentication)
+	assert.Equal(t, &[]types.Operation{
+		{Target: "/employees", Verb: "GET", Secured: ptr.To(false)},
+		{Target: "/employees", Verb: "HEAD", Scopes: []string{"read"}},
+	}, apkConf.Operations)
+	assert.False(t, validation.Validator().Validate(*apkConf).HasErrors())
+}
+
+func TestImportErrors(t *testing.T) {
+	tests := []struct {
+		definition string
+		err        string
+	}{
+		{"openapi: [", "failed to parse the OpenAPI definition"},
+		{"info: {title: Employees}", "not an OpenAPI definition, no swagger or openapi version specified"},
+		{"swagger: \"1.2\"", "unsupported swagger version: \"1.2\""},
+		{"openapi: 2.0.0", "unsupported openapi version: \"2.0.0\""},
+		{"openapi: 3.1.0\ninfo: {version: v1}", "no title specified in the info of the OpenAPI definition"},
+		{"openapi: 3.1.0\ninfo: {title: \"!!\"}", "failed to derive the base path from the title \"!!\""},
+		{"openapi: 3.1.0\ninfo: {title: Employees}\nx-wso2-production-endpoints: {}", "no urls specified in the x-wso2-production-endpoints extension"},
+		{"openapi: 3.1.0\ninfo: {title: Employees}\npaths:\n  /employees:\n    get:\n      x-wso2-sandbox-endpoints: {urls: []}", "no urls specified in the x-wso2-sandbox-endpoints extension of the operation GET /employees"},
+		{"openapi: 3.1.0\ninfo: {title: Employees}\nx-wso2-production-endpoints: {urls: [http://a:80, http://b:80]}", "multiple urls specified in the x-wso2-production-endpoints extension, only a single url is supported"},
+		{"openapi: 3.1.0\ninfo: {title: Employees}\npaths:\n  /employees:\n    get:\n      x-wso2-sandbox-endpoints: {urls: [http://a:80, http://b:80]}", "multiple urls specified in the x-wso2-sandbox-endpoints extension of the operation GET /employees, only a single url is supported"},
+		{"openapi: 3.1.0\ninfo: {title: Employees}\nx-wso2-application-security: {security-types: [basic_auth]}", "unsupported security type in the x-wso2-application-security extension: \"basic_auth\""},
+		{"openapi: 3.1.0\ninfo: {title: Employees}\npaths: [/employees]", "the paths of the OpenAPI definition must be a mapping"},
+	}
+	for _, test := range tests {
+		_, err := Import([]byte(test.definition), Options{})
+		assert.ErrorContains(t, err, test.err, test.definition)
+	}
+}
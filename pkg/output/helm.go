@@ -0,0 +1,291 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package output
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+)
+
+// HelmChartOptions holds the metadata of the generated Helm chart.
+type HelmChartOptions struct {
+	// Name is the name of the chart.
+	Name string
+	// Version is the version of the chart, defaulting to 0.1.0.
+	Version string
+	// AppVersion is the version of the application, defaulting to the version of the API.
+	AppVersion string
+	// Description is the description of the chart.
+	Description string
+}
+
+// helmTemplate collects the template expressions of a chart, set as placeholders in the resources before
+// marshaling them since the expressions are not valid YAML values.
+type helmTemplate struct {
+	expressions []string
+}
+
+// placeholder returns the placeholder of the template expression.
+func (t *helmTemplate) placeholder(expression string) string {
+	t.expressions = append(t.expressions, expression)
+	return fmt.Sprintf("__helm_template_%d__", len(t.expressions)-1)
+}
+
+// render replaces the placeholders of the marshaled resource with their template expressions, escaping the
+// template delimiters found in the resource itself.
+func (t *helmTemplate) render(data []byte) []byte {
+	replacements := []string{"{{", `{{ "{{" }}`}
+	for i, expression := range t.expressions {
+		replacements = append(replacements, fmt.Sprintf("__helm_template_%d__", i), expression)
+	}
+	return []byte(strings.NewReplacer(replacements...).Replace(string(data)))
+}
+
+// WriteHelmChart writes the resources to the directory as a Helm chart. The namespace of the resources, the
+// gateway and hostnames of the routes and the services of the Backends are templated, with the generated
+// configuration as their default values in values.yaml.
+func WriteHelmChart(dir string, objects []Object, opts HelmChartOptions) error {
+	if opts.Name == "" {
+		return errors.New("no name specified for the helm chart")
+	}
+	resources := make([]map[string]interface{}, 0, len(objects))
+	for _, object := range objects {
+		resource, err := ToMap(object)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, resource)
+	}
+
+	template := &helmTemplate{}
+	values := map[string]interface{}{
+		"namespace": "",
+	}
+	endpoints := templateBackends(template, resources)
+	if len(endpoints) > 0 {
+		values["endpoints"] = endpoints
+	}
+	gateway, hostnames := templateRoutes(template, resources)
+	if len(gateway) > 0 {
+		values["gateway"] = gateway
+	}
+	if len(hostnames) > 0 {
+		values["hostnames"] = hostnames
+	}
+	for _, resource := range resources {
+		if metadata, ok := resource["metadata"].(map[string]interface{}); ok {
+			metadata["namespace"] = template.placeholder("{{ .Values.namespace | default .Release.Namespace }}")
+		}
+	}
+
+	templatesDir := filepath.Join(dir, "templates")
+	if err := os.MkdirAll(templatesDir, 0o755); err != nil {
+		return err
+	}
+	for i, fileName := range getFileNames(objects) {
+		data, err := marshalYAML(resources[i])
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %v", getResourceName(objects[i]), err)
+		}
+		if err := os.WriteFile(filepath.Join(templatesDir, fileName), template.render(data), 0o644); err != nil {
+			return err
+		}
+	}
+
+	chart := map[string]interface{}{
+		"apiVersion": "v2",
+		"name":       opts.Name,
+		"type":       "application",
+		"version":    opts.Version,
+	}
+	if opts.Version == "" {
+		chart["version"] = "0.1.0"
+	}
+	if opts.Description != "" {
+		chart["description"] = opts.Description
+	}
+	if opts.AppVersion != "" {
+		chart["appVersion"] = opts.AppVersion
+	} else if appVersion := getAPIVersion(resources); appVersion != "" {
+		chart["appVersion"] = appVersion
+	}
+	data, err := marshalYAML(chart)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "Chart.yaml"), data, 0o644); err != nil {
+		return err
+	}
+	data, err = marshalYAML(values)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "values.yaml"), data, 0o644)
+}
+
+// templateBackends templates the protocol, host and port of the Backends with a single service, returning their
+// values by the Backend name. The ports of the route backend references to the Backends are templated as well.
+func templateBackends(template *helmTemplate, resources []map[string]interface{}) map[string]interface{} {
+	endpoints := make(map[string]interface{})
+	for _, resource := range getResources(resources, constants.BACKEND_KIND) {
+		name := getString(resource, "metadata", "name")
+		spec, _ := resource["spec"].(map[string]interface{})
+		services, _ := spec["services"].([]interface{})
+		if len(services) != 1 {
+			continue
+		}
+		service, _ := services[0].(map[string]interface{})
+		endpoints[name] = map[string]interface{}{
+			"protocol": spec["protocol"],
+			"host":     service["host"],
+			"port":     service["port"],
+		}
+		value := fmt.Sprintf("(index .Values.endpoints %q)", name)
+		spec["protocol"] = template.placeholder("{{ " + value + ".protocol | quote }}")
+		service["host"] = template.placeholder("{{ " + value + ".host | quote }}")
+		service["port"] = template.placeholder("{{ " + value + ".port }}")
+	}
+
+	for _, resource := range getRoutes(resources) {
+		spec, _ := resource["spec"].(map[string]interface{})
+		// The GQLRoutes refer their backend in the spec, while the other routes refer them in each rule.
+		backendRefs, _ := spec["backendRefs"].([]interface{})
+		rules, _ := spec["rules"].([]interface{})
+		for _, rule := range rules {
+			rule, _ := rule.(map[string]interface{})
+			ruleBackendRefs, _ := rule["backendRefs"].([]interface{})
+			backendRefs = append(backendRefs, ruleBackendRefs...)
+		}
+		for _, backendRef := range backendRefs {
+			backendRef, _ := backendRef.(map[string]interface{})
+			name, _ := backendRef["name"].(string)
+			if _, ok := endpoints[name]; ok && backendRef["kind"] == constants.BACKEND_KIND && backendRef["port"] != nil {
+				backendRef["port"] = template.placeholder(fmt.Sprintf("{{ (index .Values.endpoints %q).port }}", name))
+			}
+		}
+	}
+	return endpoints
+}
+
+// templateRoutes templates the gateway and hostnames of the routes, returning the values of the gateway and the
+// hostnames by environment. The environment of a route is resolved from the route references of the API.
+func templateRoutes(template *helmTemplate, resources []map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	environments := make(map[string]string)
+	for _, api := range getResources(resources, constants.API_KIND) {
+		spec, _ := api["spec"].(map[string]interface{})
+		for _, endpointType := range []string{constants.PRODUCTION_TYPE, constants.SANDBOX_TYPE} {
+			envConfigs, _ := spec[endpointType].([]interface{})
+			for _, envConfig := range envConfigs {
+				envConfig, _ := envConfig.(map[string]interface{})
+				routeRefs, _ := envConfig["routeRefs"].([]interface{})
+				for _, routeRef := range routeRefs {
+					if name, ok := routeRef.(string); ok {
+						environments[name] = endpointType
+					}
+				}
+			}
+		}
+	}
+
+	gateway := make(map[string]interface{})
+	hostnames := make(map[string]interface{})
+	for _, resource := range getRoutes(resources) {
+		spec, _ := resource["spec"].(map[string]interface{})
+		parentRefs, _ := spec["parentRefs"].([]interface{})
+		for _, parentRef := range parentRefs {
+			parentRef, _ := parentRef.(map[string]interface{})
+			if parentRef["kind"] != "Gateway" {
+				continue
+			}
+			templateValue(template, gateway, parentRef, "name", "name")
+			templateValue(template, gateway, parentRef, "sectionName", "listenerName")
+		}
+		if routeHostnames, ok := spec["hostnames"].([]interface{}); ok && len(routeHostnames) > 0 {
+			environment := environments[getString(resource, "metadata", "name")]
+			if environment == "" {
+				environment = constants.PRODUCTION_TYPE
+			}
+			if _, ok := hostnames[environment]; !ok {
+				hostnames[environment] = routeHostnames
+			}
+			// The hostnames are a field of the route spec, indented by two spaces.
+			spec["hostnames"] = template.placeholder("{{- toYaml .Values.hostnames." + environment + " | nindent 4 }}")
+		}
+	}
+	return gateway, hostnames
+}
+
+// templateValue templates the field of the gateway parent reference with the gateway value of the given key,
+// defaulting to the first value found. Parent references to other gateways are left as they are.
+func templateValue(template *helmTemplate, gateway map[string]interface{}, parentRef map[string]interface{}, field string, key string) {
+	value, ok := parentRef[field]
+	if !ok {
+		return
+	}
+	if _, ok := gateway[key]; !ok {
+		gateway[key] = value
+	}
+	if gateway[key] == value {
+		parentRef[field] = template.placeholder("{{ .Values.gateway." + key + " | quote }}")
+	}
+}
+
+// getAPIVersion returns the version of the first API resource.
+func getAPIVersion(resources []map[string]interface{}) string {
+	for _, api := range getResources(resources, constants.API_KIND) {
+		return getString(api, "spec", "apiVersion")
+	}
+	return ""
+}
+
+// getResources returns the resources of the given kind.
+func getResources(resources []map[string]interface{}, kind string) []map[string]interface{} {
+	var filtered []map[string]interface{}
+	for _, resource := range resources {
+		if resource["kind"] == kind {
+			filtered = append(filtered, resource)
+		}
+	}
+	return filtered
+}
+
+// getRoutes returns the HTTPRoute, GRPCRoute and GQLRoute resources.
+func getRoutes(resources []map[string]interface{}) []map[string]interface{} {
+	routes := getResources(resources, constants.HTTPROUTE_KIND)
+	routes = append(routes, getResources(resources, constants.GRPCROUTE_KIND)...)
+	return append(routes, getResources(resources, constants.GQLROUTE_KIND)...)
+}
+
+// getString returns the string field of the resource at the given path.
+func getString(resource map[string]interface{}, path ...string) string {
+	var value interface{} = resource
+	for _, key := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = object[key]
+	}
+	s, _ := value.(string)
+	return s
+}
@@ -0,0 +1,127 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+
+	"github.com/stretchr/testify/assert"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// TestWriteHelmChart test for WriteHelmChart
+func TestWriteHelmChart(t *testing.T) {
+	b := getTestBundle()
+	b.API.Spec = crds.APISpec{
+		APIVersion: "3.14",
+		Sandbox:    []crds.EnvConfig{{RouteRefs: []string{"api-sandbox-httproute-1"}}},
+	}
+	port := gwapiv1.PortNumber(8080)
+	backendKind := gwapiv1.Kind(constants.BACKEND_KIND)
+	gatewayKind := gwapiv1.Kind("Gateway")
+	listenerName := gwapiv1.SectionName("https")
+	b.HTTPRoutes[0].Name = "api-sandbox-httproute-1"
+	b.HTTPRoutes[0].Spec = gwapiv1.HTTPRouteSpec{
+		CommonRouteSpec: gwapiv1.CommonRouteSpec{
+			ParentRefs: []gwapiv1.ParentReference{{Kind: &gatewayKind, Name: "wso2-apim", SectionName: &listenerName}},
+		},
+		Hostnames: []gwapiv1.Hostname{"wso2.sandbox.gw.example.com"},
+		Rules: []gwapiv1.HTTPRouteRule{{
+			BackendRefs: []gwapiv1.HTTPBackendRef{{
+				BackendRef: gwapiv1.BackendRef{
+					BackendObjectReference: gwapiv1.BackendObjectReference{Kind: &backendKind, Name: "backend", Port: &port},
+				},
+			}},
+		}},
+	}
+	b.Backends[0].Name = "backend"
+
+	dir := t.TempDir()
+	err := WriteHelmChart(dir, Objects(b), HelmChartOptions{Name: "employee-api"})
+	assert.Nil(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "Chart.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, "apiVersion: v2\nappVersion: \"3.14\"\nname: employee-api\ntype: application\nversion: 0.1.0\n", string(data))
+
+	data, err = os.ReadFile(filepath.Join(dir, "values.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, `endpoints:
+  backend:
+    host: employee-service
+    port: 8080
+    protocol: http
+gateway:
+  listenerName: https
+  name: wso2-apim
+hostnames:
+  sandbox:
+    - wso2.sandbox.gw.example.com
+namespace: ""
+`, string(data))
+
+	data, err = os.ReadFile(filepath.Join(dir, "templates", "02-backend-backend.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, `apiVersion: dp.wso2.com/v1alpha2
+kind: Backend
+metadata:
+  name: backend
+  namespace: {{ .Values.namespace | default .Release.Namespace }}
+spec:
+  protocol: {{ (index .Values.endpoints "backend").protocol | quote }}
+  services:
+    - host: {{ (index .Values.endpoints "backend").host | quote }}
+      port: {{ (index .Values.endpoints "backend").port }}
+`, string(data))
+
+	data, err = os.ReadFile(filepath.Join(dir, "templates", "04-httproute-api-sandbox-httproute-1.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, `apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: api-sandbox-httproute-1
+  namespace: {{ .Values.namespace | default .Release.Namespace }}
+spec:
+  hostnames: {{- toYaml .Values.hostnames.sandbox | nindent 4 }}
+  parentRefs:
+    - kind: Gateway
+      name: {{ .Values.gateway.name | quote }}
+      sectionName: {{ .Values.gateway.listenerName | quote }}
+  rules:
+    - backendRefs:
+        - kind: Backend
+          name: backend
+          port: {{ (index .Values.endpoints "backend").port }}
+`, string(data))
+
+	err = WriteHelmChart(dir, Objects(b), HelmChartOptions{})
+	assert.EqualError(t, err, "no name specified for the helm chart")
+}
+
+// TestHelmTemplateRender test for helmTemplate render
+func TestHelmTemplateRender(t *testing.T) {
+	template := &helmTemplate{}
+	placeholder := template.placeholder("{{ .Values.name }}")
+	rendered := template.render([]byte("name: " + placeholder + "\nvalue: '{{ literal }}'\n"))
+	assert.Equal(t, "name: {{ .Values.name }}\nvalue: '{{ \"{{\" }} literal }}'\n", string(rendered))
+}
@@ -0,0 +1,199 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package output
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+)
+
+// KustomizationFile is the name of the Kustomize file listing the resources.
+const KustomizationFile = "kustomization.yaml"
+
+// KustomizeConfigFile is the name of the Kustomize configuration of the references between the APK resources.
+const KustomizeConfigFile = "kustomizeconfig.yaml"
+
+// KustomizeOptions holds the transformations applied by the Kustomize base to all resources.
+type KustomizeOptions struct {
+	// Namespace sets the namespace of the resources.
+	Namespace string
+	// Labels are added to the metadata of the resources.
+	Labels map[string]string
+	// NamePrefix is prepended to the names of the resources and the references to them.
+	NamePrefix string
+	// NameSuffix is appended to the names of the resources and the references to them.
+	NameSuffix string
+}
+
+// nameReference is a Kustomize name reference, listing the fields referring to the resources of a kind.
+type nameReference struct {
+	Kind       string      `yaml:"kind"`
+	Group      string      `yaml:"group,omitempty"`
+	FieldSpecs []fieldSpec `yaml:"fieldSpecs"`
+}
+
+// fieldSpec is the path of a field of the resources of a kind.
+type fieldSpec struct {
+	Kind string `yaml:"kind"`
+	Path string `yaml:"path"`
+}
+
+// policyKinds are the kinds of the policies attached to their target with a targetRef.
+var policyKinds = []string{
+	constants.AUTHENTICATION_KIND,
+	constants.RATELIMIT_POLICY_KIND,
+	constants.API_POLICY_KIND,
+	constants.AI_RATELIMIT_POLICY_KIND,
+}
+
+// nameReferences are the references between the generated resources, so that the names changed by Kustomize
+// are updated in the resources referring them as well.
+var nameReferences = []nameReference{
+	{
+		Kind: constants.CONFIGMAP_KIND,
+		FieldSpecs: []fieldSpec{
+			{Kind: constants.API_KIND, Path: "spec/definitionFileRef"},
+		},
+	},
+	{
+		Kind:  constants.BACKEND_KIND,
+		Group: constants.DP_GROUP,
+		FieldSpecs: append([]fieldSpec{
+			{Kind: constants.HTTPROUTE_KIND, Path: "spec/rules/backendRefs/name"},
+			{Kind: constants.HTTPROUTE_KIND, Path: "spec/rules/filters/requestMirror/backendRef/name"},
+			{Kind: constants.GRPCROUTE_KIND, Path: "spec/rules/backendRefs/name"},
+			{Kind: constants.GQLROUTE_KIND, Path: "spec/backendRefs/name"},
+			{Kind: constants.INTERCEPTOR_SERVICE_KIND, Path: "spec/backendRef/name"},
+		}, getPolicyFieldSpecs("spec/targetRef/name")...),
+	},
+	{
+		Kind:       constants.INTERCEPTOR_SERVICE_KIND,
+		Group:      constants.DP_GROUP,
+		FieldSpecs: append(getAPIPolicyFieldSpecs("requestInterceptors/name"), getAPIPolicyFieldSpecs("responseInterceptors/name")...),
+	},
+	{
+		Kind:  constants.SCOPE_KIND,
+		Group: constants.DP_GROUP,
+		FieldSpecs: []fieldSpec{
+			{Kind: constants.GQLROUTE_KIND, Path: "spec/rules/filters/extensionRef/name"},
+		},
+	},
+	{
+		Kind:       constants.BACKEND_JWT_KIND,
+		Group:      constants.DP_GROUP,
+		FieldSpecs: getAPIPolicyFieldSpecs("backendJwtPolicy/name"),
+	},
+	{
+		Kind:       constants.AI_PROVIDER_KIND,
+		Group:      constants.DP_GROUP,
+		FieldSpecs: getAPIPolicyFieldSpecs("aiProvider/name"),
+	},
+	{
+		Kind:  constants.HTTPROUTE_KIND,
+		Group: constants.GATEWAY_GROUP,
+		FieldSpecs: append([]fieldSpec{
+			{Kind: constants.API_KIND, Path: "spec/production/routeRefs"},
+			{Kind: constants.API_KIND, Path: "spec/sandbox/routeRefs"},
+		}, getPolicyFieldSpecs("spec/targetRef/name")...),
+	},
+	{
+		Kind:  constants.GRPCROUTE_KIND,
+		Group: constants.GATEWAY_GROUP,
+		FieldSpecs: append([]fieldSpec{
+			{Kind: constants.API_KIND, Path: "spec/production/routeRefs"},
+			{Kind: constants.API_KIND, Path: "spec/sandbox/routeRefs"},
+		}, getPolicyFieldSpecs("spec/targetRef/name")...),
+	},
+	{
+		Kind:  constants.GQLROUTE_KIND,
+		Group: constants.DP_GROUP,
+		FieldSpecs: append([]fieldSpec{
+			{Kind: constants.API_KIND, Path: "spec/production/routeRefs"},
+			{Kind: constants.API_KIND, Path: "spec/sandbox/routeRefs"},
+		}, getPolicyFieldSpecs("spec/targetRef/name")...),
+	},
+	{
+		Kind:       constants.API_KIND,
+		Group:      constants.DP_GROUP,
+		FieldSpecs: getPolicyFieldSpecs("spec/targetRef/name"),
+	},
+}
+
+// WriteKustomization writes the resources to the directory as a Kustomize base, along with the kustomization.yaml
+// listing them and the configuration of the references between the APK resources.
+func WriteKustomization(dir string, objects []Object, opts KustomizeOptions) error {
+	fileNames, err := WriteDir(dir, objects)
+	if err != nil {
+		return err
+	}
+	config, err := marshalYAML(map[string]interface{}{
+		"nameReference": nameReferences,
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, KustomizeConfigFile), config, 0o644); err != nil {
+		return err
+	}
+
+	kustomization := map[string]interface{}{
+		"apiVersion":     "kustomize.config.k8s.io/v1beta1",
+		"kind":           "Kustomization",
+		"resources":      fileNames,
+		"configurations": []string{KustomizeConfigFile},
+	}
+	if opts.Namespace != "" {
+		kustomization["namespace"] = opts.Namespace
+	}
+	if len(opts.Labels) > 0 {
+		kustomization["labels"] = []map[string]interface{}{{
+			"pairs":            opts.Labels,
+			"includeSelectors": false,
+		}}
+	}
+	if opts.NamePrefix != "" {
+		kustomization["namePrefix"] = opts.NamePrefix
+	}
+	if opts.NameSuffix != "" {
+		kustomization["nameSuffix"] = opts.NameSuffix
+	}
+	data, err := marshalYAML(kustomization)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, KustomizationFile), data, 0o644)
+}
+
+// getPolicyFieldSpecs returns the field of the given path of each policy kind.
+func getPolicyFieldSpecs(path string) []fieldSpec {
+	var fieldSpecs []fieldSpec
+	for _, kind := range policyKinds {
+		fieldSpecs = append(fieldSpecs, fieldSpec{Kind: kind, Path: path})
+	}
+	return fieldSpecs
+}
+
+// getAPIPolicyFieldSpecs returns the field of the given path in the default and override policies of the APIPolicy.
+func getAPIPolicyFieldSpecs(path string) []fieldSpec {
+	return []fieldSpec{
+		{Kind: constants.API_POLICY_KIND, Path: "spec/default/" + path},
+		{Kind: constants.API_POLICY_KIND, Path: "spec/override/" + path},
+	}
+}
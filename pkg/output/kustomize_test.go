@@ -0,0 +1,66 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWriteKustomization test for WriteKustomization
+func TestWriteKustomization(t *testing.T) {
+	dir := t.TempDir()
+	err := WriteKustomization(dir, Objects(getTestBundle()), KustomizeOptions{
+		Namespace:  "apk",
+		Labels:     map[string]string{"app": "employee-api"},
+		NameSuffix: "-v1",
+	})
+	assert.Nil(t, err)
+	data, err := os.ReadFile(filepath.Join(dir, KustomizationFile))
+	assert.Nil(t, err)
+	assert.Equal(t, `apiVersion: kustomize.config.k8s.io/v1beta1
+configurations:
+  - kustomizeconfig.yaml
+kind: Kustomization
+labels:
+  - includeSelectors: false
+    pairs:
+      app: employee-api
+nameSuffix: -v1
+namespace: apk
+resources:
+  - 01-configmap-definition.yaml
+  - 02-backend-backend.yaml
+  - 03-apipolicy-api-policy.yaml
+  - 04-httproute-api-production-httproute-1.yaml
+  - 05-api-api.yaml
+`, string(data))
+	assert.FileExists(t, filepath.Join(dir, "02-backend-backend.yaml"))
+
+	data, err = os.ReadFile(filepath.Join(dir, KustomizeConfigFile))
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `  - kind: HTTPRoute
+    group: gateway.networking.k8s.io
+    fieldSpecs:
+      - kind: API
+        path: spec/production/routeRefs
+`)
+}
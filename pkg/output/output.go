@@ -0,0 +1,217 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/bundle"
+
+	"gopkg.in/yaml.v3"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Object is a generated Kubernetes resource, holding its type and object metadata.
+type Object interface {
+	v1.Object
+	GetObjectKind() schema.ObjectKind
+}
+
+// kindOrder is the order the resources are applied in, so that the referred resources are created before the
+// resources referring them. Kinds not listed are applied last.
+var kindOrder = []string{
+	constants.CONFIGMAP_KIND,
+	constants.SECRET_KIND,
+	constants.BACKEND_KIND,
+	constants.INTERCEPTOR_SERVICE_KIND,
+	constants.BACKEND_JWT_KIND,
+	constants.AI_PROVIDER_KIND,
+	constants.SCOPE_KIND,
+	constants.AUTHENTICATION_KIND,
+	constants.RATELIMIT_POLICY_KIND,
+	constants.AI_RATELIMIT_POLICY_KIND,
+	constants.API_POLICY_KIND,
+	constants.HTTPROUTE_KIND,
+	constants.GRPCROUTE_KIND,
+	constants.GQLROUTE_KIND,
+	constants.API_KIND,
+}
+
+// Objects returns the resources of the bundle in dependency order.
+func Objects(b *bundle.Bundle) []Object {
+	var objects []Object
+	for _, configMap := range b.ConfigMaps {
+		objects = append(objects, configMap)
+	}
+	for _, backend := range b.Backends {
+		objects = append(objects, backend)
+	}
+	for _, interceptorService := range b.InterceptorServices {
+		objects = append(objects, interceptorService)
+	}
+	for _, backendJWT := range b.BackendJWTs {
+		objects = append(objects, backendJWT)
+	}
+	if b.AIProvider != nil {
+		objects = append(objects, b.AIProvider)
+	}
+	for _, scope := range b.Scopes {
+		objects = append(objects, scope)
+	}
+	for _, authentication := range b.Authentications {
+		objects = append(objects, authentication)
+	}
+	for _, rateLimitPolicy := range b.RateLimitPolicies {
+		objects = append(objects, rateLimitPolicy)
+	}
+	for _, aiRateLimitPolicy := range b.AIRateLimitPolicies {
+		objects = append(objects, aiRateLimitPolicy)
+	}
+	for _, apiPolicy := range b.APIPolicies {
+		objects = append(objects, apiPolicy)
+	}
+	for _, httpRoute := range b.HTTPRoutes {
+		objects = append(objects, httpRoute)
+	}
+	for _, grpcRoute := range b.GRPCRoutes {
+		objects = append(objects, grpcRoute)
+	}
+	for _, gqlRoute := range b.GQLRoutes {
+		objects = append(objects, gqlRoute)
+	}
+	if b.API != nil {
+		objects = append(objects, b.API)
+	}
+	return objects
+}
+
+// Sort sorts the resources in dependency order, keeping the order of the resources of the same kind.
+func Sort(objects []Object) {
+	slices.SortStableFunc(objects, func(a, b Object) int {
+		return getKindOrder(a) - getKindOrder(b)
+	})
+}
+
+// getKindOrder returns the position of the kind of the resource in the dependency order.
+func getKindOrder(object Object) int {
+	index := slices.Index(kindOrder, object.GetObjectKind().GroupVersionKind().Kind)
+	if index < 0 {
+		return len(kindOrder)
+	}
+	return index
+}
+
+// ToMap converts the resource to its generic representation, omitting the empty status and creation timestamp
+// which are set by the cluster.
+func ToMap(object Object) (map[string]interface{}, error) {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %v", getResourceName(object), err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %v", getResourceName(object), err)
+	}
+	resource, ok := normalize(value).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("failed to marshal %s: not an object", getResourceName(object))
+	}
+	if isEmpty(resource["status"]) {
+		delete(resource, "status")
+	}
+	if metadata, ok := resource["metadata"].(map[string]interface{}); ok && isEmpty(metadata["creationTimestamp"]) {
+		delete(metadata, "creationTimestamp")
+	}
+	return resource, nil
+}
+
+// MarshalYAML marshals the resource to a YAML document with the keys sorted.
+func MarshalYAML(object Object) ([]byte, error) {
+	resource, err := ToMap(object)
+	if err != nil {
+		return nil, err
+	}
+	return marshalYAML(resource)
+}
+
+// marshalYAML marshals the value to YAML with the conventional two space indentation.
+func marshalYAML(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// normalize converts the JSON numbers of the value to integers where possible, so they are not quoted in YAML.
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalize(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	}
+	return value
+}
+
+// isEmpty checks whether the value is null, or an object or array holding only empty values.
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		for _, item := range v {
+			if !isEmpty(item) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// getResourceName returns the kind and name of the resource, used in the error messages.
+func getResourceName(object Object) string {
+	return object.GetObjectKind().GroupVersionKind().Kind + " " + object.GetName()
+}
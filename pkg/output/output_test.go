@@ -0,0 +1,115 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package output
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/bundle"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// getTestBundle returns a bundle with a resource of each dependency level.
+func getTestBundle() *bundle.Bundle {
+	return &bundle.Bundle{
+		API: &crds.API{
+			TypeMeta:   v1.TypeMeta{Kind: constants.API_KIND, APIVersion: constants.DP_V1ALPHA3},
+			ObjectMeta: v1.ObjectMeta{Name: "api"},
+		},
+		HTTPRoutes: []*gwapiv1.HTTPRoute{{
+			TypeMeta:   v1.TypeMeta{Kind: constants.HTTPROUTE_KIND, APIVersion: constants.GATEWAY_V1},
+			ObjectMeta: v1.ObjectMeta{Name: "api-production-httproute-1"},
+		}},
+		Backends: []*crds.Backend{{
+			TypeMeta:   v1.TypeMeta{Kind: constants.BACKEND_KIND, APIVersion: constants.DP_V1ALPHA2},
+			ObjectMeta: v1.ObjectMeta{Name: "backend"},
+			Spec:       crds.BackendSpec{Protocol: "http", Services: []crds.BackendService{{Host: "employee-service", Port: 8080}}},
+		}},
+		APIPolicies: []*crds.APIPolicy{{
+			TypeMeta:   v1.TypeMeta{Kind: constants.API_POLICY_KIND, APIVersion: constants.DP_V1ALPHA3},
+			ObjectMeta: v1.ObjectMeta{Name: "api-policy"},
+		}},
+		ConfigMaps: []*corev1.ConfigMap{{
+			TypeMeta:   v1.TypeMeta{Kind: constants.CONFIGMAP_KIND, APIVersion: "v1"},
+			ObjectMeta: v1.ObjectMeta{Name: "definition"},
+		}},
+	}
+}
+
+// getNames returns the names of the resources.
+func getNames(objects []Object) []string {
+	var names []string
+	for _, object := range objects {
+		names = append(names, object.GetName())
+	}
+	return names
+}
+
+// TestObjects test for Objects
+func TestObjects(t *testing.T) {
+	objects := Objects(getTestBundle())
+	assert.Equal(t, []string{"definition", "backend", "api-policy", "api-production-httproute-1", "api"}, getNames(objects))
+}
+
+// TestSort test for Sort
+func TestSort(t *testing.T) {
+	b := getTestBundle()
+	secret := &corev1.Secret{
+		TypeMeta:   v1.TypeMeta{Kind: constants.SECRET_KIND, APIVersion: "v1"},
+		ObjectMeta: v1.ObjectMeta{Name: "secret"},
+	}
+	objects := []Object{b.API, b.HTTPRoutes[0], secret, b.APIPolicies[0], b.Backends[0], b.ConfigMaps[0]}
+	Sort(objects)
+	assert.Equal(t, []string{"definition", "secret", "backend", "api-policy", "api-production-httproute-1", "api"}, getNames(objects))
+}
+
+// TestToMap test for ToMap
+func TestToMap(t *testing.T) {
+	resource, err := ToMap(getTestBundle().HTTPRoutes[0])
+	assert.Nil(t, err)
+	// The empty status and creation timestamp are omitted
+	assert.NotContains(t, resource, "status")
+	assert.Equal(t, map[string]interface{}{"name": "api-production-httproute-1"}, resource["metadata"])
+
+	resource, err = ToMap(getTestBundle().Backends[0])
+	assert.Nil(t, err)
+	services := resource["spec"].(map[string]interface{})["services"].([]interface{})
+	assert.Equal(t, int64(8080), services[0].(map[string]interface{})["port"])
+}
+
+// TestMarshalYAML test for MarshalYAML
+func TestMarshalYAML(t *testing.T) {
+	data, err := MarshalYAML(getTestBundle().Backends[0])
+	assert.Nil(t, err)
+	assert.Equal(t, `apiVersion: dp.wso2.com/v1alpha2
+kind: Backend
+metadata:
+  name: backend
+spec:
+  protocol: http
+  services:
+    - host: employee-service
+      port: 8080
+`, string(data))
+}
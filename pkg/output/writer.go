@@ -0,0 +1,128 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// WriteYAML writes the resources as a multi-document YAML stream.
+func WriteYAML(w io.Writer, objects []Object) error {
+	for i, object := range objects {
+		data, err := MarshalYAML(object)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List returns the resources as the items of a Kubernetes List.
+func List(objects []Object) (*v1.List, error) {
+	list := &v1.List{
+		TypeMeta: v1.TypeMeta{
+			Kind:       "List",
+			APIVersion: "v1",
+		},
+		Items: make([]runtime.RawExtension, 0, len(objects)),
+	}
+	for _, object := range objects {
+		resource, err := ToMap(object)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(resource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %v", getResourceName(object), err)
+		}
+		list.Items = append(list.Items, runtime.RawExtension{Raw: data})
+	}
+	return list, nil
+}
+
+// WriteList writes the resources as a Kubernetes List in YAML.
+func WriteList(w io.Writer, objects []Object) error {
+	items := make([]interface{}, 0, len(objects))
+	for _, object := range objects {
+		resource, err := ToMap(object)
+		if err != nil {
+			return err
+		}
+		items = append(items, resource)
+	}
+	data, err := marshalYAML(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items":      items,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// WriteDir writes each resource to its own YAML file in the directory, creating the directory when missing.
+// The files are prefixed with their position, so applying the directory follows the order of the resources.
+// The names of the written files are returned in order.
+func WriteDir(dir string, objects []Object) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	fileNames := getFileNames(objects)
+	for i, object := range objects {
+		data, err := MarshalYAML(object)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(filepath.Join(dir, fileNames[i]), data, 0o644); err != nil {
+			return nil, err
+		}
+	}
+	return fileNames, nil
+}
+
+// getFileNames returns the file names of the resources, prefixed with their position padded to at least two digits.
+func getFileNames(objects []Object) []string {
+	width := len(strconv.Itoa(len(objects)))
+	if width < 2 {
+		width = 2
+	}
+	fileNames := make([]string, 0, len(objects))
+	for i, object := range objects {
+		fileNames = append(fileNames, fmt.Sprintf("%0*d-%s-%s.yaml", width, i+1, strings.ToLower(object.GetObjectKind().GroupVersionKind().Kind), object.GetName()))
+	}
+	return fileNames
+}
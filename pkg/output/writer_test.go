@@ -0,0 +1,79 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWriteYAML test for WriteYAML
+func TestWriteYAML(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteYAML(&buf, Objects(getTestBundle()))
+	assert.Nil(t, err)
+	documents := strings.Split(buf.String(), "---\n")
+	assert.Len(t, documents, 5)
+	assert.Contains(t, documents[0], "kind: ConfigMap\n")
+	assert.Contains(t, documents[4], "kind: API\n")
+	assert.NotContains(t, buf.String(), "status")
+	assert.NotContains(t, buf.String(), "creationTimestamp")
+}
+
+// TestList test for List
+func TestList(t *testing.T) {
+	list, err := List(Objects(getTestBundle()))
+	assert.Nil(t, err)
+	assert.Len(t, list.Items, 5)
+	data, err := json.Marshal(list)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"items":[{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"definition"}}`)
+}
+
+// TestWriteList test for WriteList
+func TestWriteList(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteList(&buf, Objects(getTestBundle()))
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(buf.String(), "apiVersion: v1\nitems:\n  - apiVersion: v1\n    kind: ConfigMap\n"))
+	assert.True(t, strings.HasSuffix(buf.String(), "kind: List\n"))
+}
+
+// TestWriteDir test for WriteDir
+func TestWriteDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "resources")
+	fileNames, err := WriteDir(dir, Objects(getTestBundle()))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"01-configmap-definition.yaml",
+		"02-backend-backend.yaml",
+		"03-apipolicy-api-policy.yaml",
+		"04-httproute-api-production-httproute-1.yaml",
+		"05-api-api.yaml",
+	}, fileNames)
+	data, err := os.ReadFile(filepath.Join(dir, fileNames[1]))
+	assert.Nil(t, err)
+	expected, _ := MarshalYAML(getTestBundle().Backends[0])
+	assert.Equal(t, string(expected), string(data))
+}
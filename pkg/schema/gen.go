@@ -0,0 +1,39 @@
+//go:build ignore
+
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// gen writes the apk-conf schema derived from the config types to apk-conf.schema.json.
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/schema"
+)
+
+func main() {
+	data, err := json.MarshalIndent(schema.Generate(), "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to marshal the apk-conf schema: %v", err)
+	}
+	if err := os.WriteFile("apk-conf.schema.json", append(data, '\n'), 0644); err != nil {
+		log.Fatalf("Failed to write the apk-conf schema: %v", err)
+	}
+}
@@ -0,0 +1,217 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package schema
+
+//go:generate go run gen.go
+
+import (
+	_ "embed"
+	"reflect"
+	"strings"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+)
+
+// Draft is the JSON Schema dialect of the generated schema.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// ID is the identifier of the apk-conf schema.
+const ID = "https://github.com/terance-edmonds/wso2-apk-k8s-go-lib/apk-conf.schema.json"
+
+//go:embed apk-conf.schema.json
+var apkConfSchema []byte
+
+// Schema represents the subset of JSON Schema used to describe the apk-conf format.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Const                interface{}        `json:"const,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Minimum              *int               `json:"minimum,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// policyParameters holds the parameters type of each policy name.
+var policyParameters = []struct {
+	policyName string
+	parameters reflect.Type
+}{
+	{constants.POLICY_ADD_HEADER, reflect.TypeOf(types.Header{})},
+	{constants.POLICY_SET_HEADER, reflect.TypeOf(types.Header{})},
+	{constants.POLICY_REMOVE_HEADER, reflect.TypeOf(types.Header{})},
+	{constants.POLICY_REQUEST_MIRROR, reflect.TypeOf(types.URLList{})},
+	{constants.POLICY_REQUEST_REDIRECT, reflect.TypeOf(types.RedirectPolicy{})},
+	{constants.POLICY_INTERCEPTOR, reflect.TypeOf(types.InterceptorService{})},
+	{constants.POLICY_BACKEND_JWT, reflect.TypeOf(types.BackendJWT{})},
+}
+
+// requiredProperties holds the required properties of the apk-conf types.
+var requiredProperties = map[reflect.Type][]string{
+	reflect.TypeOf(types.APKConf{}):            {"name", "basePath", "version"},
+	reflect.TypeOf(types.Operation{}):          {"target", "verb"},
+	reflect.TypeOf(types.OperationPolicy{}):    {"policyName"},
+	reflect.TypeOf(types.K8sService{}):         {"name", "port"},
+	reflect.TypeOf(types.Header{}):             {"headerName"},
+	reflect.TypeOf(types.URLList{}):            {"urls"},
+	reflect.TypeOf(types.RedirectPolicy{}):     {"url"},
+	reflect.TypeOf(types.InterceptorService{}): {"backendUrl"},
+	reflect.TypeOf(types.AuthConfiguration{}):  {"authType"},
+	reflect.TypeOf(types.RateLimit{}):          {"requestsPerUnit", "unit"},
+	reflect.TypeOf(types.AIProvider{}):         {"name"},
+}
+
+// propertySchemas overrides the schemas derived from the Go types for the properties, keyed by "<type>.<property>".
+var propertySchemas = map[string]*Schema{
+	"APKConf.type":                   enum(constants.API_TYPE_REST, constants.API_TYPE_GRAPHQL, constants.API_TYPE_GRPC, constants.API_TYPE_ASYNC, constants.API_TYPE_SOAP, constants.API_TYPE_SSE, constants.API_TYPE_WS, constants.API_TYPE_WEBSUB),
+	"APKConf.basePath":               {Type: "string", Pattern: "^/"},
+	"RateLimit.requestsPerUnit":      {Type: "integer", Minimum: intPtr(1)},
+	"RateLimit.unit":                 enum(constants.RATELIMIT_UNIT_MINUTE, constants.RATELIMIT_UNIT_HOUR, constants.RATELIMIT_UNIT_DAY),
+	"TokenAIRL.unit":                 enum(constants.RATELIMIT_UNIT_MINUTE, constants.RATELIMIT_UNIT_HOUR, constants.RATELIMIT_UNIT_DAY),
+	"RequestAIRL.unit":               enum(constants.RATELIMIT_UNIT_MINUTE, constants.RATELIMIT_UNIT_HOUR, constants.RATELIMIT_UNIT_DAY),
+	"AuthConfiguration.authType":     enum(constants.AUTH_TYPE_OAUTH2, constants.AUTH_TYPE_API_KEY, constants.AUTH_TYPE_MTLS, constants.AUTH_TYPE_JWT),
+	"AuthConfiguration.required":     enum("mandatory", "optional"),
+	"K8sService.port":                {OneOf: []*Schema{{Type: "string", Pattern: "^[0-9]+$"}, {Type: "integer", Minimum: intPtr(1)}}},
+	"K8sService.protocol":            enum("http", "https", "ws", "wss"),
+	"OperationPolicy.policyName":     enum(constants.POLICY_ADD_HEADER, constants.POLICY_SET_HEADER, constants.POLICY_REMOVE_HEADER, constants.POLICY_REQUEST_MIRROR, constants.POLICY_REQUEST_REDIRECT, constants.POLICY_INTERCEPTOR, constants.POLICY_BACKEND_JWT),
+	"RedirectPolicy.statusCode":      {Type: "integer", Enum: []interface{}{301, 302}},
+	"BackendJWT.encoding":            enum(constants.BACKEND_JWT_ENCODING_BASE64, constants.BACKEND_JWT_ENCODING_BASE64URL),
+	"BackendJWT.signingAlgorithm":    enum(constants.BACKEND_JWT_ALGORITHM_SHA256_RSA, constants.BACKEND_JWT_ALGORITHM_SHA384_RSA, constants.BACKEND_JWT_ALGORITHM_SHA512_RSA),
+	"BackendJWT.tokenTTL":            {Type: "integer", Minimum: intPtr(0)},
+	"OperationPolicy.parameters":     {Type: "object"},
+	"EndpointConfiguration.endpoint": {Ref: "#/$defs/Endpoint"},
+}
+
+// APKConfSchema returns the embedded JSON Schema of the apk-conf format.
+func APKConfSchema() []byte {
+	return append([]byte(nil), apkConfSchema...)
+}
+
+// Generate derives the JSON Schema of the apk-conf format from the types.APKConf struct tree.
+// Endpoints are either a URL or a Kubernetes service, and the policy parameters depend on the policy name.
+func Generate() *Schema {
+	root := &Schema{
+		Schema: Draft,
+		ID:     ID,
+		Title:  "APK Configuration",
+		Defs:   make(map[string]*Schema),
+	}
+	rootSchema := generateType(reflect.TypeOf(types.APKConf{}), root.Defs)
+	root.Ref = rootSchema.Ref
+
+	root.Defs["Endpoint"] = &Schema{
+		OneOf: []*Schema{
+			{Type: "string", Pattern: "^(https?|wss?)://"},
+			generateType(reflect.TypeOf(types.K8sService{}), root.Defs),
+		},
+	}
+	operationPolicy := root.Defs["OperationPolicy"]
+	for _, policy := range policyParameters {
+		operationPolicy.OneOf = append(operationPolicy.OneOf, &Schema{
+			Properties: map[string]*Schema{
+				"policyName": {Const: policy.policyName},
+				"parameters": generateType(policy.parameters, root.Defs),
+			},
+		})
+	}
+	return root
+}
+
+// generateType generates the schema of the given Go type, adding the schemas of the struct types to the definitions.
+func generateType(t reflect.Type, defs map[string]*Schema) *Schema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return generateType(t.Elem(), defs)
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: generateType(t.Elem(), defs)}
+	case reflect.Struct:
+		ref := &Schema{Ref: "#/$defs/" + t.Name()}
+		if _, ok := defs[t.Name()]; ok {
+			return ref
+		}
+		additionalProperties := false
+		structSchema := &Schema{
+			Type:                 "object",
+			Properties:           make(map[string]*Schema),
+			Required:             requiredProperties[t],
+			AdditionalProperties: &additionalProperties,
+		}
+		defs[t.Name()] = structSchema
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := getPropertyName(field)
+			if name == "" {
+				continue
+			}
+			if propertySchema, ok := propertySchemas[t.Name()+"."+name]; ok {
+				copied := *propertySchema
+				structSchema.Properties[name] = &copied
+			} else {
+				structSchema.Properties[name] = generateType(field.Type, defs)
+			}
+		}
+		return ref
+	}
+	return &Schema{}
+}
+
+// getPropertyName returns the YAML property name of the struct field, or an empty name when the field is skipped.
+func getPropertyName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+// enum returns the schema of a string with the given allowed values.
+func enum(values ...string) *Schema {
+	enumSchema := &Schema{Type: "string"}
+	for _, value := range values {
+		enumSchema.Enum = append(enumSchema.Enum, value)
+	}
+	return enumSchema
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,63 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	schema := Generate()
+	assert.Equal(t, Draft, schema.Schema)
+	assert.Equal(t, "#/$defs/APKConf", schema.Ref)
+
+	apkConf := schema.Defs["APKConf"]
+	assert.Equal(t, "object", apkConf.Type)
+	assert.Equal(t, []string{"name", "basePath", "version"}, apkConf.Required)
+	assert.False(t, *apkConf.AdditionalProperties)
+	assert.Equal(t, "array", apkConf.Properties["operations"].Type)
+	assert.Equal(t, "#/$defs/Operation", apkConf.Properties["operations"].Items.Ref)
+	assert.Contains(t, apkConf.Properties["type"].Enum, "GRAPHQL")
+
+	endpoint := schema.Defs["Endpoint"]
+	assert.Len(t, endpoint.OneOf, 2)
+	assert.Equal(t, "string", endpoint.OneOf[0].Type)
+	assert.Equal(t, "#/$defs/K8sService", endpoint.OneOf[1].Ref)
+	assert.Equal(t, "#/$defs/Endpoint", schema.Defs["EndpointConfiguration"].Properties["endpoint"].Ref)
+
+	operationPolicy := schema.Defs["OperationPolicy"]
+	assert.Len(t, operationPolicy.OneOf, len(policyParameters))
+	assert.Equal(t, "RequestMirror", operationPolicy.OneOf[3].Properties["policyName"].Const)
+	assert.Equal(t, "#/$defs/URLList", operationPolicy.OneOf[3].Properties["parameters"].Ref)
+}
+
+func TestAPKConfSchema(t *testing.T) {
+	data, err := json.MarshalIndent(Generate(), "", "  ")
+	assert.Nil(t, err)
+	assert.Equal(t, string(data)+"\n", string(APKConfSchema()), "apk-conf.schema.json is outdated, run go generate ./pkg/schema")
+
+	schema, err := Parse(APKConfSchema())
+	assert.Nil(t, err)
+	assert.Equal(t, ID, schema.ID)
+
+	_, err = Parse([]byte("{"))
+	assert.NotNil(t, err)
+}
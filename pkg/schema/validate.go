@@ -0,0 +1,308 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	embeddedSchema     *Schema
+	embeddedSchemaErr  error
+	embeddedSchemaOnce sync.Once
+)
+
+// yamlErrorLine matches the line number reported in the YAML syntax errors.
+var yamlErrorLine = regexp.MustCompile(`line (\d+)`)
+
+// Parse parses a JSON Schema document, such as the embedded apk-conf schema.
+func Parse(data []byte) (*Schema, error) {
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("invalid json schema: %v", err)
+	}
+	return &schema, nil
+}
+
+// ValidateYAML validates the YAML documents against the embedded apk-conf schema.
+func ValidateYAML(data []byte) diagnostics.Diagnostics {
+	embeddedSchemaOnce.Do(func() {
+		embeddedSchema, embeddedSchemaErr = Parse(apkConfSchema)
+	})
+	if embeddedSchemaErr != nil {
+		return diagnostics.FromError("", embeddedSchemaErr)
+	}
+	return embeddedSchema.ValidateYAML(data)
+}
+
+// ValidateYAML validates the YAML documents against the schema and returns every problem found,
+// located by the field path, line and column in the document.
+func (s *Schema) ValidateYAML(data []byte) diagnostics.Diagnostics {
+	var diags diagnostics.Diagnostics
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var document yaml.Node
+		if err := decoder.Decode(&document); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			diagnostic := diagnostics.Diagnostic{Severity: diagnostics.Error, Message: fmt.Sprintf("invalid yaml: %v", err)}
+			if match := yamlErrorLine.FindStringSubmatch(err.Error()); match != nil {
+				diagnostic.Line, _ = strconv.Atoi(match[1])
+			}
+			return append(diags, diagnostic)
+		}
+		if len(document.Content) == 0 {
+			continue
+		}
+		diags = append(diags, s.validate(s, document.Content[0], "")...)
+	}
+	return diags
+}
+
+// validate validates the YAML node against the given schema, resolving the references from the root schema s.
+func (s *Schema) validate(schema *Schema, node *yaml.Node, path string) diagnostics.Diagnostics {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	var diags diagnostics.Diagnostics
+	if schema.Ref != "" {
+		refSchema := s.resolve(schema.Ref)
+		if refSchema == nil {
+			return append(diags, errorAt(node, path, "unresolved schema reference %q", schema.Ref))
+		}
+		diags = append(diags, s.validate(refSchema, node, path)...)
+	}
+	if schema.Type != "" && !matchesType(node, schema.Type) {
+		return append(diags, errorAt(node, path, "expected %s, found %s", schema.Type, getNodeType(node)))
+	}
+	if schema.Const != nil && !matchesValue(node, schema.Const) {
+		diags = append(diags, errorAt(node, path, "value must be %v", schema.Const))
+	}
+	if len(schema.Enum) > 0 && !matchesEnum(node, schema.Enum) {
+		var values []string
+		for _, value := range schema.Enum {
+			values = append(values, fmt.Sprint(value))
+		}
+		diags = append(diags, errorAt(node, path, "value must be one of %s, found %q", strings.Join(values, ", "), node.Value))
+	}
+	if schema.Pattern != "" && node.Kind == yaml.ScalarNode && node.Tag == "!!str" {
+		if pattern, err := regexp.Compile(schema.Pattern); err == nil && !pattern.MatchString(node.Value) {
+			diags = append(diags, errorAt(node, path, "value %q does not match the pattern %q", node.Value, schema.Pattern))
+		}
+	}
+	if schema.Minimum != nil && node.Kind == yaml.ScalarNode && (node.Tag == "!!int" || node.Tag == "!!float") {
+		var value float64
+		if err := node.Decode(&value); err == nil && value < float64(*schema.Minimum) {
+			diags = append(diags, errorAt(node, path, "value must be at least %d, found %s", *schema.Minimum, node.Value))
+		}
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			propertyPath := diagnostics.JoinPath(path, key.Value)
+			if propertySchema, ok := schema.Properties[key.Value]; ok {
+				diags = append(diags, s.validate(propertySchema, node.Content[i+1], propertyPath)...)
+			} else if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+				diags = append(diags, errorAt(key, propertyPath, "unknown property %q", key.Value))
+			}
+		}
+		for _, name := range schema.Required {
+			if getProperty(node, name) == nil {
+				diags = append(diags, errorAt(node, diagnostics.JoinPath(path, name), "property %q is required", name))
+			}
+		}
+	case yaml.SequenceNode:
+		if schema.Items != nil {
+			for i, item := range node.Content {
+				diags = append(diags, s.validate(schema.Items, item, diagnostics.Index(path, i))...)
+			}
+		}
+	}
+
+	if len(schema.OneOf) > 0 {
+		diags = append(diags, s.validateOneOf(schema.OneOf, node, path, diags.HasErrors())...)
+	}
+	return diags
+}
+
+// validateOneOf validates the YAML node against the variants it applies to, i.e. the variants of its type with
+// matching constant properties. The problems of the closest variant are reported when none of them match.
+func (s *Schema) validateOneOf(variants []*Schema, node *yaml.Node, path string, hasErrors bool) diagnostics.Diagnostics {
+	var variantDiags []diagnostics.Diagnostics
+	matches := 0
+	for _, variant := range variants {
+		if !s.appliesTo(variant, node) {
+			continue
+		}
+		diags := s.validate(variant, node, path)
+		if !diags.HasErrors() {
+			matches++
+		}
+		variantDiags = append(variantDiags, diags)
+	}
+	switch {
+	case matches == 1:
+		return nil
+	case matches > 1:
+		return diagnostics.Diagnostics{errorAt(node, path, "value matches more than one of the allowed variants")}
+	case len(variantDiags) == 0:
+		if hasErrors {
+			return nil
+		}
+		return diagnostics.Diagnostics{errorAt(node, path, "value does not match any of the allowed variants")}
+	}
+	closest := variantDiags[0]
+	for _, diags := range variantDiags[1:] {
+		if len(diags) < len(closest) {
+			closest = diags
+		}
+	}
+	return closest
+}
+
+// appliesTo reports whether the variant applies to the YAML node, based on its type and constant properties.
+func (s *Schema) appliesTo(variant *Schema, node *yaml.Node) bool {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if variant.Ref != "" {
+		if refSchema := s.resolve(variant.Ref); refSchema != nil {
+			variant = refSchema
+		}
+	}
+	if variant.Type != "" && !matchesType(node, variant.Type) {
+		return false
+	}
+	for name, propertySchema := range variant.Properties {
+		if propertySchema.Const == nil {
+			continue
+		}
+		value := getProperty(node, name)
+		if value == nil || !matchesValue(value, propertySchema.Const) {
+			return false
+		}
+	}
+	return true
+}
+
+// resolve returns the schema definition referred by the given reference, e.g. #/$defs/Operation.
+func (s *Schema) resolve(ref string) *Schema {
+	if name, ok := strings.CutPrefix(ref, "#/$defs/"); ok {
+		return s.Defs[name]
+	}
+	if ref == "#" {
+		return s
+	}
+	return nil
+}
+
+// getProperty returns the value node of the given property of a mapping node, or nil when it is not set.
+func getProperty(node *yaml.Node, name string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == name {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// getNodeType returns the JSON Schema type name of the YAML node.
+func getNodeType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch node.Tag {
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!bool":
+		return "boolean"
+	case "!!null":
+		return "null"
+	}
+	return "string"
+}
+
+// matchesType reports whether the YAML node is of the given JSON Schema type.
+func matchesType(node *yaml.Node, schemaType string) bool {
+	nodeType := getNodeType(node)
+	return nodeType == schemaType || (schemaType == "number" && nodeType == "integer")
+}
+
+// matchesValue reports whether the YAML node holds the given JSON value.
+func matchesValue(node *yaml.Node, value interface{}) bool {
+	if node.Kind != yaml.ScalarNode {
+		return false
+	}
+	switch v := value.(type) {
+	case string:
+		return getNodeType(node) == "string" && node.Value == v
+	case bool:
+		var nodeValue bool
+		return getNodeType(node) == "boolean" && node.Decode(&nodeValue) == nil && nodeValue == v
+	case int:
+		return matchesValue(node, float64(v))
+	case float64:
+		var nodeValue float64
+		return matchesType(node, "number") && node.Decode(&nodeValue) == nil && nodeValue == v
+	}
+	return false
+}
+
+// matchesEnum reports whether the YAML node holds one of the given JSON values.
+func matchesEnum(node *yaml.Node, values []interface{}) bool {
+	for _, value := range values {
+		if matchesValue(node, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// errorAt returns an error diagnostic on the given path, positioned at the YAML node.
+func errorAt(node *yaml.Node, path string, format string, args ...interface{}) diagnostics.Diagnostic {
+	return diagnostics.Diagnostic{
+		Severity: diagnostics.Error,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+		Line:     node.Line,
+		Column:   node.Column,
+	}
+}
@@ -0,0 +1,74 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package schema
+
+import (
+	"os"
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateYAML(t *testing.T) {
+	data, err := os.ReadFile("../../examples/assets/example.apk-conf")
+	assert.Nil(t, err)
+	assert.Empty(t, ValidateYAML(data))
+
+	invalid := `name: "EmployeeServiceAPI"
+basePath: "employees-info"
+type: "RPC"
+endpointConfigurations:
+  production:
+    endpoint:
+      name: "employee-service"
+      port: 8080
+  sandbox:
+    endpoint: 8080
+rateLimit:
+  unit: Week
+  requestsPerUnit: 0
+operations:
+  - target: "/employees"
+    verb: "GET"
+    secure: true
+    operationPolicies:
+      request:
+        - policyName: "RequestMirror"
+          parameters:
+            url: "http://mirror"
+        - policyName: "Unknown"
+`
+	diags := ValidateYAML([]byte(invalid))
+	assert.Equal(t, diagnostics.Diagnostics{
+		{Severity: diagnostics.Error, Path: "basePath", Message: `value "employees-info" does not match the pattern "^/"`, Line: 2, Column: 11},
+		{Severity: diagnostics.Error, Path: "type", Message: `value must be one of REST, GRAPHQL, GRPC, ASYNC, SOAP, SSE, WS, WEBSUB, found "RPC"`, Line: 3, Column: 7},
+		{Severity: diagnostics.Error, Path: "endpointConfigurations.sandbox.endpoint", Message: "value does not match any of the allowed variants", Line: 10, Column: 15},
+		{Severity: diagnostics.Error, Path: "rateLimit.unit", Message: `value must be one of Minute, Hour, Day, found "Week"`, Line: 12, Column: 9},
+		{Severity: diagnostics.Error, Path: "rateLimit.requestsPerUnit", Message: "value must be at least 1, found 0", Line: 13, Column: 20},
+		{Severity: diagnostics.Error, Path: "operations[0].secure", Message: `unknown property "secure"`, Line: 17, Column: 5},
+		{Severity: diagnostics.Error, Path: "operations[0].operationPolicies.request[0].parameters.url", Message: `unknown property "url"`, Line: 22, Column: 13},
+		{Severity: diagnostics.Error, Path: "operations[0].operationPolicies.request[0].parameters.urls", Message: `property "urls" is required`, Line: 22, Column: 13},
+		{Severity: diagnostics.Error, Path: "operations[0].operationPolicies.request[1].policyName", Message: `value must be one of AddHeader, SetHeader, RemoveHeader, RequestMirror, RequestRedirect, Interceptor, BackendJwt, found "Unknown"`, Line: 23, Column: 23},
+		{Severity: diagnostics.Error, Path: "version", Message: `property "version" is required`, Line: 1, Column: 1},
+	}, diags)
+
+	diags = ValidateYAML([]byte("name: a\n  b: c\n"))
+	assert.EqualError(t, diags.Err(), "error: 2: invalid yaml: yaml: line 2: mapping values are not allowed in this context")
+}
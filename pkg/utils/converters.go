@@ -19,7 +19,7 @@ package utils
 
 import (
 	"encoding/json"
-	"log"
+	"fmt"
 	"os"
 
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
@@ -27,37 +27,37 @@ import (
 )
 
 // ReadAPKConf reads the APK configuration from the file
-func ReadAPKConf(configFile string) *types.APKConf {
+func ReadAPKConf(configFile string) (*types.APKConf, error) {
 	var apkConf types.APKConf
 
 	yamlFile, err := os.ReadFile(configFile)
 	if err != nil {
-		log.Printf("yamlFile.Get err   #%v ", err)
+		return nil, fmt.Errorf("failed to read APK configuration: %w", err)
 	}
 	err = yaml.Unmarshal(yamlFile, &apkConf)
 	if err != nil {
-		log.Fatalf("Unmarshal: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal APK configuration: %w", err)
 	}
 
-	return &apkConf
+	return &apkConf, nil
 }
 
 // APKConfToJSON converts the APK configuration to JSON
-func APKConfToJSON(apkConf *types.APKConf) []byte {
+func APKConfToJSON(apkConf *types.APKConf) ([]byte, error) {
 	jsonBytes, err := json.MarshalIndent(apkConf, "", " ")
 	if err != nil {
-		log.Fatalf("Failed to marshal APK configuration to JSON: %v", err)
+		return nil, fmt.Errorf("failed to marshal APK configuration to JSON: %w", err)
 	}
 
-	return jsonBytes
+	return jsonBytes, nil
 }
 
 // APKConfToYAML converts the APK configuration to YAML
-func APKConfToYAML(apkConf *types.APKConf) []byte {
+func APKConfToYAML(apkConf *types.APKConf) ([]byte, error) {
 	yamlBytes, err := yaml.Marshal(apkConf)
 	if err != nil {
-		log.Fatalf("Failed to marshal APK configuration to YAML: %v", err)
+		return nil, fmt.Errorf("failed to marshal APK configuration to YAML: %w", err)
 	}
 
-	return yamlBytes
+	return yamlBytes, nil
 }
@@ -6,9 +6,11 @@ import (
 	"encoding/json"
 	"os"
 	"reflect"
+	"strings"
 
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
 	"gopkg.in/yaml.v2"
+	"k8s.io/utils/ptr"
 )
 
 func TestReadAPKConf(t *testing.T) {
@@ -31,14 +33,14 @@ func TestReadAPKConf(t *testing.T) {
 		Authentication: &[]types.AuthConfiguration{
 			{
 				AuthType: "APIKey",
-				Enabled:  true,
+				Enabled:  ptr.To(true),
 			},
 		},
 		Operations: &[]types.Operation{
-			{Target: "/employees", Verb: "GET", Secured: true},
-			{Target: "/employee", Verb: "POST", Secured: true},
-			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: true},
-			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: true},
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true)},
+			{Target: "/employee", Verb: "POST", Secured: ptr.To(true)},
+			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: ptr.To(true)},
+			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: ptr.To(true)},
 		},
 	}
 
@@ -59,7 +61,10 @@ func TestReadAPKConf(t *testing.T) {
 	if err := tmpFile.Close(); err != nil {
 		t.Fatalf("Failed to close temp file: %v", err)
 	}
-	result := ReadAPKConf(tmpFile.Name())
+	result, err := ReadAPKConf(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("ReadAPKConf() error = %v", err)
+	}
 	if !reflect.DeepEqual(result, expected) {
 		t.Errorf("ReadAPKConf() = %v, want %v", result, expected)
 	}
@@ -85,14 +90,14 @@ func TestAPKConfToJSON(t *testing.T) {
 		Authentication: &[]types.AuthConfiguration{
 			{
 				AuthType: "APIKey",
-				Enabled:  true,
+				Enabled:  ptr.To(true),
 			},
 		},
 		Operations: &[]types.Operation{
-			{Target: "/employees", Verb: "GET", Secured: true},
-			{Target: "/employee", Verb: "POST", Secured: true},
-			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: true},
-			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: true},
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true)},
+			{Target: "/employee", Verb: "POST", Secured: ptr.To(true)},
+			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: ptr.To(true)},
+			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: ptr.To(true)},
 		},
 	}
 
@@ -101,7 +106,10 @@ func TestAPKConfToJSON(t *testing.T) {
 		t.Fatalf("Failed to marshal expected APKConf to JSON: %v", err)
 	}
 
-	result := APKConfToJSON(apkConf)
+	result, err := APKConfToJSON(apkConf)
+	if err != nil {
+		t.Fatalf("APKConfToJSON() error = %v", err)
+	}
 	if !reflect.DeepEqual(result, expected) {
 		t.Errorf("APKConfToJSON() = %s, want %s", result, expected)
 	}
@@ -127,14 +135,14 @@ func TestAPKConfToYAML(t *testing.T) {
 		Authentication: &[]types.AuthConfiguration{
 			{
 				AuthType: "APIKey",
-				Enabled:  true,
+				Enabled:  ptr.To(true),
 			},
 		},
 		Operations: &[]types.Operation{
-			{Target: "/employees", Verb: "GET", Secured: true},
-			{Target: "/employee", Verb: "POST", Secured: true},
-			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: true},
-			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: true},
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true)},
+			{Target: "/employee", Verb: "POST", Secured: ptr.To(true)},
+			{Target: "/employee/{employeeId}", Verb: "PUT", Secured: ptr.To(true)},
+			{Target: "/employee/{employeeId}", Verb: "DELETE", Secured: ptr.To(true)},
 		},
 	}
 
@@ -143,8 +151,113 @@ func TestAPKConfToYAML(t *testing.T) {
 		t.Fatalf("Failed to marshal expected APKConf to YAML: %v", err)
 	}
 
-	result := APKConfToYAML(apkConf)
+	result, err := APKConfToYAML(apkConf)
+	if err != nil {
+		t.Fatalf("APKConfToYAML() error = %v", err)
+	}
 	if !reflect.DeepEqual(result, expected) {
 		t.Errorf("APKConfToYAML() = %s, want %s", result, expected)
 	}
 }
+
+func TestReadAPKConfErrors(t *testing.T) {
+	if _, err := ReadAPKConf("does-not-exist.apk-conf"); err == nil {
+		t.Errorf("Expected an error for a missing file, got nil")
+	}
+
+	tmpFile, err := os.CreateTemp("", "apkconf-*.yaml")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.WriteString("name: [EmployeeServiceAPI"); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		t.Fatalf("Failed to close temp file: %v", err)
+	}
+	if _, err := ReadAPKConf(tmpFile.Name()); err == nil {
+		t.Errorf("Expected an error for an invalid APK configuration, got nil")
+	}
+}
+
+func TestOperationPolicyRoundTrip(t *testing.T) {
+	apkConf := &types.APKConf{
+		Name:     "EmployeeServiceAPI",
+		Version:  "3.14",
+		BasePath: "/employees-info",
+		Type:     "REST",
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.K8sService{Name: "employee-service", Namespace: "default", Port: "8080", Protocol: "http"},
+			},
+		},
+		Operations: &[]types.Operation{
+			{
+				Target: "/employees",
+				Verb:   "GET",
+				OperationPolicies: &types.OperationPolicies{
+					Request: []types.OperationPolicy{
+						{PolicyName: "AddHeader", PolicyVersion: "v1", Parameters: types.Header{HeaderName: "x-request", HeaderValue: "value"}},
+						{PolicyName: "RemoveHeader", PolicyVersion: "v1", Parameters: types.Header{HeaderName: "x-remove"}},
+						{PolicyName: "RequestMirror", PolicyVersion: "v1", Parameters: types.URLList{URLs: []string{"http://mirror-service:8080"}}},
+						{PolicyName: "RequestRedirect", PolicyVersion: "v1", Parameters: types.RedirectPolicy{URL: "https://example.com/redirect", StatusCode: 301}},
+						{PolicyName: "Interceptor", PolicyVersion: "v1", Parameters: types.InterceptorService{BackendURL: "http://interceptor-service:8443", HeadersEnabled: true}},
+						{PolicyName: "BackendJwt", PolicyVersion: "v1", Parameters: types.BackendJWT{Encoding: "base64", SigningAlgorithm: "SHA256withRSA", TokenTTL: 3600}},
+					},
+					Response: []types.OperationPolicy{
+						{PolicyName: "SetHeader", PolicyVersion: "v1", Parameters: types.Header{HeaderName: "x-response", HeaderValue: "value"}},
+					},
+				},
+			},
+		},
+	}
+
+	yamlData, err := APKConfToYAML(apkConf)
+	if err != nil {
+		t.Fatalf("Failed to marshal APKConf to YAML: %v", err)
+	}
+	var fromYAML types.APKConf
+	if err := yaml.Unmarshal(yamlData, &fromYAML); err != nil {
+		t.Fatalf("Failed to unmarshal YAML APKConf: %v", err)
+	}
+	if !reflect.DeepEqual(&fromYAML, apkConf) {
+		t.Errorf("YAML round trip = %v, want %v", fromYAML, apkConf)
+	}
+
+	jsonData, err := APKConfToJSON(apkConf)
+	if err != nil {
+		t.Fatalf("Failed to marshal APKConf to JSON: %v", err)
+	}
+	var fromJSON types.APKConf
+	if err := json.Unmarshal(jsonData, &fromJSON); err != nil {
+		t.Fatalf("Failed to unmarshal JSON APKConf: %v", err)
+	}
+	if !reflect.DeepEqual(&fromJSON, apkConf) {
+		t.Errorf("JSON round trip = %v, want %v", fromJSON, apkConf)
+	}
+}
+
+func TestOperationPolicyUnknownPolicy(t *testing.T) {
+	data := []byte(`
+name: "EmployeeServiceAPI"
+operations:
+- target: "/employees"
+  verb: "GET"
+  operationPolicies:
+    request:
+    - policyName: "UnknownPolicy"
+      parameters:
+        headerName: "x-request"
+`)
+
+	var apkConf types.APKConf
+	err := yaml.Unmarshal(data, &apkConf)
+	if err == nil {
+		t.Fatalf("Expected an error for an unknown policy, got nil")
+	}
+	if !strings.Contains(err.Error(), "UnknownPolicy") {
+		t.Errorf("Expected error to name the unknown policy, got %v", err)
+	}
+}
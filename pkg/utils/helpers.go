@@ -18,16 +18,44 @@
 package utils
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/crds"
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
 
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
+// urlSchemes holds the supported endpoint URL schemes with their default ports.
+var urlSchemes = []struct {
+	scheme string
+	port   int
+}{
+	{"https", 443},
+	{"http", 80},
+	{"wss", 443},
+	{"ws", 80},
+}
+
+// splitURL splits the URL into its scheme, default port and the rest of the URL after the scheme.
+// The scheme is empty when the URL does not use a supported scheme.
+func splitURL(url string) (string, int, string) {
+	for _, urlScheme := range urlSchemes {
+		if strings.HasPrefix(url, urlScheme.scheme+"://") {
+			return urlScheme.scheme, urlScheme.port, url[len(urlScheme.scheme)+3:]
+		}
+	}
+	return "", 0, ""
+}
+
 // GetHost extracts the host from a given URL
 func GetHost(endpoint types.Endpoint) string {
 	var url string
@@ -39,12 +67,8 @@ func GetHost(endpoint types.Endpoint) string {
 		url = ConstructURlFromK8sService(v)
 	}
 
-	var host string
-	if len(url) >= 8 && url[:8] == "https://" {
-		host = url[8:]
-	} else if len(url) >= 7 && url[:7] == "http://" {
-		host = url[7:]
-	} else {
+	scheme, _, host := splitURL(url)
+	if scheme == "" {
 		return ""
 	}
 
@@ -57,7 +81,7 @@ func GetHost(endpoint types.Endpoint) string {
 	}
 }
 
-// GetPort extracts the port from a given URL
+// GetPort extracts the port from a given URL, defaulting to the port of its scheme
 func GetPort(endpoint interface{}) int {
 	var url string
 	switch v := endpoint.(type) {
@@ -66,15 +90,8 @@ func GetPort(endpoint interface{}) int {
 	default:
 		url = ConstructURlFromK8sService(endpoint)
 	}
-	var hostPort string
-	var protocol string
-	if strings.HasPrefix(url, "https://") {
-		hostPort = url[8:]
-		protocol = "https"
-	} else if strings.HasPrefix(url, "http://") {
-		hostPort = url[7:]
-		protocol = "http"
-	} else {
+	scheme, defaultPort, hostPort := splitURL(url)
+	if scheme == "" {
 		return -1
 	}
 
@@ -90,11 +107,7 @@ func GetPort(endpoint interface{}) int {
 		}
 		return portInt
 	} else {
-		if protocol == "https" {
-			return 443
-		} else {
-			return 80
-		}
+		return defaultPort
 	}
 }
 
@@ -107,7 +120,7 @@ func ConstructURlFromK8sService(endpoint interface{}) string {
 	}
 }
 
-// GetProtocol extracts the protocol from a given URL
+// GetProtocol extracts the protocol from a given URL, i.e. http, https, ws or wss
 func GetProtocol(endpoint interface{}) string {
 	if k8sService, ok := endpoint.(types.K8sService); ok {
 		if k8sService.Protocol == "" {
@@ -115,10 +128,8 @@ func GetProtocol(endpoint interface{}) string {
 		}
 		return k8sService.Protocol
 	} else if strEndpoint, ok := endpoint.(string); ok {
-		if strings.HasPrefix(strEndpoint, "https://") {
-			return "https"
-		} else {
-			return "http"
+		if scheme, _, _ := splitURL(strEndpoint); scheme != "" {
+			return scheme
 		}
 	}
 	return "http"
@@ -126,12 +137,8 @@ func GetProtocol(endpoint interface{}) string {
 
 // GetPath extracts the path from a given URL
 func GetPath(url string) string {
-	var hostPort string
-	if strings.HasPrefix(url, "https://") {
-		hostPort = url[8:]
-	} else if strings.HasPrefix(url, "http://") {
-		hostPort = url[7:]
-	} else {
+	scheme, _, hostPort := splitURL(url)
+	if scheme == "" {
 		return ""
 	}
 
@@ -142,15 +149,33 @@ func GetPath(url string) string {
 	}
 }
 
+// RetrieveFullBasePath returns the base path including the version, unless the base path already ends with it
+func RetrieveFullBasePath(basePath string, version string) string {
+	basePath = strings.TrimSuffix(basePath, "/")
+	if version == "" || strings.HasSuffix(basePath, "/"+version) {
+		return basePath
+	}
+	return basePath + "/" + version
+}
+
+// RetrieveBasePathWithoutVersion returns the base path with the trailing version removed
+func RetrieveBasePathWithoutVersion(basePath string, version string) string {
+	basePath = strings.TrimSuffix(basePath, "/")
+	if version == "" {
+		return basePath
+	}
+	return strings.TrimSuffix(basePath, "/"+version)
+}
+
 // RetrievePathPrefix generates a path prefix based on the operation and basePath
 func RetrievePathPrefix(operation string, basePath string) string {
 	splitValues := strings.Split(operation, "/")
-	generatedPath := ""
+	generatedPath := regexp.QuoteMeta(strings.TrimSuffix(basePath, "/"))
 
 	if operation == "/*" {
-		return "(.*)"
+		return generatedPath + "(.*)"
 	} else if operation == "/" {
-		return "/"
+		return generatedPath + "/"
 	}
 
 	re := regexp.MustCompile(`\{.*\}`)
@@ -204,7 +229,7 @@ func GeneratePrefixMatch(endpointToUse types.EndpointDetails, operation types.Op
 
 	if strings.HasSuffix(generatedPath, "/*") {
 		lastSlashIndex := strings.LastIndex(generatedPath, "/")
-		generatedPath = generatedPath[:lastSlashIndex] + "///" + strconv.Itoa(pathParamCount)
+		generatedPath = generatedPath[:lastSlashIndex] + "\\" + strconv.Itoa(pathParamCount)
 	}
 	if endpointToUse.ServiceEntry {
 		return strings.TrimSpace(generatedPath)
@@ -212,31 +237,81 @@ func GeneratePrefixMatch(endpointToUse types.EndpointDetails, operation types.Op
 	return generatedPath
 }
 
-func GetHostNames(apkConf types.APKConf, endpointType string, organization types.Organization) []gwapiv1.Hostname {
-	// todo: need to implement this function
-	var hosts []gwapiv1.Hostname
+// GetHostNames resolves the route host names for the given endpoint type.
+// Each virtual host of the gateway is prefixed with the organization and environment
+// (e.g. <org>-<env>.<vhost>) in lower case with any characters invalid in a host name label
+// replaced by hyphens, falling back to the gateway hostname for production and
+// sandbox.<gateway hostname> for sandbox when no virtual hosts are configured.
+func GetHostNames(apkConf types.APKConf, endpointType string, organization types.Organization, gatewayConfig types.GatewayConfigurations) []gwapiv1.Hostname {
+	hosts := make([]gwapiv1.Hostname, 0)
+	orgAndEnv := organization.Name
+	if orgAndEnv == "" {
+		orgAndEnv = organization.OrganizationClaimValue
+	}
 	environment := apkConf.Environment
-	orgAndEnv := ""
 	if environment != "" {
-		orgAndEnv = orgAndEnv + "-" + environment
+		if orgAndEnv != "" {
+			orgAndEnv = orgAndEnv + "-" + environment
+		} else {
+			orgAndEnv = environment
+		}
+	}
+	// The organization and environment prefix is a DNS label, so the characters invalid in a label are replaced.
+	orgAndEnv = strings.Trim(regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(strings.ToLower(orgAndEnv), "-"), "-")
+	if len(orgAndEnv) > 63 {
+		orgAndEnv = strings.TrimRight(orgAndEnv[:63], "-")
+	}
+
+	seen := make(map[string]bool)
+	for _, vhost := range getVHosts(endpointType, gatewayConfig) {
+		host := strings.ToLower(strings.TrimSpace(vhost))
+		if host == "" {
+			continue
+		}
+		if orgAndEnv != "" {
+			// Wildcard virtual hosts are narrowed down to the organization's own subdomain.
+			host = orgAndEnv + "." + strings.TrimPrefix(host, "*.")
+		}
+		if !seen[host] {
+			seen[host] = true
+			hosts = append(hosts, gwapiv1.Hostname(host))
+		}
 	}
 	return hosts
 }
 
-// GetEndpoints retrieves the endpoint details from the provided APK configuration.
-func GetEndpoints(apkConf types.APKConf) map[string]types.EndpointDetails {
+// getVHosts returns the virtual hosts configured for the given endpoint type.
+func getVHosts(endpointType string, gatewayConfig types.GatewayConfigurations) []string {
+	if gatewayConfig.VHosts != nil {
+		if endpointType == constants.SANDBOX_TYPE && len(gatewayConfig.VHosts.Sandbox) > 0 {
+			return gatewayConfig.VHosts.Sandbox
+		} else if endpointType != constants.SANDBOX_TYPE && len(gatewayConfig.VHosts.Production) > 0 {
+			return gatewayConfig.VHosts.Production
+		}
+	}
+	if gatewayConfig.Hostname == "" {
+		return nil
+	}
+	if endpointType == constants.SANDBOX_TYPE {
+		return []string{"sandbox." + strings.TrimPrefix(gatewayConfig.Hostname, "*.")}
+	}
+	return []string{gatewayConfig.Hostname}
+}
+
+// GetEndpoints retrieves the endpoint details of the API with the given unique id from the provided APK configuration.
+func GetEndpoints(apkConf types.APKConf, uniqueId string) map[string]types.EndpointDetails {
 	createdEndpoints := make(map[string]types.EndpointDetails)
 	endpointConfigs := apkConf.EndpointConfigurations
 	if endpointConfigs != nil {
-		createdEndpoints = createEndpoints(endpointConfigs, "")
+		createdEndpoints = createEndpoints(endpointConfigs, uniqueId)
 	}
 	return createdEndpoints
 }
 
 // GetEndpointToUse returns the endpoint details based on the endpoint configurations and type.
-func GetEndpointToUse(endpointConfigs *types.EndpointConfigurations, endpointType string) *types.EndpointDetails {
+func GetEndpointToUse(endpointConfigs *types.EndpointConfigurations, endpointType string, uniqueId string) *types.EndpointDetails {
 	if endpointConfigs != nil {
-		operationLevelEndpoint := createEndpoints(endpointConfigs, endpointType)
+		operationLevelEndpoint := createEndpoints(endpointConfigs, uniqueId)
 		if _, ok := operationLevelEndpoint[endpointType]; ok {
 			endpoint := operationLevelEndpoint[endpointType]
 			return &endpoint
@@ -245,22 +320,440 @@ func GetEndpointToUse(endpointConfigs *types.EndpointConfigurations, endpointTyp
 	return nil
 }
 
-// createEndpoints creates a map of endpoint details based on the provided configurations and endpoint type.
-func createEndpoints(endpointConfigs *types.EndpointConfigurations, endpointType string) map[string]types.EndpointDetails {
+// GetEndpointConfiguration returns the endpoint configuration of the given endpoint type.
+func GetEndpointConfiguration(endpointConfigs *types.EndpointConfigurations, endpointType string) *types.EndpointConfiguration {
+	if endpointConfigs != nil {
+		if endpointType == constants.PRODUCTION_TYPE {
+			return endpointConfigs.Production
+		} else if endpointType == constants.SANDBOX_TYPE {
+			return endpointConfigs.Sandbox
+		}
+	}
+	return nil
+}
+
+// HasEndpoint reports whether the endpoint configurations hold an endpoint of the given type.
+func HasEndpoint(endpointConfigs *types.EndpointConfigurations, endpointType string) bool {
+	endpointConfig := GetEndpointConfiguration(endpointConfigs, endpointType)
+	return endpointConfig != nil && endpointConfig.Endpoint != nil
+}
+
+// createEndpoints creates a map of endpoint details based on the provided configurations.
+func createEndpoints(endpointConfigs *types.EndpointConfigurations, uniqueId string) map[string]types.EndpointDetails {
 	createdEndpoints := make(map[string]types.EndpointDetails)
 	productionEndpointConfig := endpointConfigs.Production
 	sandboxEndpointConfig := endpointConfigs.Sandbox
-	if endpointType == constants.PRODUCTION_TYPE || productionEndpointConfig != nil {
-		createdEndpoints[constants.PRODUCTION_TYPE] = types.EndpointDetails{
-			Name: GetHost(productionEndpointConfig.Endpoint),
-			URL:  ConstructURlFromK8sService(productionEndpointConfig.Endpoint),
+	if productionEndpointConfig != nil && productionEndpointConfig.Endpoint != nil {
+		createdEndpoints[constants.PRODUCTION_TYPE] = CreateEndpointDetails(uniqueId, *productionEndpointConfig)
+	}
+	if sandboxEndpointConfig != nil && sandboxEndpointConfig.Endpoint != nil {
+		createdEndpoints[constants.SANDBOX_TYPE] = CreateEndpointDetails(uniqueId, *sandboxEndpointConfig)
+	}
+	return createdEndpoints
+}
+
+// CreateEndpointDetails creates the endpoint details for the endpoint of the given configuration.
+// Kubernetes services are referred to directly unless they have endpoint security or a certificate configured,
+// while URLs and such services are referred to through a backend generated for the API.
+func CreateEndpointDetails(uniqueId string, endpointConfig types.EndpointConfiguration) types.EndpointDetails {
+	switch v := endpointConfig.Endpoint.(type) {
+	case types.K8sService:
+		if endpointConfig.EndSecurity.Enabled || endpointConfig.EndCertificate.Name != "" {
+			return types.EndpointDetails{
+				Name: GetBackendName(uniqueId, endpointConfig),
+				URL:  ConstructURlFromK8sService(v),
+			}
 		}
+		return types.EndpointDetails{
+			Name:         v.Name,
+			URL:          ConstructURlFromK8sService(v),
+			Namespace:    v.Namespace,
+			ServiceEntry: true,
+		}
+	case types.EndpointURL:
+		return types.EndpointDetails{
+			Name: GetBackendName(uniqueId, endpointConfig),
+			URL:  string(v),
+		}
+	}
+	return types.EndpointDetails{}
+}
+
+// GetBackendName generates a stable name for the backend of the API with the given unique id to the endpoint URL.
+// The name covers the security and TLS configurations of the endpoint, so that endpoints sharing a URL with
+// different configurations get separate backends.
+func GetBackendName(uniqueId string, endpointConfig types.EndpointConfiguration) string {
+	hash := sha1.Sum([]byte(fmt.Sprintf("%v\x00%+v\x00%+v", endpointConfig.Endpoint, endpointConfig.EndSecurity, endpointConfig.EndCertificate)))
+	return uniqueId + "-backend-" + hex.EncodeToString(hash[:])[:10]
+}
+
+// GetInterceptorEndpointConfiguration returns the endpoint configuration of the backend of the interceptor service.
+func GetInterceptorEndpointConfiguration(interceptor types.InterceptorService) types.EndpointConfiguration {
+	return types.EndpointConfiguration{
+		Endpoint: types.EndpointURL(interceptor.BackendURL),
+		EndCertificate: types.EndpointCertificate{
+			Name: interceptor.TLSSecretName,
+			Key:  interceptor.TLSSecretKey,
+		},
 	}
-	if endpointType == constants.SANDBOX_TYPE || sandboxEndpointConfig != nil {
-		createdEndpoints[constants.SANDBOX_TYPE] = types.EndpointDetails{
-			Name: GetHost(sandboxEndpointConfig.Endpoint),
-			URL:  ConstructURlFromK8sService(sandboxEndpointConfig.Endpoint),
+}
+
+// GenerateBackendObjectReference generates the backend object reference for the given endpoint details.
+func GenerateBackendObjectReference(endpoint types.EndpointDetails) gwapiv1.BackendObjectReference {
+	var backendObjectReference gwapiv1.BackendObjectReference
+	if endpoint.ServiceEntry {
+		kind := gwapiv1.Kind(constants.SERVICE_KIND)
+		backendObjectReference = gwapiv1.BackendObjectReference{
+			Kind: &kind,
+			Name: gwapiv1.ObjectName(endpoint.Name),
+		}
+		if endpoint.Namespace != "" {
+			namespace := gwapiv1.Namespace(endpoint.Namespace)
+			backendObjectReference.Namespace = &namespace
+		}
+	} else {
+		group := gwapiv1.Group(constants.DP_GROUP)
+		kind := gwapiv1.Kind(constants.BACKEND_KIND)
+		backendObjectReference = gwapiv1.BackendObjectReference{
+			Group: &group,
+			Kind:  &kind,
+			Name:  gwapiv1.ObjectName(endpoint.Name),
 		}
 	}
-	return createdEndpoints
+	if port := GetPort(endpoint.URL); port > 0 {
+		portNumber := gwapiv1.PortNumber(port)
+		backendObjectReference.Port = &portNumber
+	}
+	return backendObjectReference
+}
+
+// GetRuleName generates a stable route rule name for the given operation.
+func GetRuleName(operation types.Operation) string {
+	hash := sha1.Sum([]byte(operation.Verb + " " + operation.Target))
+	verb := strings.Trim(regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(strings.ToLower(operation.Verb), "-"), "-")
+	if verb == "" {
+		verb = "resource"
+	}
+	return verb + "-" + hex.EncodeToString(hash[:])[:10]
+}
+
+// GetHTTPRouteReference returns the route reference of the given HTTPRoute.
+func GetHTTPRouteReference(httpRoute *gwapiv1.HTTPRoute) types.RouteReference {
+	routeReference := types.RouteReference{Kind: constants.HTTPROUTE_KIND, Name: httpRoute.Name}
+	for _, rule := range httpRoute.Spec.Rules {
+		if rule.Name != nil {
+			routeReference.RuleNames = append(routeReference.RuleNames, string(*rule.Name))
+		}
+	}
+	return routeReference
+}
+
+// GetGRPCRouteReference returns the route reference of the given GRPCRoute.
+func GetGRPCRouteReference(grpcRoute *gwapiv1.GRPCRoute) types.RouteReference {
+	routeReference := types.RouteReference{Kind: constants.GRPCROUTE_KIND, Name: grpcRoute.Name}
+	for _, rule := range grpcRoute.Spec.Rules {
+		if rule.Name != nil {
+			routeReference.RuleNames = append(routeReference.RuleNames, string(*rule.Name))
+		}
+	}
+	return routeReference
+}
+
+// GetGQLRouteReference returns the route reference of the given GQLRoute.
+func GetGQLRouteReference(gqlRoute *crds.GQLRoute) types.RouteReference {
+	routeReference := types.RouteReference{Kind: constants.GQLROUTE_KIND, Name: gqlRoute.Name}
+	for _, rule := range gqlRoute.Spec.Rules {
+		if rule.Name != nil {
+			routeReference.RuleNames = append(routeReference.RuleNames, string(*rule.Name))
+		}
+	}
+	return routeReference
+}
+
+// SplitRules splits the route rules into groups of at most constants.MAX_RULES_PER_ROUTE rules, one group per route.
+// Rules with the same key, such as the rules of the same backend, are kept in the same route when they fit.
+func SplitRules[T any](rules []T, getKey func(rule T) string) [][]T {
+	var keys []string
+	rulesByKey := make(map[string][]T)
+	for _, rule := range rules {
+		key := getKey(rule)
+		if _, ok := rulesByKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		rulesByKey[key] = append(rulesByKey[key], rule)
+	}
+
+	var groups [][]T
+	var group []T
+	for _, key := range keys {
+		keyRules := rulesByKey[key]
+		if len(group) > 0 && len(group)+len(keyRules) > constants.MAX_RULES_PER_ROUTE {
+			groups = append(groups, group)
+			group = nil
+		}
+		for _, rule := range keyRules {
+			if len(group) == constants.MAX_RULES_PER_ROUTE {
+				groups = append(groups, group)
+				group = nil
+			}
+			group = append(group, rule)
+		}
+	}
+	if len(group) > 0 {
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// GetHTTPRouteNames returns the names of the given HTTPRoutes.
+func GetHTTPRouteNames(httpRoutes []*gwapiv1.HTTPRoute) []string {
+	var names []string
+	for _, httpRoute := range httpRoutes {
+		names = append(names, httpRoute.Name)
+	}
+	return names
+}
+
+// GetGRPCRouteNames returns the names of the given GRPCRoutes.
+func GetGRPCRouteNames(grpcRoutes []*gwapiv1.GRPCRoute) []string {
+	var names []string
+	for _, grpcRoute := range grpcRoutes {
+		names = append(names, grpcRoute.Name)
+	}
+	return names
+}
+
+// GetGQLRouteNames returns the names of the given GQLRoutes.
+func GetGQLRouteNames(gqlRoutes []*crds.GQLRoute) []string {
+	var names []string
+	for _, gqlRoute := range gqlRoutes {
+		names = append(names, gqlRoute.Name)
+	}
+	return names
+}
+
+// HasRouteRule returns whether any of the given routes has the rule serving the given operation.
+func HasRouteRule(routes []types.RouteReference, operation types.Operation) bool {
+	ruleName := GetRuleName(operation)
+	for _, route := range routes {
+		for _, routeRuleName := range route.RuleNames {
+			if routeRuleName == ruleName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetResourceTargetRef returns the policy target reference of the resources of the API with the given unique id.
+// APK applies such resource level policies to the route rules referring them through ExtensionRef filters.
+func GetResourceTargetRef(uniqueId string) gwapiv1alpha2.LocalPolicyTargetReferenceWithSectionName {
+	return gwapiv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+		LocalPolicyTargetReference: gwapiv1alpha2.LocalPolicyTargetReference{
+			Group: gwapiv1.Group(constants.GATEWAY_GROUP),
+			Kind:  gwapiv1.Kind(constants.RESOURCE_KIND),
+			Name:  gwapiv1.ObjectName(uniqueId),
+		},
+	}
+}
+
+// GetOperationPolicyName generates a stable name for the resource level policy of the operation, ending with the given suffix.
+func GetOperationPolicyName(uniqueId string, operation types.Operation, suffix string) string {
+	return uniqueId + "-" + GetRuleName(operation) + "-" + suffix
+}
+
+// GetOperationExtensionRefs returns the references to the resource level policies of the operation,
+// added as ExtensionRef filters to the route rules serving the operation.
+func GetOperationExtensionRefs(apkConf types.APKConf, operation types.Operation, uniqueId string) []gwapiv1.LocalObjectReference {
+	var extensionRefs []gwapiv1.LocalObjectReference
+	if !IsOperationSecured(operation) {
+		extensionRefs = append(extensionRefs, gwapiv1.LocalObjectReference{
+			Group: gwapiv1.Group(constants.DP_GROUP),
+			Kind:  gwapiv1.Kind(constants.AUTHENTICATION_KIND),
+			Name:  gwapiv1.ObjectName(GetOperationPolicyName(uniqueId, operation, "authentication")),
+		})
+	}
+	if operation.RateLimit != nil {
+		extensionRefs = append(extensionRefs, gwapiv1.LocalObjectReference{
+			Group: gwapiv1.Group(constants.DP_GROUP),
+			Kind:  gwapiv1.Kind(constants.RATELIMIT_POLICY_KIND),
+			Name:  gwapiv1.ObjectName(GetOperationPolicyName(uniqueId, operation, "ratelimit")),
+		})
+	}
+	if hasOperationAPIPolicy(apkConf, operation) {
+		extensionRefs = append(extensionRefs, gwapiv1.LocalObjectReference{
+			Group: gwapiv1.Group(constants.DP_GROUP),
+			Kind:  gwapiv1.Kind(constants.API_POLICY_KIND),
+			Name:  gwapiv1.ObjectName(GetOperationPolicyName(uniqueId, operation, "api-policy")),
+		})
+	}
+	return extensionRefs
+}
+
+// hasOperationAPIPolicy returns whether the operation has its own interceptors or backend JWT, applied through an APIPolicy
+// unless API level policies are configured as they take precedence over the operation policies.
+func hasOperationAPIPolicy(apkConf types.APKConf, operation types.Operation) bool {
+	if operation.OperationPolicies == nil || HasAPIPolicies(apkConf) {
+		return false
+	}
+	return len(GetInterceptors(operation.OperationPolicies.Request)) > 0 || len(GetInterceptors(operation.OperationPolicies.Response)) > 0 ||
+		GetBackendJWT(operation.OperationPolicies.Request) != nil
+}
+
+// GetOperationExtensionRefFilters returns the ExtensionRef filters of the resource level policies of the operation.
+func GetOperationExtensionRefFilters(apkConf types.APKConf, operation types.Operation, uniqueId string) []gwapiv1.HTTPRouteFilter {
+	var filters []gwapiv1.HTTPRouteFilter
+	extensionRefs := GetOperationExtensionRefs(apkConf, operation, uniqueId)
+	for i := range extensionRefs {
+		filters = append(filters, gwapiv1.HTTPRouteFilter{
+			Type:         gwapiv1.HTTPRouteFilterExtensionRef,
+			ExtensionRef: &extensionRefs[i],
+		})
+	}
+	return filters
+}
+
+// GetAPITargetRef returns the policy target reference of the API with the given unique id.
+func GetAPITargetRef(uniqueId string) gwapiv1alpha2.LocalPolicyTargetReferenceWithSectionName {
+	return gwapiv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+		LocalPolicyTargetReference: gwapiv1alpha2.LocalPolicyTargetReference{
+			Group: gwapiv1.Group(constants.DP_GROUP),
+			Kind:  gwapiv1.Kind(constants.API_KIND),
+			Name:  gwapiv1.ObjectName(uniqueId),
+		},
+	}
+}
+
+// GetRateLimitUnit returns the supported rate limit unit matching the given unit, ignoring case.
+func GetRateLimitUnit(unit string) (string, bool) {
+	for _, supportedUnit := range []string{constants.RATELIMIT_UNIT_MINUTE, constants.RATELIMIT_UNIT_HOUR, constants.RATELIMIT_UNIT_DAY} {
+		if strings.EqualFold(unit, supportedUnit) {
+			return supportedUnit, true
+		}
+	}
+	return "", false
+}
+
+// GetBackendJWTSigningAlgorithm returns the supported backend JWT signing algorithm matching the given algorithm, ignoring case.
+func GetBackendJWTSigningAlgorithm(signingAlgorithm string) (string, bool) {
+	for _, supportedAlgorithm := range []string{constants.BACKEND_JWT_ALGORITHM_SHA256_RSA, constants.BACKEND_JWT_ALGORITHM_SHA384_RSA, constants.BACKEND_JWT_ALGORITHM_SHA512_RSA} {
+		if strings.EqualFold(signingAlgorithm, supportedAlgorithm) {
+			return supportedAlgorithm, true
+		}
+	}
+	return "", false
+}
+
+// GetBackendJWTEncoding returns the supported backend JWT encoding matching the given encoding, ignoring case.
+func GetBackendJWTEncoding(encoding string) (string, bool) {
+	for _, supportedEncoding := range []string{constants.BACKEND_JWT_ENCODING_BASE64, constants.BACKEND_JWT_ENCODING_BASE64URL} {
+		if strings.EqualFold(encoding, supportedEncoding) {
+			return supportedEncoding, true
+		}
+	}
+	return "", false
+}
+
+// GetUniqueId returns the unique id of the API, derived from its name, version and organization
+// unless the APK configuration sets an id.
+func GetUniqueId(apkConf types.APKConf, organization types.Organization) string {
+	if apkConf.ID != "" {
+		return apkConf.ID
+	}
+	hash := sha1.Sum([]byte(strings.Join([]string{apkConf.Name, apkConf.Version, organization.Name}, "\x00")))
+	return hex.EncodeToString(hash[:])
+}
+
+// GetAIProviderName returns the name of the AIProvider of the API with the given unique id.
+func GetAIProviderName(uniqueId string) string {
+	return uniqueId + "-ai-provider"
+}
+
+// GetInterceptors returns the interceptor services configured in the given policies.
+func GetInterceptors(policies []types.OperationPolicy) []types.InterceptorService {
+	var interceptors []types.InterceptorService
+	for _, policy := range policies {
+		if interceptor, ok := policy.Parameters.(types.InterceptorService); ok {
+			interceptors = append(interceptors, interceptor)
+		}
+	}
+	return interceptors
+}
+
+// GetMirrorURLs returns the request mirror URLs configured in the given policies.
+func GetMirrorURLs(policies []types.OperationPolicy) []string {
+	var urls []string
+	for _, policy := range policies {
+		if urlList, ok := policy.Parameters.(types.URLList); ok {
+			urls = append(urls, urlList.URLs...)
+		}
+	}
+	return urls
+}
+
+// GetInterceptorServiceName generates a stable InterceptorService name for the given interceptor of the API.
+func GetInterceptorServiceName(uniqueId string, interceptor types.InterceptorService, isRequest bool) string {
+	flow := "response"
+	if isRequest {
+		flow = "request"
+	}
+	hash := sha1.Sum([]byte(fmt.Sprintf("%+v", interceptor)))
+	return uniqueId + "-" + flow + "-interceptor-" + hex.EncodeToString(hash[:])[:10]
+}
+
+// GetBackendJWT returns the first backend JWT configured in the given policies, or nil when there is none.
+func GetBackendJWT(policies []types.OperationPolicy) *types.BackendJWT {
+	for _, policy := range policies {
+		if backendJWT, ok := policy.Parameters.(types.BackendJWT); ok {
+			return &backendJWT
+		}
+	}
+	return nil
+}
+
+// GetBackendJWTName generates a stable BackendJWT name for the given backend JWT configuration of the API.
+func GetBackendJWTName(uniqueId string, backendJWT types.BackendJWT) string {
+	hash := sha1.Sum([]byte(fmt.Sprintf("%+v", backendJWT)))
+	return uniqueId + "-backend-jwt-" + hex.EncodeToString(hash[:])[:10]
+}
+
+// GetOperationPath returns the field path of the given operation in the APK configuration, e.g. operations[2].
+// Operations that are not part of the configuration are reported on the operations list.
+func GetOperationPath(apkConf types.APKConf, operation types.Operation) string {
+	if apkConf.Operations != nil {
+		for i, apiOperation := range *apkConf.Operations {
+			if apiOperation.Verb == operation.Verb && apiOperation.Target == operation.Target {
+				return diagnostics.Index("operations", i)
+			}
+		}
+	}
+	return "operations"
+}
+
+// IsOperationSecured returns whether the operation is secured, which it is unless secured is set to false.
+func IsOperationSecured(operation types.Operation) bool {
+	return operation.Secured == nil || *operation.Secured
+}
+
+// IsAuthenticationEnabled returns whether the authentication type is enabled, which it is unless enabled is set to false.
+func IsAuthenticationEnabled(authConfig types.AuthConfiguration) bool {
+	return authConfig.Enabled == nil || *authConfig.Enabled
+}
+
+// HasAPIPolicies returns whether API level request or response policies are configured, in which case
+// they apply to every operation in place of the operation policies.
+func HasAPIPolicies(apkConf types.APKConf) bool {
+	return apkConf.APIPolicies != nil && (apkConf.APIPolicies.Request != nil || apkConf.APIPolicies.Response != nil)
+}
+
+// GetAppliedPolicies returns the API policies along with the policies of each operation, leaving out the operation
+// policies when API level policies are configured as they are ignored in favour of the API policies.
+func GetAppliedPolicies(apkConf types.APKConf) []*types.OperationPolicies {
+	appliedPolicies := []*types.OperationPolicies{apkConf.APIPolicies}
+	if apkConf.Operations != nil && !HasAPIPolicies(apkConf) {
+		for _, operation := range *apkConf.Operations {
+			appliedPolicies = append(appliedPolicies, operation.OperationPolicies)
+		}
+	}
+	return appliedPolicies
 }
@@ -21,9 +21,11 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
 	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
 
 	"github.com/stretchr/testify/assert"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 func TestGetHost(t *testing.T) {
@@ -34,6 +36,9 @@ func TestGetHost(t *testing.T) {
 	}{
 		{"HTTP URL", types.EndpointURL("http://example.com:8080/path"), "example.com"},
 		{"HTTPS URL", types.EndpointURL("https://example.com:8443/path"), "example.com"},
+		{"WS URL", types.EndpointURL("ws://example.com:9090/notifications"), "example.com"},
+		{"WSS URL", types.EndpointURL("wss://example.com/notifications"), "example.com"},
+		{"Unsupported URL", types.EndpointURL("ftp://example.com/path"), ""},
 		{"K8s Service", types.K8sService{Name: "service", Namespace: "default", Protocol: "http", Port: "80"}, "service.default.svc.cluster.local"},
 	}
 
@@ -55,6 +60,10 @@ func TestGetPort(t *testing.T) {
 		{"HTTPS URL", "https://example.com:8443/path", 8443},
 		{"HTTP URL without port", "http://example.com/path", 80},
 		{"HTTPS URL without port", "https://example.com/path", 443},
+		{"WS URL", "ws://example.com:9090/notifications", 9090},
+		{"WS URL without port", "ws://example.com/notifications", 80},
+		{"WSS URL without port", "wss://example.com/notifications", 443},
+		{"Unsupported URL", "ftp://example.com/path", -1},
 		{"K8s Service", types.K8sService{Name: "service", Namespace: "default", Protocol: "http", Port: "80"}, 80},
 	}
 
@@ -74,6 +83,8 @@ func TestGetProtocol(t *testing.T) {
 	}{
 		{"HTTP URL", "http://example.com/path", "http"},
 		{"HTTPS URL", "https://example.com/path", "https"},
+		{"WS URL", "ws://example.com/notifications", "ws"},
+		{"WSS URL", "wss://example.com/notifications", "wss"},
 		{"K8s Service with protocol", types.K8sService{Name: "service", Namespace: "default", Protocol: "http", Port: "80"}, "http"},
 		{"K8s Service without protocol", types.K8sService{Name: "service", Namespace: "default", Port: "80"}, "http"},
 	}
@@ -96,6 +107,7 @@ func TestGetPath(t *testing.T) {
 		{"HTTPS URL with path", "https://example.com:8443/path/to/resource", "/path/to/resource"},
 		{"HTTP URL without path", "http://example.com:8080", ""},
 		{"HTTPS URL without path", "https://example.com:8443", ""},
+		{"WSS URL with path", "wss://example.com/notifications", "/notifications"},
 	}
 
 	for _, tt := range tests {
@@ -113,9 +125,10 @@ func TestRetrievePathPrefix(t *testing.T) {
 		basePath  string
 		expected  string
 	}{
-		{"Root operation", "/", "/base", "/"},
-		{"Wildcard operation", "/*", "/base", "(.*)"},
+		{"Root operation", "/", "/base", "/base/"},
+		{"Wildcard operation", "/*", "/base", "/base(.*)"},
 		{"Path with param", "/resource/{id}", "/base", "/base/resource/(.*)"},
+		{"Trailing wildcard", "/resource/*", "/base/1.0", "/base/1\\.0/resource(.*)"},
 	}
 
 	for _, tt := range tests {
@@ -132,6 +145,45 @@ func TestRetrievePathPrefix(t *testing.T) {
 	}
 }
 
+func TestRetrieveFullBasePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		basePath string
+		version  string
+		expected string
+	}{
+		{"Base path without version", "/base", "1.0", "/base/1.0"},
+		{"Base path with version", "/base/1.0", "1.0", "/base/1.0"},
+		{"Base path with trailing slash", "/base/", "1.0", "/base/1.0"},
+		{"Empty version", "/base", "", "/base"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RetrieveFullBasePath(tt.basePath, tt.version))
+		})
+	}
+}
+
+func TestRetrieveBasePathWithoutVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		basePath string
+		version  string
+		expected string
+	}{
+		{"Base path without version", "/base", "1.0", "/base"},
+		{"Base path with version", "/base/1.0", "1.0", "/base"},
+		{"Empty version", "/base/", "", "/base"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RetrieveBasePathWithoutVersion(tt.basePath, tt.version))
+		})
+	}
+}
+
 func TestGeneratePrefixMatch(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -142,6 +194,7 @@ func TestGeneratePrefixMatch(t *testing.T) {
 		{"Root operation", types.EndpointDetails{ServiceEntry: false}, types.Operation{Target: "/"}, "/"},
 		{"Wildcard operation", types.EndpointDetails{ServiceEntry: false}, types.Operation{Target: "/*"}, "\\1"},
 		{"Path with param", types.EndpointDetails{ServiceEntry: false}, types.Operation{Target: "/resource/{id}"}, "/resource/\\1"},
+		{"Trailing wildcard", types.EndpointDetails{ServiceEntry: false}, types.Operation{Target: "/resource/{id}/*"}, "/resource/\\1\\2"},
 	}
 
 	for _, tt := range tests {
@@ -151,3 +204,241 @@ func TestGeneratePrefixMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestGetHostNames(t *testing.T) {
+	tests := []struct {
+		name          string
+		apkConf       types.APKConf
+		endpointType  string
+		organization  types.Organization
+		gatewayConfig types.GatewayConfigurations
+		expected      []gwapiv1.Hostname
+	}{
+		{
+			"Production gateway hostname",
+			types.APKConf{},
+			constants.PRODUCTION_TYPE,
+			types.Organization{Name: "wso2"},
+			types.GatewayConfigurations{Hostname: "gw.wso2.com"},
+			[]gwapiv1.Hostname{"wso2.gw.wso2.com"},
+		},
+		{
+			"Sandbox gateway hostname with environment",
+			types.APKConf{Environment: "dev"},
+			constants.SANDBOX_TYPE,
+			types.Organization{Name: "wso2"},
+			types.GatewayConfigurations{Hostname: "gw.wso2.com"},
+			[]gwapiv1.Hostname{"wso2-dev.sandbox.gw.wso2.com"},
+		},
+		{
+			"Organization claim value fallback",
+			types.APKConf{},
+			constants.PRODUCTION_TYPE,
+			types.Organization{OrganizationClaimValue: "Carbon"},
+			types.GatewayConfigurations{Hostname: "gw.wso2.com"},
+			[]gwapiv1.Hostname{"carbon.gw.wso2.com"},
+		},
+		{
+			"Organization and environment with invalid characters",
+			types.APKConf{Environment: "QA_1"},
+			constants.PRODUCTION_TYPE,
+			types.Organization{Name: "ACME Corp."},
+			types.GatewayConfigurations{Hostname: "gw.wso2.com"},
+			[]gwapiv1.Hostname{"acme-corp-qa-1.gw.wso2.com"},
+		},
+		{
+			"Virtual hosts with wildcard and duplicates",
+			types.APKConf{},
+			constants.PRODUCTION_TYPE,
+			types.Organization{Name: "wso2"},
+			types.GatewayConfigurations{
+				Hostname: "gw.wso2.com",
+				VHosts:   &types.VHost{Production: []string{"*.example.com", "example.com", "api.example.com"}},
+			},
+			[]gwapiv1.Hostname{"wso2.example.com", "wso2.api.example.com"},
+		},
+		{
+			"Wildcard virtual host without organization",
+			types.APKConf{},
+			constants.SANDBOX_TYPE,
+			types.Organization{},
+			types.GatewayConfigurations{VHosts: &types.VHost{Sandbox: []string{"*.sandbox.example.com"}}},
+			[]gwapiv1.Hostname{"*.sandbox.example.com"},
+		},
+		{
+			"No hostname configured",
+			types.APKConf{},
+			constants.PRODUCTION_TYPE,
+			types.Organization{Name: "wso2"},
+			types.GatewayConfigurations{},
+			[]gwapiv1.Hostname{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hosts := GetHostNames(tt.apkConf, tt.endpointType, tt.organization, tt.gatewayConfig)
+			assert.Equal(t, tt.expected, hosts)
+		})
+	}
+}
+
+func TestGetEndpoints(t *testing.T) {
+	apkConf := types.APKConf{
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Sandbox: &types.EndpointConfiguration{
+				Endpoint: types.K8sService{Name: "service", Namespace: "apk", Protocol: "http", Port: "8080"},
+			},
+		},
+	}
+
+	endpoints := GetEndpoints(apkConf, "unique-id")
+	_, hasProduction := endpoints[constants.PRODUCTION_TYPE]
+	assert.False(t, hasProduction)
+	assert.Equal(t, types.EndpointDetails{
+		Name:         "service",
+		URL:          "http://service.apk.svc.cluster.local:8080",
+		Namespace:    "apk",
+		ServiceEntry: true,
+	}, endpoints[constants.SANDBOX_TYPE])
+
+	// Kubernetes services with a certificate are referred to through a backend
+	apkConf.EndpointConfigurations.Sandbox.EndCertificate = types.EndpointCertificate{Name: "service-cert", Key: "ca.crt"}
+	endpoints = GetEndpoints(apkConf, "unique-id")
+	assert.Equal(t, types.EndpointDetails{
+		Name: GetBackendName("unique-id", *apkConf.EndpointConfigurations.Sandbox),
+		URL:  "http://service.apk.svc.cluster.local:8080",
+	}, endpoints[constants.SANDBOX_TYPE])
+}
+
+func TestGenerateBackendObjectReference(t *testing.T) {
+	serviceKind := gwapiv1.Kind("Service")
+	backendKind := gwapiv1.Kind("Backend")
+	backendGroup := gwapiv1.Group("dp.wso2.com")
+	namespace := gwapiv1.Namespace("apk")
+	servicePort := gwapiv1.PortNumber(8080)
+	backendPort := gwapiv1.PortNumber(443)
+
+	tests := []struct {
+		name     string
+		endpoint types.Endpoint
+		expected gwapiv1.BackendObjectReference
+	}{
+		{
+			"K8s Service",
+			types.K8sService{Name: "service", Namespace: "apk", Protocol: "http", Port: "8080"},
+			gwapiv1.BackendObjectReference{Kind: &serviceKind, Name: "service", Namespace: &namespace, Port: &servicePort},
+		},
+		{
+			"External URL",
+			types.EndpointURL("https://example.com/api"),
+			gwapiv1.BackendObjectReference{Group: &backendGroup, Kind: &backendKind, Name: gwapiv1.ObjectName(GetBackendName("unique-id", types.EndpointConfiguration{Endpoint: types.EndpointURL("https://example.com/api")})), Port: &backendPort},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backendObjectReference := GenerateBackendObjectReference(CreateEndpointDetails("unique-id", types.EndpointConfiguration{Endpoint: tt.endpoint}))
+			assert.Equal(t, tt.expected, backendObjectReference)
+		})
+	}
+}
+
+func TestGetRuleName(t *testing.T) {
+	getEmployees := GetRuleName(types.Operation{Target: "/employees", Verb: "GET"})
+	assert.Regexp(t, `^get-[a-f0-9]{10}$`, getEmployees)
+	assert.Equal(t, getEmployees, GetRuleName(types.Operation{Target: "/employees", Verb: "GET"}))
+	assert.NotEqual(t, getEmployees, GetRuleName(types.Operation{Target: "/employee", Verb: "GET"}))
+	assert.Regexp(t, `^resource-[a-f0-9]{10}$`, GetRuleName(types.Operation{Target: "/employees"}))
+}
+
+func TestGetUniqueId(t *testing.T) {
+	apkConf := types.APKConf{Name: "EmployeeServiceAPI", Version: "3.14"}
+	organization := types.Organization{Name: "wso2"}
+	uniqueId := GetUniqueId(apkConf, organization)
+	assert.Regexp(t, `^[a-f0-9]{40}$`, uniqueId)
+	assert.Equal(t, uniqueId, GetUniqueId(apkConf, organization))
+	assert.NotEqual(t, uniqueId, GetUniqueId(apkConf, types.Organization{Name: "apk"}))
+	// The parts are separated, so that shifting characters between them changes the id
+	assert.NotEqual(t, GetUniqueId(types.APKConf{Name: "ab", Version: "c"}, organization), GetUniqueId(types.APKConf{Name: "a", Version: "bc"}, organization))
+
+	apkConf.ID = "employee-service-api"
+	assert.Equal(t, "employee-service-api", GetUniqueId(apkConf, organization))
+}
+
+func TestSplitRules(t *testing.T) {
+	var rules []string
+	for i := 0; i < 20; i++ {
+		rules = append(rules, "api-endpoint")
+	}
+	rules = append(rules, "operation-endpoint", "operation-endpoint")
+	groups := SplitRules(rules, func(rule string) string { return rule })
+	assert.Len(t, groups, 2)
+	assert.Len(t, groups[0], 16)
+	assert.Equal(t, []string{"api-endpoint", "api-endpoint", "api-endpoint", "api-endpoint", "operation-endpoint", "operation-endpoint"}, groups[1])
+
+	// Rules of the same key are moved to the next route when they do not fit
+	rules = append(rules[:14], "operation-endpoint", "operation-endpoint", "operation-endpoint", "api-endpoint")
+	groups = SplitRules(rules, func(rule string) string { return rule })
+	assert.Len(t, groups, 2)
+	assert.Len(t, groups[0], 15)
+	assert.Equal(t, []string{"operation-endpoint", "operation-endpoint", "operation-endpoint"}, groups[1])
+
+	assert.Empty(t, SplitRules([]string{}, func(rule string) string { return rule }))
+}
+
+func TestGetOperationPath(t *testing.T) {
+	apkConf := types.APKConf{
+		Operations: &[]types.Operation{
+			{Target: "/employees", Verb: "GET"},
+			{Target: "/employee", Verb: "POST"},
+		},
+	}
+	assert.Equal(t, "operations[1]", GetOperationPath(apkConf, types.Operation{Target: "/employee", Verb: "POST"}))
+	assert.Equal(t, "operations", GetOperationPath(apkConf, types.Operation{Target: "/*", Verb: "OPTIONS"}))
+}
+
+func TestGetInterceptorServiceName(t *testing.T) {
+	interceptor := types.InterceptorService{BackendURL: "https://interceptor-service:8443", HeadersEnabled: true}
+	requestName := GetInterceptorServiceName("unique-id", interceptor, true)
+	assert.Regexp(t, `^unique-id-request-interceptor-[a-f0-9]{10}$`, requestName)
+	assert.Regexp(t, `^unique-id-response-interceptor-[a-f0-9]{10}$`, GetInterceptorServiceName("unique-id", interceptor, false))
+	interceptor.BodyEnabled = true
+	assert.NotEqual(t, requestName, GetInterceptorServiceName("unique-id", interceptor, true))
+}
+
+func TestHasRouteRule(t *testing.T) {
+	operation := types.Operation{Target: "/employees", Verb: "GET"}
+	routes := []types.RouteReference{
+		{Kind: "HTTPRoute", Name: "other-route", RuleNames: []string{"post-0123456789"}},
+		{Kind: "HTTPRoute", Name: "sandbox-route", RuleNames: []string{GetRuleName(operation)}},
+	}
+
+	assert.True(t, HasRouteRule(routes, operation))
+	assert.False(t, HasRouteRule(routes[:1], operation))
+}
+
+func TestGetOperationExtensionRefs(t *testing.T) {
+	secured := false
+	interceptor := types.OperationPolicy{PolicyName: "Interceptor", Parameters: types.InterceptorService{BackendURL: "https://interceptor-service:8443"}}
+	operation := types.Operation{
+		Target:            "/employees",
+		Verb:              "GET",
+		Secured:           &secured,
+		RateLimit:         &types.RateLimit{RequestsPerUnit: 10, Unit: "Minute"},
+		OperationPolicies: &types.OperationPolicies{Request: []types.OperationPolicy{interceptor}},
+	}
+
+	extensionRefs := GetOperationExtensionRefs(types.APKConf{}, operation, "unique-id")
+	assert.Len(t, extensionRefs, 3)
+	assert.Equal(t, gwapiv1.Kind(constants.AUTHENTICATION_KIND), extensionRefs[0].Kind)
+	assert.Equal(t, gwapiv1.ObjectName("unique-id-"+GetRuleName(operation)+"-authentication"), extensionRefs[0].Name)
+	assert.Equal(t, gwapiv1.Kind(constants.RATELIMIT_POLICY_KIND), extensionRefs[1].Kind)
+	assert.Equal(t, gwapiv1.Kind(constants.API_POLICY_KIND), extensionRefs[2].Kind)
+
+	// The operation interceptors are ignored in favour of the API level policies
+	apkConf := types.APKConf{APIPolicies: &types.OperationPolicies{Request: []types.OperationPolicy{interceptor}}}
+	assert.Len(t, GetOperationExtensionRefs(apkConf, operation, "unique-id"), 2)
+
+	assert.Empty(t, GetOperationExtensionRefs(types.APKConf{}, types.Operation{Target: "/employees", Verb: "GET"}, "unique-id"))
+}
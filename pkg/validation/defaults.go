@@ -0,0 +1,317 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package validation
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/utils"
+)
+
+// supportedAPITypes holds the API types supported by APK.
+var supportedAPITypes = []string{
+	constants.API_TYPE_REST,
+	constants.API_TYPE_GRAPHQL,
+	constants.API_TYPE_GRPC,
+	constants.API_TYPE_ASYNC,
+	constants.API_TYPE_SOAP,
+	constants.API_TYPE_SSE,
+	constants.API_TYPE_WS,
+	constants.API_TYPE_WEBSUB,
+}
+
+// httpVerbs holds the operation verbs of the REST and SOAP APIs.
+var httpVerbs = []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS"}
+
+// graphQLVerbs holds the operation verbs of the GraphQL APIs.
+var graphQLVerbs = []string{constants.GRAPHQL_QUERY, constants.GRAPHQL_MUTATION, constants.GRAPHQL_SUBSCRIPTION}
+
+// asyncVerbs holds the operation verbs of the WebSocket APIs.
+var asyncVerbs = []string{constants.ASYNC_PUBLISH, constants.ASYNC_SUBSCRIBE}
+
+// subscribeVerbs holds the operation verbs of the SSE and WebSub APIs, which only deliver events to the subscribers.
+var subscribeVerbs = []string{constants.ASYNC_SUBSCRIBE}
+
+// pathAPITypes holds the API types whose operation targets are resource paths.
+var pathAPITypes = []string{constants.API_TYPE_REST, constants.API_TYPE_SOAP, constants.API_TYPE_WS, constants.API_TYPE_SSE}
+
+// httpProtocols holds the endpoint protocols of the APIs served over HTTP.
+var httpProtocols = []string{"http", "https"}
+
+// wsProtocols holds the endpoint protocols of the WebSocket APIs.
+var wsProtocols = []string{"ws", "wss"}
+
+// supportedAuthTypes holds the authentication types supported by APK.
+var supportedAuthTypes = []string{constants.AUTH_TYPE_OAUTH2, constants.AUTH_TYPE_API_KEY, constants.AUTH_TYPE_MTLS, constants.AUTH_TYPE_JWT}
+
+// validateAPIDetails validates the name, version, base path and type of the API.
+func (v *apkConfValidator) validateAPIDetails(apkConf types.APKConf) diagnostics.Diagnostics {
+	var diags diagnostics.Diagnostics
+	if apkConf.Name == "" {
+		diags.Errorf("name", "api name is required")
+	}
+	if apkConf.Version == "" {
+		diags.Errorf("version", "api version is required")
+	}
+	if apkConf.BasePath == "" {
+		diags.Errorf("basePath", "api base path is required")
+	} else if !strings.HasPrefix(apkConf.BasePath, "/") {
+		diags.Errorf("basePath", "api base path must start with \"/\": %q", apkConf.BasePath)
+	}
+	if apkConf.Type != "" && !slices.Contains(supportedAPITypes, apkConf.Type) {
+		diags.Errorf("type", "unsupported api type %q, expected one of %s", apkConf.Type, strings.Join(supportedAPITypes, ", "))
+	}
+	return diags
+}
+
+// validateEndpointConfigurations validates the production and sandbox endpoint configurations.
+func (v *apkConfValidator) validateEndpointConfigurations(endpointConfigs types.EndpointConfigurations, apiType string) diagnostics.Diagnostics {
+	var diags diagnostics.Diagnostics
+	for _, endpointType := range []string{constants.PRODUCTION_TYPE, constants.SANDBOX_TYPE} {
+		endpointConfig := utils.GetEndpointConfiguration(&endpointConfigs, endpointType)
+		if endpointConfig == nil {
+			continue
+		}
+		if endpointConfig.Endpoint == nil {
+			diags.Errorf(diagnostics.JoinPath(endpointType, "endpoint"), "%s endpoint is required", endpointType)
+		} else {
+			diags = append(diags, v.ValidateEndpoint(endpointConfig.Endpoint, apiType).WithPathPrefix(diagnostics.JoinPath(endpointType, "endpoint"))...)
+		}
+		certificate := endpointConfig.EndCertificate
+		if certificate.Name != "" && certificate.Key == "" {
+			diags.Errorf(diagnostics.JoinPath(endpointType, "certificate.secretKey"), "endpoint certificate secret key is required")
+		} else if certificate.Name == "" && certificate.Key != "" {
+			diags.Errorf(diagnostics.JoinPath(endpointType, "certificate.secretName"), "endpoint certificate secret name is required")
+		}
+		if endpointConfig.EndSecurity.Enabled && endpointConfig.EndSecurity.SecurityType.SecretName == "" {
+			diags.Errorf(diagnostics.JoinPath(endpointType, "endpointSecurity.securityType.secretName"), "secret name is required when the endpoint security is enabled")
+		}
+		if endpointConfig.AIRatelimit.Enabled {
+			diags = append(diags, v.ValidateAIRateLimit(endpointConfig.AIRatelimit).WithPathPrefix(diagnostics.JoinPath(endpointType, "aiRatelimit"))...)
+		}
+	}
+	return diags
+}
+
+// validateEndpoint validates the URL or the Kubernetes service of the endpoint.
+func (v *apkConfValidator) validateEndpoint(endpoint types.Endpoint, apiType string) diagnostics.Diagnostics {
+	var diags diagnostics.Diagnostics
+	switch e := endpoint.(type) {
+	case types.EndpointURL:
+		if utils.GetHost(e) == "" || utils.GetPort(string(e)) <= 0 {
+			diags.Errorf("", "invalid endpoint url: %q", string(e))
+		} else if protocols := getEndpointProtocols(apiType); !slices.Contains(protocols, utils.GetProtocol(string(e))) {
+			diags.Errorf("", "unsupported endpoint url scheme %q for %s APIs, expected %s", utils.GetProtocol(string(e)), apiType, strings.Join(protocols, " or "))
+		}
+	case types.K8sService:
+		if e.Name == "" {
+			diags.Errorf("name", "kubernetes service name is required")
+		}
+		if port, err := strconv.Atoi(e.Port); err != nil || port <= 0 || port > 65535 {
+			diags.Errorf("port", "invalid kubernetes service port: %q", e.Port)
+		}
+		if protocols := getEndpointProtocols(apiType); e.Protocol != "" && !slices.Contains(protocols, e.Protocol) {
+			diags.Errorf("protocol", "unsupported kubernetes service protocol %q, expected %s", e.Protocol, strings.Join(protocols, " or "))
+		}
+	}
+	return diags
+}
+
+// validateOperation validates the verb and target of the operation for the API type, along with its endpoints,
+// policies and rate limit.
+func (v *apkConfValidator) validateOperation(operation types.Operation, apiType string) diagnostics.Diagnostics {
+	var diags diagnostics.Diagnostics
+	if operation.Verb == "" {
+		diags.Errorf("verb", "operation verb is required")
+	} else if verbs := getOperationVerbs(apiType); verbs != nil && !slices.Contains(verbs, operation.Verb) {
+		diags.Errorf("verb", "unsupported verb %q for %s APIs, expected one of %s", operation.Verb, apiType, strings.Join(verbs, ", "))
+	}
+	if operation.Target == "" {
+		diags.Errorf("target", "operation target is required")
+	} else if slices.Contains(pathAPITypes, apiType) && !strings.HasPrefix(operation.Target, "/") {
+		diags.Errorf("target", "operation target must start with \"/\": %q", operation.Target)
+	}
+	if operation.EndpointConfigurations != nil {
+		diags = append(diags, v.ValidateEndpointConfigurations(*operation.EndpointConfigurations, apiType).WithPathPrefix("endpointConfigurations")...)
+	}
+	if operation.OperationPolicies != nil {
+		diags = append(diags, v.ValidateOperationPolicies(*operation.OperationPolicies).WithPathPrefix("operationPolicies")...)
+	}
+	if operation.RateLimit != nil {
+		diags = append(diags, v.ValidateRateLimit(*operation.RateLimit).WithPathPrefix("rateLimit")...)
+	}
+	return diags
+}
+
+// validateOperationPolicies validates the parameters of the request and response policies.
+// Request mirror and redirect policies are only allowed in the request flow.
+func (v *apkConfValidator) validateOperationPolicies(policies types.OperationPolicies) diagnostics.Diagnostics {
+	var diags diagnostics.Diagnostics
+	for _, flow := range []string{"request", "response"} {
+		flowPolicies := policies.Request
+		if flow == "response" {
+			flowPolicies = policies.Response
+		}
+		for i, policy := range flowPolicies {
+			policyPath := diagnostics.Index(flow, i)
+			switch parameters := policy.Parameters.(type) {
+			case nil:
+				diags.Errorf(diagnostics.JoinPath(policyPath, "parameters"), "parameters are required for the %s policy", policy.PolicyName)
+			case types.Header:
+				if parameters.HeaderName == "" {
+					diags.Errorf(diagnostics.JoinPath(policyPath, "parameters.headerName"), "header name is required")
+				}
+			case types.URLList:
+				if flow == "response" {
+					diags.Errorf(policyPath, "%s policy cannot be used as a response policy", policy.PolicyName)
+				}
+				if len(parameters.URLs) == 0 {
+					diags.Errorf(diagnostics.JoinPath(policyPath, "parameters.urls"), "at least one request mirror url is required")
+				}
+				for j, url := range parameters.URLs {
+					if !isHTTPURL(url) {
+						diags.Errorf(diagnostics.JoinPath(policyPath, diagnostics.Index("parameters.urls", j)), "invalid request mirror url: %q", url)
+					}
+				}
+			case types.RedirectPolicy:
+				if flow == "response" {
+					diags.Errorf(policyPath, "%s policy cannot be used as a response policy", policy.PolicyName)
+				}
+				if !isHTTPURL(parameters.URL) {
+					diags.Errorf(diagnostics.JoinPath(policyPath, "parameters.url"), "invalid request redirect url: %q", parameters.URL)
+				}
+				if parameters.StatusCode != 0 && parameters.StatusCode != 301 && parameters.StatusCode != 302 {
+					diags.Errorf(diagnostics.JoinPath(policyPath, "parameters.statusCode"), "unsupported redirect status code %d, expected 301 or 302", parameters.StatusCode)
+				}
+			case types.InterceptorService:
+				if !isHTTPURL(parameters.BackendURL) {
+					diags.Errorf(diagnostics.JoinPath(policyPath, "parameters.backendUrl"), "invalid interceptor backend url: %q", parameters.BackendURL)
+				}
+			case types.BackendJWT:
+				if flow == "response" {
+					diags.Errorf(policyPath, "%s policy cannot be used as a response policy", policy.PolicyName)
+				}
+				if _, ok := utils.GetBackendJWTEncoding(parameters.Encoding); parameters.Encoding != "" && !ok {
+					diags.Errorf(diagnostics.JoinPath(policyPath, "parameters.encoding"), "unsupported backend jwt encoding: %q", parameters.Encoding)
+				}
+				if _, ok := utils.GetBackendJWTSigningAlgorithm(parameters.SigningAlgorithm); parameters.SigningAlgorithm != "" && !ok {
+					diags.Errorf(diagnostics.JoinPath(policyPath, "parameters.signingAlgorithm"), "unsupported backend jwt signing algorithm: %q", parameters.SigningAlgorithm)
+				}
+				if parameters.TokenTTL < 0 {
+					diags.Errorf(diagnostics.JoinPath(policyPath, "parameters.tokenTTL"), "invalid backend jwt token ttl: %d", parameters.TokenTTL)
+				}
+			}
+		}
+	}
+	return diags
+}
+
+// validateRateLimit validates the requests count and time unit of the rate limit.
+func (v *apkConfValidator) validateRateLimit(rateLimit types.RateLimit) diagnostics.Diagnostics {
+	var diags diagnostics.Diagnostics
+	if rateLimit.RequestsPerUnit <= 0 {
+		diags.Errorf("requestsPerUnit", "invalid rate limit requests per unit: %d", rateLimit.RequestsPerUnit)
+	}
+	if _, ok := utils.GetRateLimitUnit(rateLimit.Unit); !ok {
+		diags.Errorf("unit", "invalid rate limit unit: %q", rateLimit.Unit)
+	}
+	return diags
+}
+
+// validateAIRateLimit validates the token and request count limits of the AI rate limit and their time units.
+func (v *apkConfValidator) validateAIRateLimit(aiRatelimit types.AIRatelimit) diagnostics.Diagnostics {
+	var diags diagnostics.Diagnostics
+	token := aiRatelimit.Token
+	request := aiRatelimit.Request
+	if token.PromptLimit < 0 || token.CompletionLimit < 0 || token.TotalLimit < 0 {
+		diags.Errorf("token", "ai token rate limits cannot be negative")
+	}
+	if request.RequestLimit < 0 {
+		diags.Errorf("request.requestLimit", "ai request rate limit cannot be negative")
+	}
+	hasTokenLimit := token.PromptLimit > 0 || token.CompletionLimit > 0 || token.TotalLimit > 0
+	if _, ok := utils.GetRateLimitUnit(token.Unit); hasTokenLimit && !ok {
+		diags.Errorf("token.unit", "invalid ai token rate limit unit: %q", token.Unit)
+	}
+	if _, ok := utils.GetRateLimitUnit(request.Unit); request.RequestLimit > 0 && !ok {
+		diags.Errorf("request.unit", "invalid ai request rate limit unit: %q", request.Unit)
+	}
+	if !hasTokenLimit && request.RequestLimit <= 0 {
+		diags.Errorf("", "ai rate limit is enabled without token or request limits")
+	}
+	return diags
+}
+
+// validateAuthentication validates the authentication type and its required configurations.
+func (v *apkConfValidator) validateAuthentication(authConfig types.AuthConfiguration) diagnostics.Diagnostics {
+	var diags diagnostics.Diagnostics
+	authType := ""
+	for _, supportedAuthType := range supportedAuthTypes {
+		if strings.EqualFold(authConfig.AuthType, supportedAuthType) {
+			authType = supportedAuthType
+		}
+	}
+	if authType == "" {
+		diags.Errorf("authType", "unsupported authentication type %q, expected one of %s", authConfig.AuthType, strings.Join(supportedAuthTypes, ", "))
+	}
+	if authConfig.Required != "" && authConfig.Required != "mandatory" && authConfig.Required != "optional" {
+		diags.Errorf("required", "unsupported authentication requirement %q, expected mandatory or optional", authConfig.Required)
+	}
+	if authType == constants.AUTH_TYPE_MTLS && utils.IsAuthenticationEnabled(authConfig) && len(authConfig.Certificates) == 0 {
+		diags.Errorf("certificates", "certificates are required for the mTLS authentication")
+	}
+	return diags
+}
+
+// getOperationVerbs returns the operation verbs supported by the API type, or nil when any verb is accepted.
+func getOperationVerbs(apiType string) []string {
+	switch apiType {
+	case constants.API_TYPE_REST, constants.API_TYPE_SOAP:
+		return httpVerbs
+	case constants.API_TYPE_GRAPHQL:
+		return graphQLVerbs
+	case constants.API_TYPE_WS:
+		return asyncVerbs
+	case constants.API_TYPE_SSE, constants.API_TYPE_WEBSUB:
+		return subscribeVerbs
+	}
+	return nil
+}
+
+// getEndpointProtocols returns the endpoint protocols supported by the API type.
+func getEndpointProtocols(apiType string) []string {
+	if apiType == constants.API_TYPE_WS {
+		return wsProtocols
+	}
+	return httpProtocols
+}
+
+// isHTTPURL reports whether the URL is a valid http or https URL.
+func isHTTPURL(url string) bool {
+	return utils.GetHost(types.EndpointURL(url)) != "" && slices.Contains(httpProtocols, utils.GetProtocol(url))
+}
+
+// hasEndpoint reports whether the endpoint configurations hold a production or sandbox endpoint.
+func hasEndpoint(endpointConfigs *types.EndpointConfigurations) bool {
+	return utils.HasEndpoint(endpointConfigs, constants.PRODUCTION_TYPE) || utils.HasEndpoint(endpointConfigs, constants.SANDBOX_TYPE)
+}
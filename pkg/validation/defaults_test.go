@@ -0,0 +1,206 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func TestValidateAPIDetails(t *testing.T) {
+	tests := []struct {
+		name    string
+		apkConf types.APKConf
+		paths   []string
+	}{
+		{"Valid API", types.APKConf{Name: "API", Version: "1.0", BasePath: "/api", Type: "GRAPHQL"}, nil},
+		{"Default type", types.APKConf{Name: "API", Version: "1.0", BasePath: "/api"}, nil},
+		{"Missing details", types.APKConf{}, []string{"name", "version", "basePath"}},
+		{"Relative base path", types.APKConf{Name: "API", Version: "1.0", BasePath: "api"}, []string{"basePath"}},
+		{"Unsupported type", types.APKConf{Name: "API", Version: "1.0", BasePath: "/api", Type: "rest"}, []string{"type"}},
+	}
+
+	v := Validator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.paths, getPaths(v.ValidateAPIDetails(tt.apkConf)))
+		})
+	}
+}
+
+func TestValidateEndpointConfigurations(t *testing.T) {
+	endpointConfigs := types.EndpointConfigurations{
+		Production: &types.EndpointConfiguration{
+			EndCertificate: types.EndpointCertificate{Name: "backend-cert"},
+			EndSecurity:    types.EndpointSecurity{Enabled: true},
+		},
+		Sandbox: &types.EndpointConfiguration{
+			Endpoint:    types.EndpointURL("http://sandbox.example.com"),
+			AIRatelimit: types.AIRatelimit{Enabled: true},
+		},
+	}
+
+	v := Validator()
+	assert.Equal(t, []string{
+		"production.endpoint",
+		"production.certificate.secretKey",
+		"production.endpointSecurity.securityType.secretName",
+		"sandbox.aiRatelimit",
+	}, getPaths(v.ValidateEndpointConfigurations(endpointConfigs, "REST")))
+}
+
+func TestValidateEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint types.Endpoint
+		apiType  string
+		paths    []string
+	}{
+		{"Valid URL", types.EndpointURL("https://backend.example.com/api"), "REST", nil},
+		{"URL without scheme", types.EndpointURL("backend.example.com"), "REST", []string{""}},
+		{"URL with invalid port", types.EndpointURL("http://backend.example.com:port"), "REST", []string{""}},
+		{"WebSocket URL for REST", types.EndpointURL("ws://backend.example.com"), "REST", []string{""}},
+		{"Valid WebSocket URL", types.EndpointURL("wss://backend.example.com/notifications"), "WS", nil},
+		{"HTTP URL for WebSocket", types.EndpointURL("http://backend.example.com"), "WS", []string{""}},
+		{"Valid service", types.K8sService{Name: "backend", Namespace: "apk", Port: "8080", Protocol: "http"}, "REST", nil},
+		{"Valid WebSocket service", types.K8sService{Name: "backend", Namespace: "apk", Port: "8080", Protocol: "ws"}, "WS", nil},
+		{"Invalid service", types.K8sService{Port: "0", Protocol: "tcp"}, "REST", []string{"name", "port", "protocol"}},
+	}
+
+	v := Validator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.paths, getPaths(v.ValidateEndpoint(tt.endpoint, tt.apiType)))
+		})
+	}
+}
+
+func TestValidateOperation(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation types.Operation
+		apiType   string
+		paths     []string
+	}{
+		{"Valid REST operation", types.Operation{Target: "/employees", Verb: "GET"}, "REST", nil},
+		{"Missing verb and target", types.Operation{}, "REST", []string{"verb", "target"}},
+		{"Lower case REST verb", types.Operation{Target: "/employees", Verb: "get"}, "REST", []string{"verb"}},
+		{"Relative REST target", types.Operation{Target: "employees", Verb: "GET"}, "REST", []string{"target"}},
+		{"Valid GraphQL operation", types.Operation{Target: "employees", Verb: "QUERY"}, "GRAPHQL", nil},
+		{"HTTP verb for GraphQL", types.Operation{Target: "employees", Verb: "GET"}, "GRAPHQL", []string{"verb"}},
+		{"Valid WebSocket operation", types.Operation{Target: "/notifications", Verb: "SUBSCRIBE"}, "WS", nil},
+		{"HTTP verb for WebSocket", types.Operation{Target: "/notifications", Verb: "GET"}, "WS", []string{"verb"}},
+		{"Valid SSE operation", types.Operation{Target: "/events", Verb: "SUBSCRIBE"}, "SSE", nil},
+		{"Publish for SSE", types.Operation{Target: "/events", Verb: "PUBLISH"}, "SSE", []string{"verb"}},
+		{"Valid WebSub topic", types.Operation{Target: "_issues", Verb: "SUBSCRIBE"}, "WEBSUB", nil},
+		{"Valid gRPC operation", types.Operation{Target: "org.apk.EmployeeService", Verb: "GetEmployee"}, "GRPC", nil},
+		{"Invalid rate limit", types.Operation{Target: "/employees", Verb: "GET", RateLimit: &types.RateLimit{Unit: "Minute"}}, "REST", []string{"rateLimit.requestsPerUnit"}},
+		{
+			"Invalid policies",
+			types.Operation{Target: "/employees", Verb: "GET", OperationPolicies: &types.OperationPolicies{
+				Request: []types.OperationPolicy{{PolicyName: "AddHeader", Parameters: types.Header{}}},
+			}},
+			"REST",
+			[]string{"operationPolicies.request[0].parameters.headerName"},
+		},
+	}
+
+	v := Validator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.paths, getPaths(v.ValidateOperation(tt.operation, tt.apiType)))
+		})
+	}
+}
+
+func TestValidateOperationPolicies(t *testing.T) {
+	policies := types.OperationPolicies{
+		Request: []types.OperationPolicy{
+			{PolicyName: "RequestMirror", Parameters: types.URLList{URLs: []string{"http://mirror.example.com", "mirror"}}},
+			{PolicyName: "RequestRedirect", Parameters: types.RedirectPolicy{URL: "http://redirect.example.com", StatusCode: 307}},
+			{PolicyName: "Interceptor", Parameters: types.InterceptorService{BackendURL: "interceptor"}},
+			{PolicyName: "BackendJwt", Parameters: types.BackendJWT{Encoding: "base64", SigningAlgorithm: "HS256", TokenTTL: -1}},
+			{PolicyName: "SetHeader"},
+		},
+		Response: []types.OperationPolicy{
+			{PolicyName: "RequestMirror", Parameters: types.URLList{URLs: []string{"http://mirror.example.com"}}},
+			{PolicyName: "RemoveHeader", Parameters: types.Header{HeaderName: "X-Internal"}},
+		},
+	}
+
+	v := Validator()
+	assert.Equal(t, []string{
+		"request[0].parameters.urls[1]",
+		"request[1].parameters.statusCode",
+		"request[2].parameters.backendUrl",
+		"request[3].parameters.signingAlgorithm",
+		"request[3].parameters.tokenTTL",
+		"request[4].parameters",
+		"response[0]",
+	}, getPaths(v.ValidateOperationPolicies(policies)))
+}
+
+func TestValidateRateLimit(t *testing.T) {
+	v := Validator()
+	assert.Empty(t, v.ValidateRateLimit(types.RateLimit{RequestsPerUnit: 5, Unit: "hour"}))
+	assert.Equal(t, []string{"requestsPerUnit", "unit"}, getPaths(v.ValidateRateLimit(types.RateLimit{Unit: "Week"})))
+}
+
+func TestValidateAIRateLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		aiRatelimit types.AIRatelimit
+		paths       []string
+	}{
+		{"Token limits", types.AIRatelimit{Enabled: true, Token: types.TokenAIRL{TotalLimit: 1000, Unit: "Minute"}}, nil},
+		{"Request limits", types.AIRatelimit{Enabled: true, Request: types.RequestAIRL{RequestLimit: 10, Unit: "Day"}}, nil},
+		{"Invalid units", types.AIRatelimit{Enabled: true, Token: types.TokenAIRL{PromptLimit: 10}, Request: types.RequestAIRL{RequestLimit: 10, Unit: "Week"}}, []string{"token.unit", "request.unit"}},
+		{"Negative limits", types.AIRatelimit{Enabled: true, Token: types.TokenAIRL{TotalLimit: -1}, Request: types.RequestAIRL{RequestLimit: -1}}, []string{"token", "request.requestLimit", ""}},
+	}
+
+	v := Validator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.paths, getPaths(v.ValidateAIRateLimit(tt.aiRatelimit)))
+		})
+	}
+}
+
+func TestValidateAuthentication(t *testing.T) {
+	tests := []struct {
+		name       string
+		authConfig types.AuthConfiguration
+		paths      []string
+	}{
+		{"OAuth2", types.AuthConfiguration{AuthType: "OAuth2", Enabled: ptr.To(true), Required: "mandatory"}, nil},
+		{"Lower case API key", types.AuthConfiguration{AuthType: "apikey", Enabled: ptr.To(true)}, nil},
+		{"Unsupported type", types.AuthConfiguration{AuthType: "Basic", Required: "always"}, []string{"authType", "required"}},
+		{"mTLS without certificates", types.AuthConfiguration{AuthType: "mTLS", Enabled: ptr.To(true)}, []string{"certificates"}},
+	}
+
+	v := Validator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.paths, getPaths(v.ValidateAuthentication(tt.authConfig)))
+		})
+	}
+}
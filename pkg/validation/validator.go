@@ -0,0 +1,110 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package validation
+
+import (
+	"strings"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/constants"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
+)
+
+// apkConfValidator is the interface for the APK configuration validator.
+type apkConfValidator struct {
+	ValidateAPIDetails             func(apkConf types.APKConf) diagnostics.Diagnostics
+	ValidateEndpointConfigurations func(endpointConfigs types.EndpointConfigurations, apiType string) diagnostics.Diagnostics
+	ValidateEndpoint               func(endpoint types.Endpoint, apiType string) diagnostics.Diagnostics
+	ValidateOperation              func(operation types.Operation, apiType string) diagnostics.Diagnostics
+	ValidateOperationPolicies      func(policies types.OperationPolicies) diagnostics.Diagnostics
+	ValidateRateLimit              func(rateLimit types.RateLimit) diagnostics.Diagnostics
+	ValidateAIRateLimit            func(aiRatelimit types.AIRatelimit) diagnostics.Diagnostics
+	ValidateAuthentication         func(authConfig types.AuthConfiguration) diagnostics.Diagnostics
+}
+
+// Validator creates a new APK configuration validator.
+func Validator() *apkConfValidator {
+	v := &apkConfValidator{}
+	v.ValidateAPIDetails = v.validateAPIDetails
+	v.ValidateEndpointConfigurations = v.validateEndpointConfigurations
+	v.ValidateEndpoint = v.validateEndpoint
+	v.ValidateOperation = v.validateOperation
+	v.ValidateOperationPolicies = v.validateOperationPolicies
+	v.ValidateRateLimit = v.validateRateLimit
+	v.ValidateAIRateLimit = v.validateAIRateLimit
+	v.ValidateAuthentication = v.validateAuthentication
+	return v
+}
+
+// Validate validates the APK configuration against the rules of its API type and returns every problem found,
+// located by the field path in the APK configuration, e.g. operations[2].verb.
+func (v *apkConfValidator) Validate(apkConf types.APKConf) diagnostics.Diagnostics {
+	diags := v.ValidateAPIDetails(apkConf)
+	apiType := apkConf.Type
+	if apiType == "" {
+		apiType = constants.API_TYPE_REST
+	}
+
+	if apkConf.EndpointConfigurations != nil {
+		diags = append(diags, v.ValidateEndpointConfigurations(*apkConf.EndpointConfigurations, apiType).WithPathPrefix("endpointConfigurations")...)
+	}
+	hasAPIEndpoint := hasEndpoint(apkConf.EndpointConfigurations)
+
+	var operations []types.Operation
+	if apkConf.Operations != nil {
+		operations = *apkConf.Operations
+	}
+	if len(operations) == 0 && !hasAPIEndpoint {
+		diags.Errorf("endpointConfigurations", "no production or sandbox endpoint specified for the API")
+	}
+	operationPaths := make(map[string]string)
+	for i, operation := range operations {
+		operationPath := diagnostics.Index("operations", i)
+		diags = append(diags, v.ValidateOperation(operation, apiType).WithPathPrefix(operationPath)...)
+		if operation.Verb != "" && operation.Target != "" {
+			key := strings.ToUpper(operation.Verb) + " " + operation.Target
+			if definedPath, ok := operationPaths[key]; ok {
+				diags.Errorf(operationPath, "duplicate operation %s %s, already defined at %s", operation.Verb, operation.Target, definedPath)
+			} else {
+				operationPaths[key] = operationPath
+			}
+		}
+		if !hasAPIEndpoint && !hasEndpoint(operation.EndpointConfigurations) {
+			diags.Errorf(diagnostics.JoinPath(operationPath, "endpointConfigurations"), "no production or sandbox endpoint specified for the operation or the API")
+		}
+		if apkConf.RateLimit != nil && operation.RateLimit != nil {
+			diags.Errorf(diagnostics.JoinPath(operationPath, "rateLimit"), "api level and operation level rate limits cannot be used together")
+		}
+	}
+
+	if apkConf.RateLimit != nil {
+		diags = append(diags, v.ValidateRateLimit(*apkConf.RateLimit).WithPathPrefix("rateLimit")...)
+	}
+	if apkConf.APIPolicies != nil {
+		diags = append(diags, v.ValidateOperationPolicies(*apkConf.APIPolicies).WithPathPrefix("apiPolicies")...)
+	}
+	if apkConf.Authentication != nil {
+		for i, authConfig := range *apkConf.Authentication {
+			diags = append(diags, v.ValidateAuthentication(authConfig).WithPathPrefix(diagnostics.Index("authentication", i))...)
+		}
+	}
+	if apkConf.AIProvider != nil && apkConf.AIProvider.Name == "" {
+		diags.Errorf("aiProvider.name", "ai provider name is required")
+	}
+	return diags
+}
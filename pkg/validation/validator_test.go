@@ -0,0 +1,103 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/config/types"
+	"github.com/terance-edmonds/wso2-apk-k8s-go-lib/pkg/diagnostics"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+// getPaths returns the paths of the given diagnostics.
+func getPaths(diags diagnostics.Diagnostics) []string {
+	var paths []string
+	for _, diagnostic := range diags {
+		paths = append(paths, diagnostic.Path)
+	}
+	return paths
+}
+
+// TestValidator test for Validator
+func TestValidator(t *testing.T) {
+	apkConf := types.APKConf{
+		Name:     "EmployeeServiceAPI",
+		Version:  "3.14",
+		BasePath: "/employees-info",
+		Type:     "REST",
+		EndpointConfigurations: &types.EndpointConfigurations{
+			Production: &types.EndpointConfiguration{
+				Endpoint: types.EndpointURL("https://backend.example.com:8443/api"),
+			},
+		},
+		Operations: &[]types.Operation{
+			{Target: "/employees", Verb: "GET", Secured: ptr.To(true)},
+			{Target: "/employee", Verb: "POST", Secured: ptr.To(true), RateLimit: &types.RateLimit{RequestsPerUnit: 5, Unit: "Minute"}},
+		},
+		Authentication: &[]types.AuthConfiguration{
+			{AuthType: "OAuth2", Enabled: ptr.To(true)},
+		},
+	}
+
+	v := Validator()
+
+	diags := v.Validate(apkConf)
+	assert.Empty(t, diags)
+
+	// Every problem is reported at once
+	apkConf.Type = "RPC"
+	(*apkConf.Operations)[0].Verb = ""
+	(*apkConf.Operations)[1].Target = "/employees"
+	(*apkConf.Operations)[1].Verb = "GET"
+	(*apkConf.Operations)[1].RateLimit.Unit = "Week"
+	(*apkConf.Authentication)[0].AuthType = "Basic"
+	apkConf.EndpointConfigurations.Production.Endpoint = types.EndpointURL("backend")
+	diags = v.Validate(apkConf)
+	assert.True(t, diags.HasErrors())
+	assert.ElementsMatch(t, []string{
+		"type",
+		"endpointConfigurations.production.endpoint",
+		"operations[0].verb",
+		"operations[1].rateLimit.unit",
+		"authentication[0].authType",
+	}, getPaths(diags))
+
+	// Duplicate operations
+	apkConf.Type = "REST"
+	(*apkConf.Operations)[0].Verb = "GET"
+	diags = v.Validate(apkConf)
+	assert.Contains(t, getPaths(diags), "operations[1]")
+
+	// Missing production and sandbox endpoints
+	apkConf.EndpointConfigurations = nil
+	diags = v.Validate(apkConf)
+	assert.Contains(t, getPaths(diags), "operations[0].endpointConfigurations")
+	assert.Contains(t, getPaths(diags), "operations[1].endpointConfigurations")
+
+	// Overridden validation
+	v.ValidateOperation = func(operation types.Operation, apiType string) diagnostics.Diagnostics {
+		return nil
+	}
+	diags = v.Validate(types.APKConf{Name: "API", Version: "1.0", BasePath: "/api", Operations: &[]types.Operation{
+		{Verb: "FETCH", EndpointConfigurations: &types.EndpointConfigurations{Sandbox: &types.EndpointConfiguration{Endpoint: types.EndpointURL("http://backend")}}},
+	}})
+	assert.Empty(t, diags)
+}